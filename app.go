@@ -2,13 +2,21 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 
 	"ThinkTimerV2/internal/database"
+	"ThinkTimerV2/internal/idle"
 	"ThinkTimerV2/internal/models"
 	"ThinkTimerV2/internal/services"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 type App struct {
@@ -17,6 +25,21 @@ type App struct {
 	projectService   *services.ProjectService
 	timeBlockService *services.TimeBlockService
 	settingsService  *services.SettingsService
+	timerService     *services.TimerService
+	pomodoroService  *services.PomodoroService
+	tagService       *services.TagService
+	billingService   *services.BillingService
+	clientService    *services.ClientService
+	fieldService     *services.CustomFieldService
+	milestoneService *services.MilestoneService
+	reportService    *services.ReportService
+	timesheetService *services.TimesheetService
+	taskService      *services.TaskService
+	budgetService    *services.BudgetService
+	trashService     *services.TrashService
+	undoService      *services.UndoService
+	commandMu        sync.Mutex // Serializes recorded commands with undo and redo
+	idleSource       idle.Source
 }
 
 func NewApp() *App {
@@ -36,11 +59,127 @@ func (a *App) startup(ctx context.Context) {
 
 	conn := db.GetConnection()
 	a.projectService = services.NewProjectService(conn)
-	a.timeBlockService = services.NewTimeBlockService(conn)
 	a.settingsService = services.NewSettingsService(conn)
+	a.timeBlockService = services.NewTimeBlockService(conn, a.settingsService)
+	a.timerService = services.NewTimerService(conn, a.timeBlockService)
+	a.pomodoroService = services.NewPomodoroService(conn, a.timerService, a.settingsService)
+	a.tagService = services.NewTagService(conn)
+	a.billingService = services.NewBillingService(conn, a.settingsService)
+	a.clientService = services.NewClientService(conn)
+	a.fieldService = services.NewCustomFieldService(conn)
+	a.milestoneService = services.NewMilestoneService(conn, a.settingsService)
+	a.reportService = services.NewReportService(conn)
+	a.timesheetService = services.NewTimesheetService(conn, a.settingsService)
+	a.taskService = services.NewTaskService(conn)
+	a.budgetService = services.NewBudgetService(conn, a.settingsService)
+	a.trashService = services.NewTrashService(conn, a.settingsService, a.timeBlockService)
+	a.undoService = services.NewUndoService(conn)
+
+	// Bring durations written before the duration rule existed back in line, once
+	err = db.RunOnce("repair_time_block_durations", func() error {
+		_, err := a.timeBlockService.RepairDurations()
+		return err
+	})
+	if err != nil {
+		println("Time block duration repair error:", err.Error())
+	}
+
+	// Blocks still open at this point were left behind by a crash or sleep
+	if _, err := a.timerService.DetectOrphanedTimeBlocks(); err != nil {
+		println("Orphaned time block detection error:", err.Error())
+	}
+
+	// Items that outlived the trash retention period are removed for good
+	if _, err := a.trashService.PurgeExpired(); err != nil {
+		println("Trash purge error:", err.Error())
+	}
+
+	a.idleSource = idle.NewSource()
+
+	go a.runHeartbeat(ctx)
+	go a.runIdleMonitor(ctx)
+	go a.runPomodoro(ctx)
+}
+
+// runHeartbeat periodically records that the running timer is alive, so a crash can be recovered accurately,
+// checks whether the tracked time pushed a budget over its alert threshold and announces upcoming deadlines
+func (a *App) runHeartbeat(ctx context.Context) {
+	ticker := time.NewTicker(services.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.timerService.Heartbeat(); err != nil {
+				println("Timer heartbeat error:", err.Error())
+			}
+			a.checkBudgets()
+			a.checkDeadlines()
+		}
+	}
+}
+
+// runIdleMonitor polls the idle source and tells the frontend when the user walked away from a running timer
+func (a *App) runIdleMonitor(ctx context.Context) {
+	ticker := time.NewTicker(services.IdleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			settings, err := a.settingsService.GetSettings()
+			if err != nil || settings.IdleThresholdMinutes <= 0 {
+				continue
+			}
+
+			idleTime, err := a.idleSource.IdleTime()
+			if err != nil {
+				continue
+			}
+
+			threshold := time.Duration(settings.IdleThresholdMinutes) * time.Minute
+			period, err := a.timerService.CheckIdle(idleTime, threshold)
+			if err != nil {
+				println("Idle check error:", err.Error())
+				continue
+			}
+			if period != nil {
+				wailsRuntime.EventsEmit(a.ctx, "timer:idle", period)
+			}
+		}
+	}
+}
+
+// runPomodoro advances the Pomodoro schedule and tells the frontend when a phase changes
+func (a *App) runPomodoro(ctx context.Context) {
+	ticker := time.NewTicker(services.PomodoroTickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			unlock := a.exclusive()
+			changed, state, err := a.pomodoroService.Tick()
+			unlock()
+			if err != nil {
+				println("Pomodoro tick error:", err.Error())
+				continue
+			}
+			if changed {
+				a.emitPomodoroState(state)
+			}
+		}
+	}
 }
 
 func (a *App) CreateProject(req models.CreateProjectRequest) (*models.Project, error) {
+	defer a.record("Create project")()
 	return a.projectService.CreateProject(req)
 }
 
@@ -53,42 +192,250 @@ func (a *App) GetProjectByID(id int) (*models.Project, error) {
 }
 
 func (a *App) UpdateProject(id int, req models.UpdateProjectRequest) (*models.Project, error) {
-	return a.projectService.UpdateProject(id, req)
+	defer a.record("Edit project")()
+	project, err := a.projectService.UpdateProject(id, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.EstimatedHours != nil {
+		a.checkBudgets()
+	}
+	return project, nil
+}
+
+// GetArchivedProjects returns the projects hidden from GetAllProjects by archiving
+func (a *App) GetArchivedProjects() ([]models.Project, error) {
+	return a.projectService.GetArchivedProjects()
+}
+
+// ArchiveProject hides a project from the project list; its time blocks stay in reports
+func (a *App) ArchiveProject(id int) (*models.Project, error) {
+	defer a.record("Archive project")()
+	return a.projectService.ArchiveProject(id)
+}
+
+// RestoreProject brings an archived project back with its previous status
+func (a *App) RestoreProject(id int) (*models.Project, error) {
+	defer a.record("Restore project")()
+	return a.projectService.RestoreProject(id)
 }
 
+// DeleteProject moves a project and its time blocks to the trash
 func (a *App) DeleteProject(id int) error {
-	return a.projectService.DeleteProject(id)
+	defer a.record("Delete project")()
+	if err := a.projectService.DeleteProject(id); err != nil {
+		return err
+	}
+	a.refreshTimerState()
+	return nil
 }
 
 func (a *App) UpdateProjectsOrder(projectOrders map[int]int) error {
+	defer a.record("Reorder projects")()
 	return a.projectService.UpdateProjectsOrder(projectOrders)
 }
 
+// CreateProjectLink adds a link to a project
+func (a *App) CreateProjectLink(req models.CreateProjectLinkRequest) (*models.ProjectLink, error) {
+	defer a.record("Add project link")()
+	return a.projectService.CreateProjectLink(req)
+}
+
+// GetProjectLinks returns the links of a project in display order
+func (a *App) GetProjectLinks(projectID int) ([]models.ProjectLink, error) {
+	return a.projectService.GetProjectLinks(projectID)
+}
+
+func (a *App) UpdateProjectLink(id int, req models.UpdateProjectLinkRequest) (*models.ProjectLink, error) {
+	defer a.record("Update project link")()
+	return a.projectService.UpdateProjectLink(id, req)
+}
+
+func (a *App) DeleteProjectLink(id int) error {
+	defer a.record("Delete project link")()
+	return a.projectService.DeleteProjectLink(id)
+}
+
+func (a *App) UpdateProjectLinksOrder(linkOrders map[int]int) error {
+	defer a.record("Reorder project links")()
+	return a.projectService.UpdateProjectLinksOrder(linkOrders)
+}
+
+// DuplicateProject copies a project with its links, tags, tasks and custom field values, but not its time blocks
+func (a *App) DuplicateProject(id int) (*models.Project, error) {
+	defer a.record("Duplicate project")()
+	return a.projectService.DuplicateProject(id)
+}
+
+// SaveProjectAsTemplate saves a project as a named template for new projects
+func (a *App) SaveProjectAsTemplate(projectID int, name string) (*models.ProjectTemplate, error) {
+	defer a.record("Save project template")()
+	return a.projectService.SaveProjectAsTemplate(projectID, name)
+}
+
+func (a *App) GetAllProjectTemplates() ([]models.ProjectTemplate, error) {
+	return a.projectService.GetAllProjectTemplates()
+}
+
+func (a *App) DeleteProjectTemplate(id int) error {
+	defer a.record("Delete project template")()
+	return a.projectService.DeleteProjectTemplate(id)
+}
+
+// CreateProjectFromTemplate creates a project from a template; an empty name takes the template's name
+func (a *App) CreateProjectFromTemplate(templateID int, name string) (*models.Project, error) {
+	defer a.record("Create project from template")()
+	return a.projectService.CreateProjectFromTemplate(templateID, name)
+}
+
+// CreateMilestone adds a milestone to a project
+func (a *App) CreateMilestone(req models.CreateMilestoneRequest) (*models.Milestone, error) {
+	defer a.record("Add milestone")()
+	return a.milestoneService.CreateMilestone(req)
+}
+
+// GetProjectMilestones returns the milestones of a project by due date
+func (a *App) GetProjectMilestones(projectID int) ([]models.Milestone, error) {
+	return a.milestoneService.GetProjectMilestones(projectID)
+}
+
+func (a *App) UpdateMilestone(id int, req models.UpdateMilestoneRequest) (*models.Milestone, error) {
+	defer a.record("Update milestone")()
+	return a.milestoneService.UpdateMilestone(id, req)
+}
+
+func (a *App) DeleteMilestone(id int) error {
+	defer a.record("Delete milestone")()
+	return a.milestoneService.DeleteMilestone(id)
+}
+
+// GetDeadlinesByDateRange returns the project deadlines and milestones due in the date range, for the calendar
+func (a *App) GetDeadlinesByDateRange(startDate, endDate time.Time) ([]models.Deadline, error) {
+	return a.milestoneService.GetDeadlinesByDateRange(startDate, endDate)
+}
+
+func (a *App) CreateTask(req models.CreateTaskRequest) (*models.Task, error) {
+	defer a.record("Create task")()
+	return a.taskService.CreateTask(req)
+}
+
+// GetTaskTree returns the tasks of a project with durations rolled up at every level
+func (a *App) GetTaskTree(projectID int) (*models.TaskTree, error) {
+	return a.taskService.GetTaskTree(projectID)
+}
+
+func (a *App) UpdateTask(id int, req models.UpdateTaskRequest) (*models.Task, error) {
+	defer a.record("Edit task")()
+	task, err := a.taskService.UpdateTask(id, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.EstimatedHours != nil {
+		a.checkBudgets()
+	}
+	return task, nil
+}
+
+// DeleteTask deletes a task and its subtasks, keeping their time blocks on the project
+func (a *App) DeleteTask(id int) error {
+	defer a.record("Delete task")()
+	return a.taskService.DeleteTask(id)
+}
+
+func (a *App) UpdateTasksOrder(taskOrders map[int]int) error {
+	defer a.record("Reorder tasks")()
+	return a.taskService.UpdateTasksOrder(taskOrders)
+}
+
 func (a *App) CreateTimeBlock(req models.CreateTimeBlockRequest) (*models.TimeBlock, error) {
-	return a.timeBlockService.CreateTimeBlock(req)
+	defer a.record("Create time block")()
+	timeBlock, err := a.timeBlockService.CreateTimeBlock(req)
+	if err != nil {
+		return nil, err
+	}
+	a.checkBudgets()
+	return timeBlock, nil
 }
 
 func (a *App) GetTimeBlocksByDate(date time.Time) ([]models.TimeBlock, error) {
 	return a.timeBlockService.GetTimeBlocksByDate(date)
 }
 
-func (a *App) GetTimeBlocksByDateRange(startDate, endDate time.Time) ([]models.TimeBlock, error) {
-	return a.timeBlockService.GetTimeBlocksByDateRange(startDate, endDate)
+func (a *App) GetTimeBlocksByDateRange(startDate, endDate time.Time, filter *models.TimeBlockFilter) ([]models.TimeBlock, error) {
+	return a.timeBlockService.GetTimeBlocksByDateRange(startDate, endDate, filter)
 }
 
 func (a *App) UpdateTimeBlock(id int, req models.UpdateTimeBlockRequest) (*models.TimeBlock, error) {
-	return a.timeBlockService.UpdateTimeBlock(id, req)
+	defer a.record("Edit time block")()
+	timeBlock, err := a.timeBlockService.UpdateTimeBlock(id, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.ProjectID != nil {
+		a.refreshTimerState()
+	}
+	a.checkBudgets()
+	return timeBlock, nil
+}
+
+// RepairTimeBlockDurations recomputes inconsistent time block durations and returns how many were fixed
+func (a *App) RepairTimeBlockDurations() (int, error) {
+	defer a.record("Repair durations")()
+	return a.timeBlockService.RepairDurations()
 }
 
+// MoveTimeBlocks reassigns several time blocks to another project
+func (a *App) MoveTimeBlocks(req models.MoveTimeBlocksRequest) ([]models.TimeBlock, error) {
+	defer a.record("Move time blocks")()
+	timeBlocks, err := a.timeBlockService.MoveTimeBlocks(req)
+	if err != nil {
+		return nil, err
+	}
+	a.refreshTimerState()
+	a.checkBudgets()
+	return timeBlocks, nil
+}
+
+// ResolveTimeBlockOverlaps saves a time block and trims, splits or merges the blocks it overlaps
+func (a *App) ResolveTimeBlockOverlaps(req models.ResolveOverlapRequest) (*models.TimeBlock, error) {
+	defer a.record("Resolve overlap")()
+	return a.timeBlockService.ResolveOverlaps(req)
+}
+
+// SplitTimeBlock splits a time block at the given time, optionally moving the second part to another project
+func (a *App) SplitTimeBlock(id int, at time.Time, newProjectID int) ([]models.TimeBlock, error) {
+	defer a.record("Split time block")()
+	return a.timeBlockService.SplitTimeBlock(id, at, newProjectID)
+}
+
+// MergeTimeBlocks merges time blocks of one project into a single block
+func (a *App) MergeTimeBlocks(ids []int) (*models.TimeBlock, error) {
+	defer a.record("Merge time blocks")()
+	return a.timeBlockService.MergeTimeBlocks(ids)
+}
+
+// DeleteTimeBlock moves a time block to the trash
 func (a *App) DeleteTimeBlock(id int) error {
-	return a.timeBlockService.DeleteTimeBlock(id)
+	defer a.record("Delete time block")()
+	if err := a.timeBlockService.DeleteTimeBlock(id); err != nil {
+		return err
+	}
+	a.refreshTimerState()
+	return nil
+}
+
+// GetTimeBlockHistory returns the audit trail of a time block: every create, edit, deletion and restore
+func (a *App) GetTimeBlockHistory(id int) ([]models.TimeBlockChange, error) {
+	return a.timeBlockService.GetTimeBlockHistory(id)
 }
 
 func (a *App) StopRunningTimeBlock(id int) (*models.TimeBlock, error) {
+	defer a.record("Stop time block")()
 	return a.timeBlockService.StopRunningTimeBlock(id)
 }
 
 func (a *App) StopTimeBlockWithDuration(id int, duration int) (*models.TimeBlock, error) {
+	defer a.record("Stop time block")()
 	return a.timeBlockService.StopTimeBlockWithDuration(id, duration)
 }
 
@@ -96,11 +443,431 @@ func (a *App) GetTotalDurationByProject(projectID int) (int, error) {
 	return a.timeBlockService.GetTotalDurationByProject(projectID)
 }
 
+func (a *App) CreateTag(req models.CreateTagRequest) (*models.Tag, error) {
+	defer a.record("Create tag")()
+	return a.tagService.CreateTag(req)
+}
+
+func (a *App) GetAllTags() ([]models.Tag, error) {
+	return a.tagService.GetAllTags()
+}
+
+func (a *App) UpdateTag(id int, req models.UpdateTagRequest) (*models.Tag, error) {
+	defer a.record("Edit tag")()
+	return a.tagService.UpdateTag(id, req)
+}
+
+func (a *App) DeleteTag(id int) error {
+	defer a.record("Delete tag")()
+	return a.tagService.DeleteTag(id)
+}
+
+// MergeTags folds the source tags into the target tag
+func (a *App) MergeTags(req models.MergeTagsRequest) (*models.Tag, error) {
+	defer a.record("Merge tags")()
+	return a.tagService.MergeTags(req)
+}
+
+func (a *App) SetTimeBlockTags(timeBlockID int, tagIDs []int) ([]models.Tag, error) {
+	defer a.record("Tag time block")()
+	return a.tagService.SetTimeBlockTags(timeBlockID, tagIDs)
+}
+
+func (a *App) SetProjectTags(projectID int, tagIDs []int) ([]models.Tag, error) {
+	defer a.record("Tag project")()
+	return a.tagService.SetProjectTags(projectID, tagIDs)
+}
+
+// GetTagTotals returns the tracked time per tag for a date range
+func (a *App) GetTagTotals(startDate, endDate time.Time) ([]models.TagTotal, error) {
+	return a.tagService.GetTagTotals(startDate, endDate)
+}
+
+// GetReport returns the tracked time of a date range grouped by day, week, month, project, tag or client,
+// narrowed by an optional filter
+func (a *App) GetReport(grouping models.ReportGrouping, startDate, endDate time.Time, filter *models.TimeBlockFilter) (*models.Report, error) {
+	return a.reportService.GetReport(grouping, startDate, endDate, filter)
+}
+
+// ExportTimesheet renders a timesheet of a date range and set of projects as HTML or PDF and saves it where
+// the user chooses. It returns the saved path, or an empty one when the dialog was cancelled.
+func (a *App) ExportTimesheet(req models.TimesheetRequest) (string, error) {
+	content, err := a.timesheetService.RenderTimesheet(req)
+	if err != nil {
+		return "", err
+	}
+
+	extension := "." + string(req.Format)
+	start, end := req.StartDate.In(time.Local).Format("2006-01-02"), req.EndDate.In(time.Local).Format("2006-01-02")
+	filename := "timesheet-" + start
+	if end != start {
+		filename += "-to-" + end
+	}
+
+	path, err := wailsRuntime.SaveFileDialog(a.ctx, wailsRuntime.SaveDialogOptions{
+		Title:           "Save Timesheet",
+		DefaultFilename: filename + extension,
+		Filters: []wailsRuntime.FileFilter{
+			{DisplayName: fmt.Sprintf("%s files (*%s)", strings.ToUpper(string(req.Format)), extension), Pattern: "*" + extension},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	if filepath.Ext(path) == "" {
+		path += extension
+	}
+
+	return path, os.WriteFile(path, content, 0o644)
+}
+
+// GetBillableSummary returns the billable time and amounts for a date range, narrowed by an optional filter
+func (a *App) GetBillableSummary(startDate, endDate time.Time, filter *models.TimeBlockFilter) (*models.BillableSummary, error) {
+	return a.billingService.GetBillableSummary(startDate, endDate, filter)
+}
+
+func (a *App) CreateClient(req models.CreateClientRequest) (*models.Client, error) {
+	defer a.record("Create client")()
+	return a.clientService.CreateClient(req)
+}
+
+func (a *App) GetAllClients() ([]models.Client, error) {
+	return a.clientService.GetAllClients()
+}
+
+func (a *App) UpdateClient(id int, req models.UpdateClientRequest) (*models.Client, error) {
+	defer a.record("Edit client")()
+	return a.clientService.UpdateClient(id, req)
+}
+
+// DeleteClient deletes a client and keeps its projects without one
+func (a *App) DeleteClient(id int) error {
+	defer a.record("Delete client")()
+	return a.clientService.DeleteClient(id)
+}
+
+// GetClientTotals returns the tracked time per client for a date range
+func (a *App) GetClientTotals(startDate, endDate time.Time) ([]models.ClientTotal, error) {
+	return a.clientService.GetClientTotals(startDate, endDate)
+}
+
+func (a *App) CreateCustomField(req models.CreateCustomFieldRequest) (*models.CustomField, error) {
+	defer a.record("Create custom field")()
+	return a.fieldService.CreateCustomField(req)
+}
+
+func (a *App) GetAllCustomFields() ([]models.CustomField, error) {
+	return a.fieldService.GetAllCustomFields()
+}
+
+func (a *App) UpdateCustomField(id int, req models.UpdateCustomFieldRequest) (*models.CustomField, error) {
+	defer a.record("Update custom field")()
+	return a.fieldService.UpdateCustomField(id, req)
+}
+
+// DeleteCustomField deletes a custom field and its values on every project and time block
+func (a *App) DeleteCustomField(id int) error {
+	defer a.record("Delete custom field")()
+	return a.fieldService.DeleteCustomField(id)
+}
+
+// SetProjectFieldValues replaces the custom field values of a project, keyed by field ID
+func (a *App) SetProjectFieldValues(projectID int, values map[int]string) (map[int]string, error) {
+	defer a.record("Set project fields")()
+	return a.fieldService.SetProjectFieldValues(projectID, values)
+}
+
+// SetTimeBlockFieldValues replaces the custom field values of a time block, keyed by field ID
+func (a *App) SetTimeBlockFieldValues(timeBlockID int, values map[int]string) (map[int]string, error) {
+	defer a.record("Set time block fields")()
+	return a.fieldService.SetTimeBlockFieldValues(timeBlockID, values)
+}
+
+// GetCustomFieldTotals returns the tracked time per value of a custom field for a date range, narrowed by an optional filter
+func (a *App) GetCustomFieldTotals(fieldID int, startDate, endDate time.Time, filter *models.TimeBlockFilter) ([]models.CustomFieldTotal, error) {
+	return a.fieldService.GetCustomFieldTotals(fieldID, startDate, endDate, filter)
+}
+
+func (a *App) GetTimerState() (*models.TimerState, error) {
+	return a.timerService.GetTimerState()
+}
+
+func (a *App) StartTimer(req models.StartTimerRequest) (*models.TimerState, error) {
+	defer a.exclusive()()
+	state, err := a.timerService.StartTimer(req)
+	if err != nil {
+		return nil, err
+	}
+	a.emitTimerState(state)
+	return state, nil
+}
+
+func (a *App) PauseTimer() (*models.TimerState, error) {
+	defer a.exclusive()()
+	state, err := a.timerService.PauseTimer()
+	if err != nil {
+		return nil, err
+	}
+	a.emitTimerState(state)
+	return state, nil
+}
+
+func (a *App) ResumeTimer() (*models.TimerState, error) {
+	defer a.exclusive()()
+	state, err := a.timerService.ResumeTimer()
+	if err != nil {
+		return nil, err
+	}
+	a.emitTimerState(state)
+	return state, nil
+}
+
+func (a *App) StopTimer() (*models.TimeBlock, error) {
+	defer a.exclusive()()
+	timeBlock, err := a.timerService.StopTimer()
+	if err != nil {
+		return nil, err
+	}
+	if state, err := a.timerService.GetTimerState(); err == nil {
+		a.emitTimerState(state)
+	}
+	a.checkBudgets()
+	return timeBlock, nil
+}
+
+func (a *App) ResetTimer() (*models.TimerState, error) {
+	defer a.exclusive()()
+	state, err := a.timerService.ResetTimer()
+	if err != nil {
+		return nil, err
+	}
+	a.emitTimerState(state)
+	return state, nil
+}
+
+func (a *App) GetOrphanedTimeBlocks() ([]models.OrphanedTimeBlock, error) {
+	return a.timerService.GetOrphanedTimeBlocks()
+}
+
+func (a *App) RecoverTimeBlock(req models.RecoverTimeBlockRequest) (*models.TimerState, error) {
+	defer a.exclusive()()
+	state, err := a.timerService.RecoverTimeBlock(req)
+	if err != nil {
+		return nil, err
+	}
+	a.emitTimerState(state)
+	return state, nil
+}
+
+func (a *App) GetIdlePeriod() *models.IdlePeriod {
+	return a.timerService.GetIdlePeriod()
+}
+
+func (a *App) ResolveIdle(req models.ResolveIdleRequest) (*models.TimerState, error) {
+	defer a.exclusive()()
+	state, err := a.timerService.ResolveIdle(req)
+	if err != nil {
+		return nil, err
+	}
+	a.emitTimerState(state)
+	return state, nil
+}
+
+func (a *App) GetPomodoroState() (*models.PomodoroState, error) {
+	return a.pomodoroService.GetPomodoroState()
+}
+
+func (a *App) StartPomodoro(req models.StartPomodoroRequest) (*models.PomodoroState, error) {
+	defer a.exclusive()()
+	state, err := a.pomodoroService.StartPomodoro(req)
+	if err != nil {
+		return nil, err
+	}
+	a.emitPomodoroState(state)
+	return state, nil
+}
+
+func (a *App) StopPomodoro() (*models.PomodoroState, error) {
+	defer a.exclusive()()
+	state, err := a.pomodoroService.StopPomodoro()
+	if err != nil {
+		return nil, err
+	}
+	a.emitPomodoroState(state)
+	return state, nil
+}
+
+func (a *App) GetPomodoroCyclesByDate(date time.Time) ([]models.PomodoroCycle, error) {
+	return a.pomodoroService.GetPomodoroCyclesByDate(date)
+}
+
+func (a *App) GetPomodoroCountsByDateRange(startDate, endDate time.Time) ([]models.PomodoroDayCount, error) {
+	return a.pomodoroService.GetPomodoroCountsByDateRange(startDate, endDate)
+}
+
+// GetProjectBurnDown returns consumed and remaining hours of a project's budget with a projected completion date
+func (a *App) GetProjectBurnDown(projectID int) (*models.BurnDown, error) {
+	return a.budgetService.GetProjectBurnDown(projectID)
+}
+
+// GetTaskBurnDown returns consumed and remaining hours of a task's budget, including its subtasks
+func (a *App) GetTaskBurnDown(taskID int) (*models.BurnDown, error) {
+	return a.budgetService.GetTaskBurnDown(taskID)
+}
+
+// GetTrash lists the deleted projects and time blocks that can still be restored
+func (a *App) GetTrash() (*models.Trash, error) {
+	return a.trashService.GetTrash()
+}
+
+// RestoreProjectFromTrash brings back a deleted project with the time blocks deleted along with it
+func (a *App) RestoreProjectFromTrash(id int) error {
+	defer a.record("Restore project from trash")()
+	if err := a.trashService.RestoreProjectFromTrash(id); err != nil {
+		return err
+	}
+	a.checkBudgets()
+	return nil
+}
+
+// RestoreTimeBlockFromTrash brings back a deleted time block, and its project when that was deleted too
+func (a *App) RestoreTimeBlockFromTrash(id int) (*models.TimeBlock, error) {
+	defer a.record("Restore time block from trash")()
+	timeBlock, err := a.trashService.RestoreTimeBlockFromTrash(id)
+	if err != nil {
+		return nil, err
+	}
+	a.checkBudgets()
+	return timeBlock, nil
+}
+
+// PurgeProject permanently deletes a trashed project and all of its time blocks
+func (a *App) PurgeProject(id int) error {
+	defer a.exclusive()()
+	return a.trashService.PurgeProject(id)
+}
+
+// PurgeTimeBlock permanently deletes a trashed time block
+func (a *App) PurgeTimeBlock(id int) error {
+	defer a.exclusive()()
+	return a.trashService.PurgeTimeBlock(id)
+}
+
+// EmptyTrash permanently deletes everything in the trash
+func (a *App) EmptyTrash() error {
+	defer a.exclusive()()
+	return a.trashService.EmptyTrash()
+}
+
+// GetUndoState returns the changes Undo and Redo would apply next
+func (a *App) GetUndoState() (*models.UndoState, error) {
+	return a.undoService.GetUndoState()
+}
+
+// Undo reverts the most recent data change and returns it
+func (a *App) Undo() (*models.Command, error) {
+	a.commandMu.Lock()
+	defer a.commandMu.Unlock()
+
+	command, err := a.undoService.Undo()
+	if err != nil {
+		return nil, err
+	}
+	a.refreshTimerState()
+	a.checkBudgets()
+	return command, nil
+}
+
+// Redo applies the most recently undone data change again and returns it
+func (a *App) Redo() (*models.Command, error) {
+	a.commandMu.Lock()
+	defer a.commandMu.Unlock()
+
+	command, err := a.undoService.Redo()
+	if err != nil {
+		return nil, err
+	}
+	a.refreshTimerState()
+	a.checkBudgets()
+	return command, nil
+}
+
+// record captures the data changes made until the returned function is called as one undoable command
+func (a *App) record(label string) func() {
+	a.commandMu.Lock()
+	id, err := a.undoService.Begin(label)
+	if err != nil {
+		println("Command log error:", err.Error())
+	}
+
+	return func() {
+		defer a.commandMu.Unlock()
+		if err != nil {
+			return
+		}
+		if err := a.undoService.End(id); err != nil {
+			println("Command log error:", err.Error())
+		}
+	}
+}
+
+// exclusive holds off recorded commands, undo and redo until the returned function is called. Timer and
+// Pomodoro changes follow the clock and move state the command log leaves out, so they are not undoable, and
+// purges are final; they still must not run while a command records, or their changes would become part of it.
+// Undo refuses to remove a recorded row these changes came to depend on, like a project the timer ran on.
+func (a *App) exclusive() func() {
+	a.commandMu.Lock()
+	return a.commandMu.Unlock
+}
+
+// checkBudgets tells the frontend about every budget that just crossed the alert threshold
+func (a *App) checkBudgets() {
+	alerts, err := a.budgetService.CheckBudgetAlerts()
+	if err != nil {
+		println("Budget check error:", err.Error())
+		return
+	}
+	for _, alert := range alerts {
+		wailsRuntime.EventsEmit(a.ctx, "budget:alert", alert)
+	}
+}
+
+// checkDeadlines notifies the frontend of project deadlines and milestones that came within the reminder window
+func (a *App) checkDeadlines() {
+	reminders, err := a.milestoneService.CheckDeadlineReminders()
+	if err != nil {
+		println("Deadline check error:", err.Error())
+		return
+	}
+	for _, reminder := range reminders {
+		wailsRuntime.EventsEmit(a.ctx, "deadline:reminder", reminder)
+	}
+}
+
+// emitTimerState notifies every window that the timer state changed
+func (a *App) emitTimerState(state *models.TimerState) {
+	wailsRuntime.EventsEmit(a.ctx, "timer:state", state)
+}
+
+// emitPomodoroState notifies every window of a Pomodoro phase change and the timer it drives
+func (a *App) emitPomodoroState(state *models.PomodoroState) {
+	wailsRuntime.EventsEmit(a.ctx, "pomodoro:state", state)
+	a.refreshTimerState()
+}
+
+// refreshTimerState re-emits the timer state after its time block was changed outside the timer
+func (a *App) refreshTimerState() {
+	if state, err := a.timerService.GetTimerState(); err == nil {
+		a.emitTimerState(state)
+	}
+}
+
 func (a *App) GetSettings() (*models.Settings, error) {
 	return a.settingsService.GetSettings()
 }
 
 func (a *App) UpdateSettings(req models.UpdateSettingsRequest) (*models.Settings, error) {
+	defer a.record("Update settings")()
 	return a.settingsService.UpdateSettings(req)
 }
 
@@ -54,6 +54,78 @@ class API {
         }
     }
 
+    static async duplicateProject(id) {
+        try {
+            return await window.go.main.App.DuplicateProject(id);
+        } catch (error) {
+            console.error('Error duplicating project:', error);
+            throw error;
+        }
+    }
+
+    static async saveProjectAsTemplate(projectId, name) {
+        try {
+            return await window.go.main.App.SaveProjectAsTemplate(projectId, name);
+        } catch (error) {
+            console.error('Error saving project template:', error);
+            throw error;
+        }
+    }
+
+    static async getAllProjectTemplates() {
+        try {
+            return await window.go.main.App.GetAllProjectTemplates();
+        } catch (error) {
+            console.error('Error getting project templates:', error);
+            throw error;
+        }
+    }
+
+    static async deleteProjectTemplate(id) {
+        try {
+            return await window.go.main.App.DeleteProjectTemplate(id);
+        } catch (error) {
+            console.error('Error deleting project template:', error);
+            throw error;
+        }
+    }
+
+    static async createProjectFromTemplate(templateId, name) {
+        try {
+            return await window.go.main.App.CreateProjectFromTemplate(templateId, name);
+        } catch (error) {
+            console.error('Error creating project from template:', error);
+            throw error;
+        }
+    }
+
+    static async createProjectLink(linkData) {
+        try {
+            return await window.go.main.App.CreateProjectLink(linkData);
+        } catch (error) {
+            console.error('Error creating project link:', error);
+            throw error;
+        }
+    }
+
+    static async updateProjectLink(id, linkData) {
+        try {
+            return await window.go.main.App.UpdateProjectLink(id, linkData);
+        } catch (error) {
+            console.error('Error updating project link:', error);
+            throw error;
+        }
+    }
+
+    static async deleteProjectLink(id) {
+        try {
+            return await window.go.main.App.DeleteProjectLink(id);
+        } catch (error) {
+            console.error('Error deleting project link:', error);
+            throw error;
+        }
+    }
+
     static async createTimeBlock(timeBlockData) {
         try {
             return await window.go.main.App.CreateTimeBlock(timeBlockData);
@@ -72,9 +144,9 @@ class API {
         }
     }
 
-    static async getTimeBlocksByDateRange(startDate, endDate) {
+    static async getTimeBlocksByDateRange(startDate, endDate, filter = null) {
         try {
-            return await window.go.main.App.GetTimeBlocksByDateRange(startDate, endDate);
+            return await window.go.main.App.GetTimeBlocksByDateRange(startDate, endDate, filter);
         } catch (error) {
             console.error('Error getting time blocks by range:', error);
             throw error;
@@ -99,6 +171,42 @@ class API {
         }
     }
 
+    static async moveTimeBlocks(moveData) {
+        try {
+            return await window.go.main.App.MoveTimeBlocks(moveData);
+        } catch (error) {
+            console.error('Error moving time blocks:', error);
+            throw error;
+        }
+    }
+
+    static async splitTimeBlock(id, at, newProjectId) {
+        try {
+            return await window.go.main.App.SplitTimeBlock(id, at, newProjectId);
+        } catch (error) {
+            console.error('Error splitting time block:', error);
+            throw error;
+        }
+    }
+
+    static async mergeTimeBlocks(ids) {
+        try {
+            return await window.go.main.App.MergeTimeBlocks(ids);
+        } catch (error) {
+            console.error('Error merging time blocks:', error);
+            throw error;
+        }
+    }
+
+    static async resolveTimeBlockOverlaps(resolveData) {
+        try {
+            return await window.go.main.App.ResolveTimeBlockOverlaps(resolveData);
+        } catch (error) {
+            console.error('Error resolving time block overlaps:', error);
+            throw error;
+        }
+    }
+
     static async deleteTimeBlock(id) {
         try {
             const result = await window.go.main.App.DeleteTimeBlock(parseInt(id));
@@ -127,6 +235,555 @@ class API {
         }
     }
 
+    static async getTimerState() {
+        try {
+            return await window.go.main.App.GetTimerState();
+        } catch (error) {
+            console.error('Error getting timer state:', error);
+            throw error;
+        }
+    }
+
+    static async startTimer(timerData) {
+        try {
+            return await window.go.main.App.StartTimer(timerData);
+        } catch (error) {
+            console.error('Error starting timer:', error);
+            throw error;
+        }
+    }
+
+    static async pauseTimer() {
+        try {
+            return await window.go.main.App.PauseTimer();
+        } catch (error) {
+            console.error('Error pausing timer:', error);
+            throw error;
+        }
+    }
+
+    static async resumeTimer() {
+        try {
+            return await window.go.main.App.ResumeTimer();
+        } catch (error) {
+            console.error('Error resuming timer:', error);
+            throw error;
+        }
+    }
+
+    static async stopTimer() {
+        try {
+            return await window.go.main.App.StopTimer();
+        } catch (error) {
+            console.error('Error stopping timer:', error);
+            throw error;
+        }
+    }
+
+    static async resetTimer() {
+        try {
+            return await window.go.main.App.ResetTimer();
+        } catch (error) {
+            console.error('Error resetting timer:', error);
+            throw error;
+        }
+    }
+
+    static async getOrphanedTimeBlocks() {
+        try {
+            return await window.go.main.App.GetOrphanedTimeBlocks();
+        } catch (error) {
+            console.error('Error getting orphaned time blocks:', error);
+            throw error;
+        }
+    }
+
+    static async recoverTimeBlock(recoveryData) {
+        try {
+            return await window.go.main.App.RecoverTimeBlock(recoveryData);
+        } catch (error) {
+            console.error('Error recovering time block:', error);
+            throw error;
+        }
+    }
+
+    static async getIdlePeriod() {
+        try {
+            return await window.go.main.App.GetIdlePeriod();
+        } catch (error) {
+            console.error('Error getting idle period:', error);
+            throw error;
+        }
+    }
+
+    static async resolveIdle(idleData) {
+        try {
+            return await window.go.main.App.ResolveIdle(idleData);
+        } catch (error) {
+            console.error('Error resolving idle time:', error);
+            throw error;
+        }
+    }
+
+    static async getPomodoroState() {
+        try {
+            return await window.go.main.App.GetPomodoroState();
+        } catch (error) {
+            console.error('Error getting pomodoro state:', error);
+            throw error;
+        }
+    }
+
+    static async startPomodoro(pomodoroData) {
+        try {
+            return await window.go.main.App.StartPomodoro(pomodoroData);
+        } catch (error) {
+            console.error('Error starting pomodoro:', error);
+            throw error;
+        }
+    }
+
+    static async stopPomodoro() {
+        try {
+            return await window.go.main.App.StopPomodoro();
+        } catch (error) {
+            console.error('Error stopping pomodoro:', error);
+            throw error;
+        }
+    }
+
+    static async getPomodoroCountsByDateRange(startDate, endDate) {
+        try {
+            return await window.go.main.App.GetPomodoroCountsByDateRange(startDate, endDate);
+        } catch (error) {
+            console.error('Error getting pomodoro counts:', error);
+            throw error;
+        }
+    }
+
+    static async getAllTags() {
+        try {
+            return await window.go.main.App.GetAllTags();
+        } catch (error) {
+            console.error('Error getting tags:', error);
+            throw error;
+        }
+    }
+
+    static async createTag(tagData) {
+        try {
+            return await window.go.main.App.CreateTag(tagData);
+        } catch (error) {
+            console.error('Error creating tag:', error);
+            throw error;
+        }
+    }
+
+    static async updateTag(id, tagData) {
+        try {
+            return await window.go.main.App.UpdateTag(id, tagData);
+        } catch (error) {
+            console.error('Error updating tag:', error);
+            throw error;
+        }
+    }
+
+    static async deleteTag(id) {
+        try {
+            return await window.go.main.App.DeleteTag(id);
+        } catch (error) {
+            console.error('Error deleting tag:', error);
+            throw error;
+        }
+    }
+
+    static async mergeTags(mergeData) {
+        try {
+            return await window.go.main.App.MergeTags(mergeData);
+        } catch (error) {
+            console.error('Error merging tags:', error);
+            throw error;
+        }
+    }
+
+    static async setTimeBlockTags(timeBlockId, tagIds) {
+        try {
+            return await window.go.main.App.SetTimeBlockTags(timeBlockId, tagIds);
+        } catch (error) {
+            console.error('Error setting time block tags:', error);
+            throw error;
+        }
+    }
+
+    static async setProjectTags(projectId, tagIds) {
+        try {
+            return await window.go.main.App.SetProjectTags(projectId, tagIds);
+        } catch (error) {
+            console.error('Error setting project tags:', error);
+            throw error;
+        }
+    }
+
+    static async getTagTotals(startDate, endDate) {
+        try {
+            return await window.go.main.App.GetTagTotals(startDate, endDate);
+        } catch (error) {
+            console.error('Error getting tag totals:', error);
+            throw error;
+        }
+    }
+
+    static async getReport(grouping, startDate, endDate, filter = null) {
+        try {
+            return await window.go.main.App.GetReport(grouping, startDate, endDate, filter);
+        } catch (error) {
+            console.error('Error getting report:', error);
+            throw error;
+        }
+    }
+
+    static async exportTimesheet(request) {
+        try {
+            return await window.go.main.App.ExportTimesheet(request);
+        } catch (error) {
+            console.error('Error exporting timesheet:', error);
+            throw error;
+        }
+    }
+
+    static async getBillableSummary(startDate, endDate, filter = null) {
+        try {
+            return await window.go.main.App.GetBillableSummary(startDate, endDate, filter);
+        } catch (error) {
+            console.error('Error getting billable summary:', error);
+            throw error;
+        }
+    }
+
+    static async getAllClients() {
+        try {
+            return await window.go.main.App.GetAllClients();
+        } catch (error) {
+            console.error('Error getting clients:', error);
+            throw error;
+        }
+    }
+
+    static async createClient(clientData) {
+        try {
+            return await window.go.main.App.CreateClient(clientData);
+        } catch (error) {
+            console.error('Error creating client:', error);
+            throw error;
+        }
+    }
+
+    static async updateClient(id, clientData) {
+        try {
+            return await window.go.main.App.UpdateClient(id, clientData);
+        } catch (error) {
+            console.error('Error updating client:', error);
+            throw error;
+        }
+    }
+
+    static async deleteClient(id) {
+        try {
+            return await window.go.main.App.DeleteClient(id);
+        } catch (error) {
+            console.error('Error deleting client:', error);
+            throw error;
+        }
+    }
+
+    static async getClientTotals(startDate, endDate) {
+        try {
+            return await window.go.main.App.GetClientTotals(startDate, endDate);
+        } catch (error) {
+            console.error('Error getting client totals:', error);
+            throw error;
+        }
+    }
+
+    static async getAllCustomFields() {
+        try {
+            return await window.go.main.App.GetAllCustomFields();
+        } catch (error) {
+            console.error('Error getting custom fields:', error);
+            throw error;
+        }
+    }
+
+    static async createCustomField(fieldData) {
+        try {
+            return await window.go.main.App.CreateCustomField(fieldData);
+        } catch (error) {
+            console.error('Error creating custom field:', error);
+            throw error;
+        }
+    }
+
+    static async updateCustomField(id, fieldData) {
+        try {
+            return await window.go.main.App.UpdateCustomField(id, fieldData);
+        } catch (error) {
+            console.error('Error updating custom field:', error);
+            throw error;
+        }
+    }
+
+    static async deleteCustomField(id) {
+        try {
+            return await window.go.main.App.DeleteCustomField(id);
+        } catch (error) {
+            console.error('Error deleting custom field:', error);
+            throw error;
+        }
+    }
+
+    static async setProjectFieldValues(projectId, values) {
+        try {
+            return await window.go.main.App.SetProjectFieldValues(projectId, values);
+        } catch (error) {
+            console.error('Error setting project field values:', error);
+            throw error;
+        }
+    }
+
+    static async setTimeBlockFieldValues(timeBlockId, values) {
+        try {
+            return await window.go.main.App.SetTimeBlockFieldValues(timeBlockId, values);
+        } catch (error) {
+            console.error('Error setting time block field values:', error);
+            throw error;
+        }
+    }
+
+    static async getCustomFieldTotals(fieldId, startDate, endDate, filter = null) {
+        try {
+            return await window.go.main.App.GetCustomFieldTotals(fieldId, startDate, endDate, filter);
+        } catch (error) {
+            console.error('Error getting custom field totals:', error);
+            throw error;
+        }
+    }
+
+    static async createMilestone(milestoneData) {
+        try {
+            return await window.go.main.App.CreateMilestone(milestoneData);
+        } catch (error) {
+            console.error('Error creating milestone:', error);
+            throw error;
+        }
+    }
+
+    static async updateMilestone(id, milestoneData) {
+        try {
+            return await window.go.main.App.UpdateMilestone(id, milestoneData);
+        } catch (error) {
+            console.error('Error updating milestone:', error);
+            throw error;
+        }
+    }
+
+    static async deleteMilestone(id) {
+        try {
+            return await window.go.main.App.DeleteMilestone(id);
+        } catch (error) {
+            console.error('Error deleting milestone:', error);
+            throw error;
+        }
+    }
+
+    static async getDeadlinesByDateRange(startDate, endDate) {
+        try {
+            return await window.go.main.App.GetDeadlinesByDateRange(startDate, endDate);
+        } catch (error) {
+            console.error('Error getting deadlines:', error);
+            throw error;
+        }
+    }
+
+    static async createTask(taskData) {
+        try {
+            return await window.go.main.App.CreateTask(taskData);
+        } catch (error) {
+            console.error('Error creating task:', error);
+            throw error;
+        }
+    }
+
+    static async getTaskTree(projectId) {
+        try {
+            return await window.go.main.App.GetTaskTree(projectId);
+        } catch (error) {
+            console.error('Error getting task tree:', error);
+            throw error;
+        }
+    }
+
+    static async updateTask(id, taskData) {
+        try {
+            return await window.go.main.App.UpdateTask(id, taskData);
+        } catch (error) {
+            console.error('Error updating task:', error);
+            throw error;
+        }
+    }
+
+    static async deleteTask(id) {
+        try {
+            return await window.go.main.App.DeleteTask(id);
+        } catch (error) {
+            console.error('Error deleting task:', error);
+            throw error;
+        }
+    }
+
+    static async updateTasksOrder(taskOrders) {
+        try {
+            return await window.go.main.App.UpdateTasksOrder(taskOrders);
+        } catch (error) {
+            console.error('Error updating task order:', error);
+            throw error;
+        }
+    }
+
+    static async getProjectBurnDown(projectId) {
+        try {
+            return await window.go.main.App.GetProjectBurnDown(projectId);
+        } catch (error) {
+            console.error('Error getting project burn-down:', error);
+            throw error;
+        }
+    }
+
+    static async getTaskBurnDown(taskId) {
+        try {
+            return await window.go.main.App.GetTaskBurnDown(taskId);
+        } catch (error) {
+            console.error('Error getting task burn-down:', error);
+            throw error;
+        }
+    }
+
+    static async getArchivedProjects() {
+        try {
+            return await window.go.main.App.GetArchivedProjects();
+        } catch (error) {
+            console.error('Error getting archived projects:', error);
+            throw error;
+        }
+    }
+
+    static async archiveProject(id) {
+        try {
+            return await window.go.main.App.ArchiveProject(id);
+        } catch (error) {
+            console.error('Error archiving project:', error);
+            throw error;
+        }
+    }
+
+    static async restoreProject(id) {
+        try {
+            return await window.go.main.App.RestoreProject(id);
+        } catch (error) {
+            console.error('Error restoring project:', error);
+            throw error;
+        }
+    }
+
+    static async getTrash() {
+        try {
+            return await window.go.main.App.GetTrash();
+        } catch (error) {
+            console.error('Error loading trash:', error);
+            throw error;
+        }
+    }
+
+    static async restoreProjectFromTrash(id) {
+        try {
+            return await window.go.main.App.RestoreProjectFromTrash(id);
+        } catch (error) {
+            console.error('Error restoring project from trash:', error);
+            throw error;
+        }
+    }
+
+    static async restoreTimeBlockFromTrash(id) {
+        try {
+            return await window.go.main.App.RestoreTimeBlockFromTrash(id);
+        } catch (error) {
+            console.error('Error restoring time block from trash:', error);
+            throw error;
+        }
+    }
+
+    static async purgeProject(id) {
+        try {
+            return await window.go.main.App.PurgeProject(id);
+        } catch (error) {
+            console.error('Error purging project:', error);
+            throw error;
+        }
+    }
+
+    static async purgeTimeBlock(id) {
+        try {
+            return await window.go.main.App.PurgeTimeBlock(id);
+        } catch (error) {
+            console.error('Error purging time block:', error);
+            throw error;
+        }
+    }
+
+    static async emptyTrash() {
+        try {
+            return await window.go.main.App.EmptyTrash();
+        } catch (error) {
+            console.error('Error emptying trash:', error);
+            throw error;
+        }
+    }
+
+    static async undo() {
+        try {
+            return await window.go.main.App.Undo();
+        } catch (error) {
+            console.error('Error undoing change:', error);
+            throw error;
+        }
+    }
+
+    static async redo() {
+        try {
+            return await window.go.main.App.Redo();
+        } catch (error) {
+            console.error('Error redoing change:', error);
+            throw error;
+        }
+    }
+
+    static async getUndoState() {
+        try {
+            return await window.go.main.App.GetUndoState();
+        } catch (error) {
+            console.error('Error loading undo state:', error);
+            throw error;
+        }
+    }
+
+    static async getTimeBlockHistory(id) {
+        try {
+            return await window.go.main.App.GetTimeBlockHistory(id);
+        } catch (error) {
+            console.error('Error loading time block history:', error);
+            throw error;
+        }
+    }
+
     static async getSettings() {
         try {
             return await window.go.main.App.GetSettings();
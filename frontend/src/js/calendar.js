@@ -11,6 +11,7 @@ class Calendar {
         this.currentDate = new Date();
         this.selectedDate = new Date();
         this.monthTimeBlocks = [];
+        this.monthDeadlines = [];
         this.timer = null;
 
         this._tooltipHideTimer = null;
@@ -122,6 +123,19 @@ class Calendar {
             this.monthTimeBlocks = [];
         }
 
+        // Deadlines cover the whole six-week grid, including the days of the neighbouring months
+        const gridStart = new Date(startOfMonth);
+        gridStart.setDate(gridStart.getDate() - startOfMonth.getDay());
+        const gridEnd = new Date(gridStart);
+        gridEnd.setDate(gridEnd.getDate() + 42);
+
+        try {
+            this.monthDeadlines = await API.getDeadlinesByDateRange(gridStart, gridEnd) || [];
+        } catch (error) {
+            console.error('Error loading month deadlines:', error);
+            this.monthDeadlines = [];
+        }
+
         this.calendarContainer.innerHTML = this.createCalendarHTML();
         this.bindCalendarEvents();
         this.updateSelectedDateDisplay();
@@ -185,7 +199,7 @@ class Calendar {
                 ].filter(Boolean).join(' ');
 
 
-                const projectsForDate = this.getProjectsForDate(currentDate);
+                const deadlinesForDate = this.getDeadlinesForDate(currentDate);
                 const timeBlocksForDate = this.getTimeBlocksForDate(currentDate);
 
 
@@ -194,18 +208,20 @@ class Calendar {
                 const dayTooltips = [];
 
 
-                if (projectsForDate.length > 0) {
-                    if (projectsForDate.length <= 3) {
+                if (deadlinesForDate.length > 0) {
+                    if (deadlinesForDate.length <= 3) {
 
-                        projectIndicators += projectsForDate.map(project => {
+                        projectIndicators += deadlinesForDate.map(deadline => {
 
-                            dayTooltips.push(`Deadline: ${project.name}`);
+                            dayTooltips.push(deadline.milestone_id
+                                ? `Milestone: ${deadline.project_name} – ${deadline.title}`
+                                : `Deadline: ${deadline.project_name}`);
                             return `<div class="calendar-project-dot"></div>`;
                         }).join('');
                     } else {
 
-                        dayTooltips.push(`${projectsForDate.length} project deadlines`);
-                        projectIndicators += `<div class="calendar-project-indicator multiple">${projectsForDate.length}</div>`;
+                        dayTooltips.push(`${deadlinesForDate.length} deadlines`);
+                        projectIndicators += `<div class="calendar-project-indicator multiple">${deadlinesForDate.length}</div>`;
                     }
                 }
 
@@ -337,13 +353,12 @@ class Calendar {
         });
     }
 
-    getProjectsForDate(date) {
-        const projects = this.projects.getProjects();
+    // Project deadlines and milestones due on the date
+    getDeadlinesForDate(date) {
         const targetDate = Utils.getStartOfDay(date);
 
-        return projects.filter(project => {
-            if (!project.deadline) return false;
-            const deadlineDate = Utils.getStartOfDay(new Date(project.deadline));
+        return (this.monthDeadlines || []).filter(deadline => {
+            const deadlineDate = Utils.getStartOfDay(new Date(deadline.due_date));
             return deadlineDate.getTime() === targetDate.getTime();
         });
     }
@@ -0,0 +1,186 @@
+// Clients Module - Handles client management from the settings page
+import API from './api.js';
+import Utils from './utils.js';
+import Dialog from './dialog.js';
+import StandardModal from './standard-modal.js';
+
+class Clients {
+    constructor() {
+        this.clients = [];
+        this.currentEditingId = null;
+
+        this.initializeElements();
+        this.bindEvents();
+    }
+
+    initializeElements() {
+        this.clientsList = document.getElementById('clients-list');
+        this.addClientBtn = document.getElementById('add-client');
+
+        this.clientModal = new StandardModal('client-modal', {
+            title: 'Add Client',
+            icon: 'fas fa-briefcase',
+            iconType: 'project'
+        });
+
+        this.nameField = document.getElementById('client-name');
+        this.contactField = document.getElementById('client-contact');
+        this.defaultRateField = document.getElementById('client-default-rate');
+        this.currencyField = document.getElementById('client-currency');
+        this.notesField = document.getElementById('client-notes');
+    }
+
+    bindEvents() {
+        this.addClientBtn?.addEventListener('click', () => {
+            this.currentEditingId = null;
+            this.clientModal.setTitle('Add Client');
+            this.openModal();
+        });
+
+        this.clientModal.setFormHandler('client-form', (e) => this.handleSubmit(e));
+        document.getElementById('cancel-client')?.addEventListener('click', () => this.closeModal());
+        this.clientModal.modal.querySelector('.standard-modal-close')?.addEventListener('click', () => this.closeModal());
+
+        this.clientsList?.addEventListener('click', (e) => {
+            const btn = e.target.closest('.tag-action-btn');
+            if (!btn) return;
+            const id = parseInt(btn.closest('.client-row').dataset.id, 10);
+            if (btn.dataset.action === 'edit') {
+                this.editClient(id);
+            } else if (btn.dataset.action === 'delete') {
+                this.deleteClient(id);
+            }
+        });
+
+        document.addEventListener('keydown', (e) => {
+            if (e.key === 'Escape' && this.clientModal.isVisible) {
+                this.closeModal();
+            }
+        });
+    }
+
+    async loadClients() {
+        try {
+            this.clients = await API.getAllClients() || [];
+        } catch (error) {
+            console.error('Error loading clients:', error);
+            this.clients = [];
+        }
+        this.renderClients();
+    }
+
+    renderClients() {
+        if (!this.clientsList) return;
+
+        if (this.clients.length === 0) {
+            this.clientsList.innerHTML = '<span class="no-tags">No clients yet</span>';
+            return;
+        }
+
+        this.clientsList.innerHTML = this.clients.map(client => `
+            <div class="client-row" data-id="${client.id}">
+                <div class="client-details">
+                    <span class="client-name">${Utils.escapeHtml(client.name)}</span>
+                    <span class="client-meta">${[
+                        client.contact ? Utils.escapeHtml(client.contact) : '',
+                        client.default_rate ? `${Utils.formatMoney(client.default_rate, client.currency || null)}/h` : ''
+                    ].filter(Boolean).join(' · ')}</span>
+                </div>
+                <button type="button" class="tag-action-btn" data-action="edit" title="Edit client">
+                    <i class="fas fa-edit"></i>
+                </button>
+                <button type="button" class="tag-action-btn delete" data-action="delete" title="Delete client">
+                    <i class="fas fa-trash"></i>
+                </button>
+            </div>
+        `).join('');
+    }
+
+    editClient(id) {
+        const client = this.clients.find(c => c.id === id);
+        if (!client) return;
+
+        this.currentEditingId = id;
+        this.clientModal.setTitle('Edit Client');
+        this.nameField.value = client.name;
+        this.contactField.value = client.contact || '';
+        this.defaultRateField.value = client.default_rate || '';
+        this.currencyField.value = client.currency || '';
+        this.notesField.value = client.notes || '';
+        this.openModal();
+    }
+
+    async deleteClient(id) {
+        const confirmed = await Dialog.confirm(
+            'Delete Client',
+            'Delete this client? Its projects and time blocks are kept without a client.',
+            { confirmText: 'Delete', cancelText: 'Cancel', confirmType: 'danger' }
+        );
+        if (!confirmed) return;
+
+        try {
+            await API.deleteClient(id);
+            Utils.showNotification('Success', 'Client deleted', 'success');
+            window.dispatchEvent(new CustomEvent('clientsUpdated'));
+        } catch (error) {
+            console.error('Error deleting client:', error);
+            Utils.showNotification('Error', String(error || 'Failed to delete client'), 'error');
+        }
+        await this.loadClients();
+    }
+
+    openModal() {
+        this.clientModal.show();
+        this.nameField.focus();
+    }
+
+    closeModal() {
+        this.clientModal.hide();
+        setTimeout(() => {
+            this.currentEditingId = null;
+            this.clientModal.resetForm('client-form');
+            this.clientModal.setTitle('Add Client');
+        }, 200);
+    }
+
+    async handleSubmit(e) {
+        e.preventDefault();
+
+        const formData = new FormData(e.target);
+        const clientData = {
+            name: (formData.get('name') || '').trim(),
+            contact: (formData.get('contact') || '').trim(),
+            default_rate: parseFloat(formData.get('default_rate')) || 0,
+            currency: (formData.get('currency') || '').trim(),
+            notes: (formData.get('notes') || '').trim()
+        };
+
+        if (!clientData.name) {
+            Utils.showNotification('Error', 'Client name is required', 'error');
+            return;
+        }
+        if (clientData.default_rate < 0) {
+            Utils.showNotification('Error', 'Hourly rate cannot be negative', 'error');
+            return;
+        }
+
+        try {
+            if (this.currentEditingId) {
+                await API.updateClient(this.currentEditingId, clientData);
+                Utils.showNotification('Success', 'Client updated successfully!', 'success');
+            } else {
+                await API.createClient(clientData);
+                Utils.showNotification('Success', 'Client created successfully!', 'success');
+            }
+
+            this.closeModal();
+            await this.loadClients();
+            window.dispatchEvent(new CustomEvent('clientsUpdated'));
+        } catch (error) {
+            console.error('Error saving client:', error);
+            Utils.showNotification('Error', String(error || 'Failed to save client'), 'error');
+        }
+    }
+}
+
+export default Clients;
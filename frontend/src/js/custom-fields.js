@@ -0,0 +1,197 @@
+// Custom Fields Module - Handles custom field definitions from the settings page
+import API from './api.js';
+import Utils from './utils.js';
+import Dialog from './dialog.js';
+import StandardModal from './standard-modal.js';
+
+const FIELD_TYPE_LABELS = {
+    text: 'Text',
+    number: 'Number',
+    date: 'Date',
+    select: 'Select'
+};
+
+class CustomFields {
+    constructor() {
+        this.fields = [];
+        this.currentEditingId = null;
+
+        this.initializeElements();
+        this.bindEvents();
+    }
+
+    initializeElements() {
+        this.fieldsList = document.getElementById('custom-fields-list');
+        this.addFieldBtn = document.getElementById('add-custom-field');
+
+        this.fieldModal = new StandardModal('custom-field-modal', {
+            title: 'Add Field',
+            icon: 'fas fa-table-list',
+            iconType: 'project'
+        });
+
+        this.nameField = document.getElementById('custom-field-name');
+        this.typeField = document.getElementById('custom-field-type');
+        this.optionsField = document.getElementById('custom-field-options');
+        this.optionsGroup = document.getElementById('custom-field-options-group');
+    }
+
+    bindEvents() {
+        this.addFieldBtn?.addEventListener('click', () => {
+            this.currentEditingId = null;
+            this.fieldModal.setTitle('Add Field');
+            this.openModal();
+        });
+
+        this.typeField?.addEventListener('change', () => this.updateOptionsVisibility());
+
+        this.fieldModal.setFormHandler('custom-field-form', (e) => this.handleSubmit(e));
+        document.getElementById('cancel-custom-field')?.addEventListener('click', () => this.closeModal());
+        this.fieldModal.modal.querySelector('.standard-modal-close')?.addEventListener('click', () => this.closeModal());
+
+        this.fieldsList?.addEventListener('click', (e) => {
+            const btn = e.target.closest('.tag-action-btn');
+            if (!btn) return;
+            const id = parseInt(btn.closest('.client-row').dataset.id, 10);
+            if (btn.dataset.action === 'edit') {
+                this.editField(id);
+            } else if (btn.dataset.action === 'delete') {
+                this.deleteField(id);
+            }
+        });
+
+        document.addEventListener('keydown', (e) => {
+            if (e.key === 'Escape' && this.fieldModal.isVisible) {
+                this.closeModal();
+            }
+        });
+    }
+
+    async loadFields() {
+        try {
+            this.fields = await API.getAllCustomFields() || [];
+        } catch (error) {
+            console.error('Error loading custom fields:', error);
+            this.fields = [];
+        }
+        this.renderFields();
+    }
+
+    renderFields() {
+        if (!this.fieldsList) return;
+
+        if (this.fields.length === 0) {
+            this.fieldsList.innerHTML = '<span class="no-tags">No custom fields yet</span>';
+            return;
+        }
+
+        this.fieldsList.innerHTML = this.fields.map(field => `
+            <div class="client-row" data-id="${field.id}">
+                <div class="client-details">
+                    <span class="client-name">${Utils.escapeHtml(field.name)}</span>
+                    <span class="client-meta">${[
+                        FIELD_TYPE_LABELS[field.type] || field.type,
+                        field.type === 'select' ? Utils.escapeHtml(field.options.join(', ')) : ''
+                    ].filter(Boolean).join(' · ')}</span>
+                </div>
+                <button type="button" class="tag-action-btn" data-action="edit" title="Edit field">
+                    <i class="fas fa-edit"></i>
+                </button>
+                <button type="button" class="tag-action-btn delete" data-action="delete" title="Delete field">
+                    <i class="fas fa-trash"></i>
+                </button>
+            </div>
+        `).join('');
+    }
+
+    updateOptionsVisibility() {
+        this.optionsGroup.style.display = this.typeField.value === 'select' ? '' : 'none';
+    }
+
+    editField(id) {
+        const field = this.fields.find(f => f.id === id);
+        if (!field) return;
+
+        this.currentEditingId = id;
+        this.fieldModal.setTitle('Edit Field');
+        this.nameField.value = field.name;
+        this.typeField.value = field.type;
+        // The type of an existing field is fixed so its stored values stay valid
+        this.typeField.disabled = true;
+        this.optionsField.value = (field.options || []).join('\n');
+        this.openModal();
+    }
+
+    async deleteField(id) {
+        const confirmed = await Dialog.confirm(
+            'Delete Field',
+            'Delete this field? Its values on all projects and time blocks are removed too.',
+            { confirmText: 'Delete', cancelText: 'Cancel', confirmType: 'danger' }
+        );
+        if (!confirmed) return;
+
+        try {
+            await API.deleteCustomField(id);
+            Utils.showNotification('Success', 'Field deleted', 'success');
+            window.dispatchEvent(new CustomEvent('customFieldsUpdated'));
+        } catch (error) {
+            console.error('Error deleting custom field:', error);
+            Utils.showNotification('Error', String(error || 'Failed to delete field'), 'error');
+        }
+        await this.loadFields();
+    }
+
+    openModal() {
+        this.updateOptionsVisibility();
+        this.fieldModal.show();
+        this.nameField.focus();
+    }
+
+    closeModal() {
+        this.fieldModal.hide();
+        setTimeout(() => {
+            this.currentEditingId = null;
+            this.fieldModal.resetForm('custom-field-form');
+            this.fieldModal.setTitle('Add Field');
+            this.typeField.disabled = false;
+        }, 200);
+    }
+
+    async handleSubmit(e) {
+        e.preventDefault();
+
+        const name = this.nameField.value.trim();
+        const type = this.typeField.value;
+        const options = type === 'select'
+            ? this.optionsField.value.split(/[\n,]/).map(option => option.trim()).filter(Boolean)
+            : [];
+
+        if (!name) {
+            Utils.showNotification('Error', 'Field name is required', 'error');
+            return;
+        }
+        if (type === 'select' && options.length === 0) {
+            Utils.showNotification('Error', 'A select field needs at least one option', 'error');
+            return;
+        }
+
+        try {
+            if (this.currentEditingId) {
+                await API.updateCustomField(this.currentEditingId, { name, options });
+                Utils.showNotification('Success', 'Field updated successfully!', 'success');
+            } else {
+                await API.createCustomField({ name, type, options, order: this.fields.length });
+                Utils.showNotification('Success', 'Field created successfully!', 'success');
+            }
+
+            this.closeModal();
+            await this.loadFields();
+            window.dispatchEvent(new CustomEvent('customFieldsUpdated'));
+        } catch (error) {
+            console.error('Error saving custom field:', error);
+            Utils.showNotification('Error', String(error || 'Failed to save field'), 'error');
+        }
+    }
+}
+
+export default CustomFields;
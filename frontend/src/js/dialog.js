@@ -121,9 +121,11 @@ class Dialog {
         }
         
         
+        // Keep the handlers of a dialog shown again right away
+        const { onConfirm, onCancel } = this;
         setTimeout(() => {
-            this.onConfirm = null;
-            this.onCancel = null;
+            if (this.onConfirm === onConfirm) this.onConfirm = null;
+            if (this.onCancel === onCancel) this.onCancel = null;
         }, 200); 
     }
 
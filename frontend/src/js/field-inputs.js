@@ -0,0 +1,98 @@
+/**
+ * Field Inputs
+ * Custom field editor used by the project and time block forms, one input per field definition
+ */
+
+import API from './api.js';
+import Utils from './utils.js';
+
+class FieldInputs {
+    constructor(containerId, options = {}) {
+        this.container = document.getElementById(containerId);
+        this.options = {
+            emptyChoice: 'No value',
+            ...options
+        };
+        this.fields = [];
+        this.values = {};
+
+        if (!this.container) {
+            console.error('FieldInputs: Container not found');
+            return;
+        }
+
+        // Keep pending edits while the definitions change under the form
+        this.container.addEventListener('input', (e) => {
+            const id = e.target.dataset.fieldId;
+            if (id) this.values[id] = e.target.value;
+        });
+
+        this.renderFields();
+    }
+
+    async refresh() {
+        try {
+            this.fields = await API.getAllCustomFields() || [];
+        } catch (error) {
+            console.error('Error loading custom fields:', error);
+            this.fields = [];
+        }
+        this.renderFields();
+    }
+
+    setValues(values) {
+        this.values = { ...(values || {}) };
+        this.renderFields();
+    }
+
+    // Values keyed by field ID; empty inputs are left out so they clear the field
+    getValues() {
+        const values = {};
+        this.container.querySelectorAll('[data-field-id]').forEach(input => {
+            const value = input.value.trim();
+            if (value) values[input.dataset.fieldId] = value;
+        });
+        return values;
+    }
+
+    renderFields() {
+        // The surrounding form group only shows once fields are defined
+        const group = this.container.closest('.form-group');
+        if (group) group.style.display = this.fields.length > 0 ? '' : 'none';
+
+        this.container.innerHTML = this.fields.map(field => `
+            <div class="field-input-row">
+                <label for="${this.container.id}-${field.id}">${Utils.escapeHtml(field.name)}</label>
+                ${this.renderInput(field)}
+            </div>
+        `).join('');
+
+        // Values are set programmatically so quotes in them cannot break the markup
+        this.container.querySelectorAll('[data-field-id]').forEach(input => {
+            input.value = this.values[input.dataset.fieldId] || '';
+        });
+    }
+
+    renderInput(field) {
+        const attrs = `id="${this.container.id}-${field.id}" data-field-id="${field.id}" autocomplete="off"`;
+        switch (field.type) {
+            case 'number':
+                return `<input type="number" step="any" ${attrs}>`;
+            case 'date':
+                return `<input type="date" ${attrs}>`;
+            case 'select': {
+                const current = this.values[field.id];
+                // Keep a value whose option was removed so saving does not silently drop it
+                const options = current && !field.options.includes(current) ? [...field.options, current] : field.options;
+                return `<select ${attrs}>
+                    <option value="">${Utils.escapeHtml(this.options.emptyChoice)}</option>
+                    ${options.map(option => `<option>${Utils.escapeHtml(option)}</option>`).join('')}
+                </select>`;
+            }
+            default:
+                return `<input type="text" ${attrs}>`;
+        }
+    }
+}
+
+export default FieldInputs;
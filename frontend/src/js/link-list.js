@@ -0,0 +1,182 @@
+/**
+ * Link List
+ * Project link editor shown in the project form; changes are saved together with the project
+ */
+
+import API from './api.js';
+import Utils from './utils.js';
+import DragReorder from './drag-reorder.js';
+
+class LinkList {
+    constructor(containerId) {
+        this.container = document.getElementById(containerId);
+        this.links = [];
+        this.original = new Map();
+        this.reorder = null;
+
+        if (!this.container) {
+            console.error('LinkList: Container not found');
+            return;
+        }
+
+        this.render();
+        this.bindEvents();
+        this.renderLinks();
+    }
+
+    render() {
+        this.container.innerHTML = `
+            <div class="link-rows"></div>
+            <button type="button" class="btn btn-secondary link-add">
+                <i class="fas fa-plus"></i> Add link
+            </button>
+        `;
+        this.rowsEl = this.container.querySelector('.link-rows');
+        this.addBtn = this.container.querySelector('.link-add');
+    }
+
+    bindEvents() {
+        this.addBtn.addEventListener('click', () => {
+            this.links.push({ id: null, kind: 'web', label: '', url: '' });
+            this.renderLinks();
+            const fields = this.rowsEl.querySelectorAll('.link-url-field');
+            fields[fields.length - 1]?.focus();
+        });
+
+        this.rowsEl.addEventListener('input', (e) => {
+            const row = e.target.closest('.link-row');
+            if (!row) return;
+            const link = this.links[parseInt(row.dataset.index, 10)];
+            if (e.target.classList.contains('link-label-field')) {
+                link.label = e.target.value;
+            } else if (e.target.classList.contains('link-url-field')) {
+                link.url = e.target.value;
+            }
+        });
+
+        this.rowsEl.addEventListener('change', (e) => {
+            if (!e.target.classList.contains('link-kind')) return;
+            const row = e.target.closest('.link-row');
+            this.links[parseInt(row.dataset.index, 10)].kind = e.target.value;
+            this.renderLinks();
+        });
+
+        this.rowsEl.addEventListener('keydown', (e) => {
+            if (e.key === 'Enter' && e.target.matches('.link-label-field, .link-url-field')) {
+                // Keep Enter from submitting the surrounding form
+                e.preventDefault();
+                e.target.blur();
+            }
+        });
+
+        this.rowsEl.addEventListener('click', (e) => {
+            const btn = e.target.closest('.tag-action-btn');
+            if (!btn || btn.dataset.action !== 'delete') return;
+            this.links.splice(parseInt(btn.closest('.link-row').dataset.index, 10), 1);
+            this.renderLinks();
+        });
+    }
+
+    load(links) {
+        this.links = (links || []).map(link => ({ id: link.id, kind: link.kind || 'web', label: link.label || '', url: link.url }));
+        this.original = new Map((links || []).map((link, index) => [link.id, { ...link, order: index }]));
+        this.renderLinks();
+    }
+
+    clear() {
+        this.load([]);
+    }
+
+    renderLinks() {
+        if (this.links.length === 0) {
+            this.rowsEl.innerHTML = '<span class="no-tags">No links yet</span>';
+        } else {
+            this.rowsEl.innerHTML = this.links.map((link, index) => `
+                <div class="link-row" data-index="${index}">
+                    <span class="link-drag-handle js-link-drag-handle" title="Drag to reorder"><i class="fas fa-grip-vertical"></i></span>
+                    <select class="link-kind" title="Opens in">
+                        <option value="web" ${link.kind === 'web' ? 'selected' : ''}>Web</option>
+                        <option value="discord" ${link.kind === 'discord' ? 'selected' : ''}>Discord</option>
+                    </select>
+                    <input type="text" class="link-label-field" placeholder="Label" autocomplete="off">
+                    <input type="text" class="link-url-field" autocomplete="off"
+                        placeholder="${link.kind === 'discord' ? 'https://discord.com/channels/&lt;server_id&gt;/&lt;channel_id&gt;' : 'https://...'}">
+                    <button type="button" class="tag-action-btn delete" data-action="delete" title="Remove link">
+                        <i class="fas fa-trash"></i>
+                    </button>
+                </div>
+            `).join('');
+            // Values are set programmatically so quotes in them cannot break the markup
+            this.rowsEl.querySelectorAll('.link-row').forEach((row, index) => {
+                row.querySelector('.link-label-field').value = this.links[index].label;
+                row.querySelector('.link-url-field').value = this.links[index].url;
+            });
+        }
+
+        this.initReorder();
+    }
+
+    initReorder() {
+        if (this.reorder) {
+            this.reorder.destroy();
+            this.reorder = null;
+        }
+        if (!this.rowsEl.querySelector('.link-row')) return;
+
+        this.reorder = new DragReorder({
+            container: this.rowsEl,
+            itemSelector: '.link-row',
+            handleSelector: '.js-link-drag-handle',
+            onReorder: (newOrder) => {
+                this.links = newOrder.map(item => this.links[parseInt(item.element.dataset.index, 10)]);
+                this.renderLinks();
+            }
+        });
+    }
+
+    // Rows with a URL, trimmed, in display order
+    getLinks() {
+        return this.links
+            .map(link => ({ ...link, label: link.label.trim(), url: link.url.trim() }))
+            .filter(link => link.url);
+    }
+
+    // Returns an error message for the first invalid link, or null
+    validate() {
+        for (const link of this.getLinks()) {
+            if (link.kind === 'web' && !Utils.isValidURL(link.url)) {
+                return `Please enter a valid URL for ${link.label || link.url}`;
+            }
+        }
+        return null;
+    }
+
+    // Saves the differences with the loaded links to a project
+    async save(projectId) {
+        const links = this.getLinks();
+        const kept = new Set(links.filter(link => link.id).map(link => link.id));
+
+        for (const id of this.original.keys()) {
+            if (!kept.has(id)) await API.deleteProjectLink(id);
+        }
+
+        for (const [order, link] of links.entries()) {
+            const original = link.id ? this.original.get(link.id) : null;
+            if (!original) {
+                await API.createProjectLink({ project_id: projectId, label: link.label, url: link.url, kind: link.kind, order });
+                continue;
+            }
+
+            const updates = {};
+            if (link.label !== original.label) updates.label = link.label;
+            if (link.url !== original.url) updates.url = link.url;
+            if (link.kind !== original.kind) updates.kind = link.kind;
+            if (order !== original.order) updates.order = order;
+            if (Object.keys(updates).length > 0) {
+                await API.updateProjectLink(link.id, updates);
+            }
+        }
+    }
+}
+
+export default LinkList;
@@ -0,0 +1,174 @@
+/**
+ * Milestone List
+ * Project milestone editor shown in the project form; changes are saved together with the project
+ */
+
+import API from './api.js';
+import Utils from './utils.js';
+
+class MilestoneList {
+    constructor(containerId) {
+        this.container = document.getElementById(containerId);
+        this.milestones = [];
+        this.original = new Map();
+
+        if (!this.container) {
+            console.error('MilestoneList: Container not found');
+            return;
+        }
+
+        this.render();
+        this.bindEvents();
+        this.renderMilestones();
+    }
+
+    render() {
+        this.container.innerHTML = `
+            <div class="milestone-rows"></div>
+            <button type="button" class="btn btn-secondary milestone-add">
+                <i class="fas fa-plus"></i> Add milestone
+            </button>
+        `;
+        this.rowsEl = this.container.querySelector('.milestone-rows');
+        this.addBtn = this.container.querySelector('.milestone-add');
+    }
+
+    bindEvents() {
+        this.addBtn.addEventListener('click', () => {
+            this.milestones.push({ id: null, title: '', due_date: '', done: false, estimated_hours: '' });
+            this.renderMilestones();
+            const fields = this.rowsEl.querySelectorAll('.milestone-title-field');
+            fields[fields.length - 1]?.focus();
+        });
+
+        this.rowsEl.addEventListener('input', (e) => {
+            const row = e.target.closest('.milestone-row');
+            if (!row) return;
+            const milestone = this.milestones[parseInt(row.dataset.index, 10)];
+            if (e.target.classList.contains('milestone-title-field')) {
+                milestone.title = e.target.value;
+            } else if (e.target.classList.contains('milestone-date-field')) {
+                milestone.due_date = e.target.value;
+            } else if (e.target.classList.contains('milestone-hours-field')) {
+                milestone.estimated_hours = e.target.value;
+            } else if (e.target.classList.contains('milestone-done-field')) {
+                milestone.done = e.target.checked;
+            }
+        });
+
+        this.rowsEl.addEventListener('keydown', (e) => {
+            if (e.key === 'Enter' && e.target.matches('.milestone-row input')) {
+                // Keep Enter from submitting the surrounding form
+                e.preventDefault();
+                e.target.blur();
+            }
+        });
+
+        this.rowsEl.addEventListener('click', (e) => {
+            const btn = e.target.closest('.tag-action-btn');
+            if (!btn || btn.dataset.action !== 'delete') return;
+            this.milestones.splice(parseInt(btn.closest('.milestone-row').dataset.index, 10), 1);
+            this.renderMilestones();
+        });
+    }
+
+    load(milestones) {
+        this.milestones = (milestones || []).map(milestone => ({
+            id: milestone.id,
+            title: milestone.title,
+            due_date: Utils.formatDateForInput(milestone.due_date),
+            done: !!milestone.done,
+            estimated_hours: milestone.estimated_hours ?? ''
+        }));
+        this.original = new Map(this.milestones.map(milestone => [milestone.id, { ...milestone }]));
+        this.renderMilestones();
+    }
+
+    clear() {
+        this.load([]);
+    }
+
+    renderMilestones() {
+        if (this.milestones.length === 0) {
+            this.rowsEl.innerHTML = '<span class="no-tags">No milestones yet</span>';
+            return;
+        }
+
+        this.rowsEl.innerHTML = this.milestones.map((milestone, index) => `
+            <div class="milestone-row" data-index="${index}">
+                <input type="checkbox" class="milestone-done-field" title="Done">
+                <input type="text" class="milestone-title-field" placeholder="Title" autocomplete="off">
+                <input type="date" class="milestone-date-field" title="Due date" autocomplete="off">
+                <input type="number" class="milestone-hours-field" placeholder="Hours" min="0" step="0.25" title="Hour budget (optional)" autocomplete="off">
+                <button type="button" class="tag-action-btn delete" data-action="delete" title="Remove milestone">
+                    <i class="fas fa-trash"></i>
+                </button>
+            </div>
+        `).join('');
+        // Values are set programmatically so quotes in them cannot break the markup
+        this.rowsEl.querySelectorAll('.milestone-row').forEach((row, index) => {
+            const milestone = this.milestones[index];
+            row.querySelector('.milestone-done-field').checked = milestone.done;
+            row.querySelector('.milestone-title-field').value = milestone.title;
+            row.querySelector('.milestone-date-field').value = milestone.due_date;
+            row.querySelector('.milestone-hours-field').value = milestone.estimated_hours;
+        });
+    }
+
+    // Rows with a title or a date, trimmed
+    getMilestones() {
+        return this.milestones
+            .map(milestone => ({ ...milestone, title: milestone.title.trim() }))
+            .filter(milestone => milestone.title || milestone.due_date);
+    }
+
+    // Returns an error message for the first incomplete milestone, or null
+    validate() {
+        for (const milestone of this.getMilestones()) {
+            if (!milestone.title) return 'Please enter a title for every milestone';
+            if (!milestone.due_date) return `Please enter a due date for ${milestone.title}`;
+            if (parseFloat(milestone.estimated_hours) < 0) return `The hour budget of ${milestone.title} cannot be negative`;
+        }
+        return null;
+    }
+
+    // Saves the differences with the loaded milestones to a project
+    async save(projectId) {
+        const milestones = this.getMilestones();
+        const kept = new Set(milestones.filter(milestone => milestone.id).map(milestone => milestone.id));
+
+        for (const id of this.original.keys()) {
+            if (!kept.has(id)) await API.deleteMilestone(id);
+        }
+
+        for (const milestone of milestones) {
+            // Local midnight, like project deadlines, so the date does not shift with the time zone
+            const [y, m, d] = milestone.due_date.split('-').map(Number);
+            const dueDate = new Date(y, m - 1, d);
+            const hours = parseFloat(milestone.estimated_hours) || 0;
+
+            const original = milestone.id ? this.original.get(milestone.id) : null;
+            if (!original) {
+                const created = await API.createMilestone({
+                    project_id: projectId,
+                    title: milestone.title,
+                    due_date: dueDate,
+                    estimated_hours: hours || null
+                });
+                if (milestone.done) await API.updateMilestone(created.id, { done: true });
+                continue;
+            }
+
+            const updates = {};
+            if (milestone.title !== original.title) updates.title = milestone.title;
+            if (milestone.due_date !== original.due_date) updates.due_date = dueDate;
+            if (milestone.done !== original.done) updates.done = milestone.done;
+            if (hours !== (parseFloat(original.estimated_hours) || 0)) updates.estimated_hours = hours;
+            if (Object.keys(updates).length > 0) {
+                await API.updateMilestone(milestone.id, updates);
+            }
+        }
+    }
+}
+
+export default MilestoneList;
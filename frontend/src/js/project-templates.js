@@ -0,0 +1,203 @@
+/**
+ * Project Templates
+ * Saves projects as templates and creates new projects from them; one modal serves both
+ */
+
+import API from './api.js';
+import Utils from './utils.js';
+import Dialog from './dialog.js';
+import StandardModal from './standard-modal.js';
+
+class ProjectTemplates {
+    constructor(options = {}) {
+        this.options = {
+            onProjectCreated: null,
+            ...options
+        };
+        this.templates = [];
+        this.mode = 'use';
+        this.sourceProjectId = null;
+
+        this.initializeElements();
+        this.bindEvents();
+    }
+
+    initializeElements() {
+        this.fromTemplateBtn = document.getElementById('add-project-from-template');
+
+        this.templateModal = new StandardModal('project-template-modal', {
+            title: 'New Project from Template',
+            icon: 'fas fa-clone',
+            iconType: 'project'
+        });
+
+        this.selectGroup = document.getElementById('project-template-select-group');
+        this.selectField = document.getElementById('project-template-select');
+        this.summaryEl = document.getElementById('project-template-summary');
+        this.deleteBtn = document.getElementById('delete-project-template');
+        this.nameField = document.getElementById('project-template-name');
+        this.nameLabel = document.getElementById('project-template-name-label');
+        this.submitBtn = document.getElementById('submit-project-template');
+    }
+
+    bindEvents() {
+        this.fromTemplateBtn?.addEventListener('click', () => this.openUse());
+
+        this.selectField?.addEventListener('change', () => this.updateSummary());
+        this.deleteBtn?.addEventListener('click', () => this.deleteSelected());
+
+        this.templateModal.setFormHandler('project-template-form', (e) => this.handleSubmit(e));
+        document.getElementById('cancel-project-template')?.addEventListener('click', () => this.closeModal());
+        this.templateModal.modal.querySelector('.standard-modal-close')?.addEventListener('click', () => this.closeModal());
+
+        document.addEventListener('keydown', (e) => {
+            if (e.key === 'Escape' && this.templateModal.isVisible) {
+                this.closeModal();
+            }
+        });
+    }
+
+    async loadTemplates() {
+        try {
+            this.templates = await API.getAllProjectTemplates() || [];
+        } catch (error) {
+            console.error('Error loading project templates:', error);
+            this.templates = [];
+        }
+        if (this.fromTemplateBtn) {
+            this.fromTemplateBtn.style.display = this.templates.length > 0 ? '' : 'none';
+        }
+    }
+
+    // Opens the modal to create a project from one of the saved templates
+    async openUse() {
+        await this.loadTemplates();
+        if (this.templates.length === 0) return;
+
+        this.mode = 'use';
+        this.templateModal.setTitle('New Project from Template');
+        this.selectGroup.style.display = '';
+        this.nameLabel.textContent = 'Project Name';
+        this.submitBtn.textContent = 'Create Project';
+
+        this.selectField.innerHTML = this.templates.map(template =>
+            `<option value="${template.id}">${Utils.escapeHtml(template.name)}</option>`
+        ).join('');
+        this.updateSummary();
+        this.openModal();
+    }
+
+    // Opens the modal to save a project as a new template
+    openSave(project) {
+        this.mode = 'save';
+        this.sourceProjectId = project.id;
+        this.templateModal.setTitle('Save as Template');
+        this.selectGroup.style.display = 'none';
+        this.nameLabel.textContent = 'Template Name';
+        this.submitBtn.textContent = 'Save Template';
+        this.nameField.value = project.name;
+        this.openModal();
+        this.nameField.select();
+    }
+
+    getSelectedTemplate() {
+        const id = parseInt(this.selectField.value, 10);
+        return this.templates.find(template => template.id === id) || null;
+    }
+
+    updateSummary() {
+        const template = this.getSelectedTemplate();
+        if (!template) {
+            this.summaryEl.textContent = '';
+            return;
+        }
+
+        const countTasks = (tasks) => tasks.reduce((count, task) => count + 1 + countTasks(task.children || []), 0);
+        const parts = [
+            template.links.length ? `${template.links.length} link${template.links.length === 1 ? '' : 's'}` : '',
+            template.tags.length ? `${template.tags.length} tag${template.tags.length === 1 ? '' : 's'}` : '',
+            template.tasks.length ? `${countTasks(template.tasks)} task${countTasks(template.tasks) === 1 ? '' : 's'}` : '',
+            template.directory ? `directory ${template.directory}` : ''
+        ].filter(Boolean);
+        this.summaryEl.textContent = parts.length ? `Includes ${parts.join(', ')}` : 'Includes the project details only';
+        this.nameField.placeholder = template.name;
+    }
+
+    async deleteSelected() {
+        const template = this.getSelectedTemplate();
+        if (!template) return;
+
+        const confirmed = await Dialog.confirm(
+            'Delete Template',
+            `Delete the template "${template.name}"? Projects created from it are kept.`,
+            { confirmText: 'Delete', cancelText: 'Cancel', confirmType: 'danger' }
+        );
+        if (!confirmed) return;
+
+        try {
+            await API.deleteProjectTemplate(template.id);
+            Utils.showNotification('Success', 'Template deleted', 'success');
+        } catch (error) {
+            console.error('Error deleting project template:', error);
+            Utils.showNotification('Error', String(error || 'Failed to delete template'), 'error');
+            return;
+        }
+
+        await this.loadTemplates();
+        if (this.templates.length === 0) {
+            this.closeModal();
+            return;
+        }
+        this.selectField.querySelector(`option[value="${template.id}"]`)?.remove();
+        this.updateSummary();
+    }
+
+    openModal() {
+        this.templateModal.show();
+        this.nameField.focus();
+    }
+
+    closeModal() {
+        this.templateModal.hide();
+        setTimeout(() => {
+            this.sourceProjectId = null;
+            this.templateModal.resetForm('project-template-form');
+            this.nameField.placeholder = '';
+        }, 200);
+    }
+
+    async handleSubmit(e) {
+        e.preventDefault();
+
+        const name = this.nameField.value.trim();
+
+        try {
+            if (this.mode === 'save') {
+                if (!name) {
+                    Utils.showNotification('Error', 'Template name is required', 'error');
+                    return;
+                }
+                await API.saveProjectAsTemplate(this.sourceProjectId, name);
+                Utils.showNotification('Success', 'Template saved successfully!', 'success');
+                this.closeModal();
+                await this.loadTemplates();
+            } else {
+                const template = this.getSelectedTemplate();
+                if (!template) return;
+                // An empty name takes the template's name
+                const project = await API.createProjectFromTemplate(template.id, name);
+                Utils.showNotification('Success', 'Project created successfully!', 'success');
+                this.closeModal();
+                if (this.options.onProjectCreated) {
+                    await this.options.onProjectCreated(project);
+                }
+            }
+        } catch (error) {
+            console.error('Error saving project template:', error);
+            const fallback = this.mode === 'save' ? 'Failed to save template' : 'Failed to create project';
+            Utils.showNotification('Error', String(error || fallback), 'error');
+        }
+    }
+}
+
+export default ProjectTemplates;
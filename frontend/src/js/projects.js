@@ -5,6 +5,12 @@ import Dialog from './dialog.js';
 import Tooltip from './tooltip.js';
 import StandardModal from './standard-modal.js';
 import DragReorder from './drag-reorder.js';
+import TagSelector from './tag-selector.js';
+import TaskList from './task-list.js';
+import LinkList from './link-list.js';
+import MilestoneList from './milestone-list.js';
+import FieldInputs from './field-inputs.js';
+import ProjectTemplates from './project-templates.js';
 import * as Runtime from '../../wailsjs/runtime/runtime.js';
 
 class Projects {
@@ -12,7 +18,11 @@ class Projects {
         this.projects = [];
         this.currentEditingId = null;
         this.projectDurations = new Map(); // cache project total durations in seconds
+        this.burnDowns = new Map(); // cache burn-down of projects with an hour budget
+        this.clients = [];
         this.projectReorder = null;
+        this.archivedProjects = [];
+        this.archivedProjectsExpanded = false;
         
         this.initializeElements();
         this.bindEvents();
@@ -33,12 +43,17 @@ class Projects {
                     this.projectModal.setIcon('fas fa-edit', 'project-edit');
                     this.nameField.value = project.name || '';
                     this.descriptionField.value = project.description || '';
-                    this.url1Field.value = project.url1 || '';
-                    this.url2Field.value = project.url2 || '';
-                    this.url3Field.value = project.url3 || '';
-                    if (this.discordField) this.discordField.value = project.discord || '';
+                    this.linkList.load(project.links);
                     this.directoryField.value = project.directory || '';
                     this.deadlineField.value = project.deadline ? Utils.formatDateForInput(project.deadline) : '';
+                    this.milestoneList.load(project.milestones);
+                    this.hourlyRateField.value = project.hourly_rate || '';
+                    this.estimatedHoursField.value = project.estimated_hours || '';
+                    this.billableField.checked = !!project.billable;
+                    this.clientField.value = project.client_id || '';
+                    this.tagSelector.setSelected(project.tags);
+                    this.fieldInputs.setValues(project.custom_fields);
+                    this.taskList.load(project.id);
                     this.openModal();
                 }
             } catch (e) {
@@ -50,6 +65,9 @@ class Projects {
     initializeElements() {
         this.activeProjectsList = document.getElementById('active-projects-list');
         this.completedProjectsList = document.getElementById('completed-projects-list');
+        this.archivedProjectsList = document.getElementById('archived-projects-list');
+        this.archivedProjectsSection = document.getElementById('archived-projects-section');
+        this.archivedProjectsHeader = this.archivedProjectsSection ? this.archivedProjectsSection.querySelector('.section-header') : null;
         this.completedProjectsSection = document.getElementById('completed-projects-section');
         this.completedProjectsHeader = this.completedProjectsSection ? this.completedProjectsSection.querySelector('.section-header') : null;
         this.toggleCompletedBtn = document.getElementById('toggle-completed-projects');
@@ -67,12 +85,20 @@ class Projects {
         // Form fields
         this.nameField = document.getElementById('project-name');
         this.descriptionField = document.getElementById('project-description');
-        this.url1Field = document.getElementById('project-url1');
-        this.url2Field = document.getElementById('project-url2');
-        this.url3Field = document.getElementById('project-url3');
-        this.discordField = document.getElementById('project-discord');
     this.directoryField = document.getElementById('project-directory');
         this.deadlineField = document.getElementById('project-deadline');
+        this.hourlyRateField = document.getElementById('project-hourly-rate');
+        this.estimatedHoursField = document.getElementById('project-estimated-hours');
+        this.billableField = document.getElementById('project-billable');
+        this.clientField = document.getElementById('project-client');
+        this.tagSelector = new TagSelector('project-tags');
+        this.taskList = new TaskList('project-tasks');
+        this.linkList = new LinkList('project-links');
+        this.milestoneList = new MilestoneList('project-milestones');
+        this.fieldInputs = new FieldInputs('project-fields');
+        this.templates = new ProjectTemplates({
+            onProjectCreated: (project) => this.openCreatedProject(project)
+        });
 
         // Add tooltip to Add Project button via TooltipManager if available
         if (this.addProjectBtn) {
@@ -120,6 +146,23 @@ class Projects {
             });
         }
 
+        // Archived projects stay collapsed until asked for
+        this.archivedProjectsHeader?.addEventListener('click', () => this.toggleArchivedProjects());
+
+        // Client names and the client selector follow changes made on the settings page
+        window.addEventListener('clientsUpdated', () => this.loadProjects());
+
+        // Projects restored from the trash come back into the list
+        window.addEventListener('trashUpdated', () => this.loadProjects());
+
+        // The backend reports when tracked time pushes a budget over the alert threshold
+        Runtime.EventsOn('budget:alert', (alert) => this.handleBudgetAlert(alert));
+        Runtime.EventsOn('deadline:reminder', (reminder) => this.handleDeadlineReminder(reminder));
+
+        // Keep project totals in sync when time blocks change or move between projects
+        window.addEventListener('timeBlockUpdated', Utils.debounce(() => this.renderProjects(), 300));
+        window.addEventListener('customFieldsUpdated', () => this.fieldInputs.refresh());
+
         // Close modal with escape key
         document.addEventListener('keydown', (e) => {
             if (e.key === 'Escape' && this.projectModal.isVisible) {
@@ -131,6 +174,9 @@ class Projects {
     async loadProjects() {
         try {
             this.projects = await API.getAllProjects() || [];
+            this.archivedProjects = await API.getArchivedProjects() || [];
+            await this.loadClients();
+            await this.templates.loadTemplates();
             this.renderProjects();
             this.updateProjectSelectors();
             // Notify other modules (calendar, timeblocks, etc.) that projects changed
@@ -143,6 +189,27 @@ class Projects {
         }
     }
 
+    async loadClients() {
+        try {
+            this.clients = await API.getAllClients() || [];
+        } catch (error) {
+            this.clients = [];
+        }
+
+        if (this.clientField) {
+            const currentValue = this.clientField.value;
+            this.clientField.innerHTML = '<option value="">No client</option>' + this.clients.map(client =>
+                `<option value="${client.id}">${Utils.escapeHtml(client.name)}</option>`
+            ).join('');
+            this.clientField.value = currentValue;
+        }
+    }
+
+    getClientName(clientId) {
+        const client = this.clients.find(c => c.id === clientId);
+        return client ? client.name : null;
+    }
+
     async renderProjects() {
         const activeProjects = this.projects.filter(p => p.status !== 'completed');
         const completedProjects = this.projects.filter(p => p.status === 'completed');
@@ -151,7 +218,7 @@ class Projects {
         if (!this.activeProjectsList) return;
 
         // Pre-fetch durations for visible projects to display totals
-        await this.fetchProjectDurations([...activeProjects, ...completedProjects]);
+        await this.fetchProjectDurations([...activeProjects, ...completedProjects, ...this.archivedProjects]);
 
         if (activeProjects.length === 0) {
             this.activeProjectsList.innerHTML = `
@@ -174,6 +241,13 @@ class Projects {
             this.completedProjectsSection.style.display = 'none';
         }
 
+        // Render archived projects
+        if (this.archivedProjectsSection) {
+            this.archivedProjectsSection.style.display = this.archivedProjects.length > 0 ? 'block' : 'none';
+            this.archivedProjectsList.innerHTML = this.archivedProjects.map(project => this.createProjectCard(project)).join('');
+            this.updateArchivedProjectsVisibility();
+        }
+
         this.bindProjectEvents();
         this.initProjectReorder();
     }
@@ -195,13 +269,14 @@ class Projects {
         if (statusClass === 'active') statusIcon = '<i class="fas fa-play-circle"></i>';
         else if (statusClass === 'paused') statusIcon = '<i class="fas fa-pause-circle"></i>';
         else if (statusClass === 'completed') statusIcon = '<i class="fas fa-check-circle"></i>';
+        else if (statusClass === 'archived') statusIcon = '<i class="fas fa-box-archive"></i>';
 
     const totalSeconds = this.projectDurations.get(project.id) || 0;
     const totalDisplay = totalSeconds > 0 ? Utils.formatDurationShort(totalSeconds) : '0m';
 
         return `
             <div class="project-card" data-id="${project.id}">
-                ${statusClass !== 'completed' ? '<span class="drag-handle js-drag-handle" title="Drag to reorder">⠿</span>' : ''}
+                ${statusClass !== 'completed' && statusClass !== 'archived' ? '<span class="drag-handle js-drag-handle" title="Drag to reorder">⠿</span>' : ''}
                 <div class="project-info">
                     <div class="project-header">
                         <div class="project-title-section">
@@ -213,6 +288,7 @@ class Projects {
                             ${project.description ? `
                                 <p class="project-description">${Utils.escapeHtml(project.description)}</p>
                             ` : ''}
+                            ${TagSelector.renderChips(project.tags)}
                         </div>
                     </div>
                     
@@ -221,36 +297,37 @@ class Projects {
                             <i class="fas fa-calendar-plus"></i>
                             <span>Created: ${Utils.formatDate(project.created_at)}</span>
                         </div>
-                        ${deadline ? `
+                        ${project.client_id && this.getClientName(project.client_id) ? `
                             <div class="project-meta-item">
-                                <i class="fas fa-calendar-check"></i>
-                                <span>Deadline: ${deadline}</span>
+                                <i class="fas fa-briefcase"></i>
+                                <span>${Utils.escapeHtml(this.getClientName(project.client_id))}</span>
                             </div>
                         ` : ''}
-                        ${project.url1 ? `
+                        ${project.billable ? `
                             <div class="project-meta-item">
-                                <i class="${this.getUrlIcon(project.url1)}"></i>
-                                <a href="${project.url1}" target="_blank" class="project-url">${Utils.escapeHtml(project.url1)}</a>
+                                <i class="fas fa-coins"></i>
+                                <span>Billable${project.hourly_rate ? `: ${Utils.formatMoney(project.hourly_rate)}/h` : ''}</span>
                             </div>
                         ` : ''}
-                        ${project.url2 ? `
+                        ${this.burnDowns.has(project.id) ? this.renderBurnDown(this.burnDowns.get(project.id)) : ''}
+                        ${deadline ? `
                             <div class="project-meta-item">
-                                <i class="${this.getUrlIcon(project.url2)}"></i>
-                                <a href="${project.url2}" target="_blank" class="project-url">${Utils.escapeHtml(project.url2)}</a>
+                                <i class="fas fa-calendar-check"></i>
+                                <span>Deadline: ${deadline}</span>
                             </div>
                         ` : ''}
-                        ${project.url3 ? `
+                        ${this.renderNextMilestone(project.milestones)}
+                        ${(project.links || []).map(link => link.kind === 'discord' ? `
                             <div class="project-meta-item">
-                                <i class="${this.getUrlIcon(project.url3)}"></i>
-                                <a href="${project.url3}" target="_blank" class="project-url">${Utils.escapeHtml(project.url3)}</a>
+                                <i class="fab fa-discord"></i>
+                                <a href="#" class="project-discord" data-discord="${Utils.escapeHtml(link.url)}">${Utils.escapeHtml(link.label || link.url)}</a>
                             </div>
-                        ` : ''}
-                        ${project.discord ? `
+                        ` : `
                             <div class="project-meta-item">
-                                <i class="fab fa-discord"></i>
-                                <a href="#" class="project-discord" data-discord="${Utils.escapeHtml(project.discord)}">${Utils.escapeHtml(project.discord)}</a>
+                                <i class="${this.getUrlIcon(link.url)}"></i>
+                                <a href="${Utils.escapeHtml(link.url)}" target="_blank" class="project-url">${Utils.escapeHtml(link.label || link.url)}</a>
                             </div>
-                        ` : ''}
+                        `).join('')}
                         ${project.directory ? `
                             <div class="project-meta-item">
                                 <i class="fas fa-folder-open"></i>
@@ -261,6 +338,11 @@ class Projects {
                 </div>
                 
                 <div class="project-actions">
+                    ${project.status === 'archived' ? `
+                        <button class="project-action-btn restore" data-action="restore" data-id="${project.id}" title="Restore">
+                            <i class="fas fa-box-open"></i>
+                        </button>
+                    ` : `
                     <button class="project-action-btn edit" data-action="edit" data-id="${project.id}" title="Edit">
                         <i class="fas fa-edit"></i>
                     </button>
@@ -279,6 +361,16 @@ class Projects {
                             <i class="fas fa-undo"></i>
                         </button>
                     `}
+                    <button class="project-action-btn duplicate" data-action="duplicate" data-id="${project.id}" title="Duplicate">
+                        <i class="fas fa-clone"></i>
+                    </button>
+                    <button class="project-action-btn template" data-action="template" data-id="${project.id}" title="Save as template">
+                        <i class="fas fa-bookmark"></i>
+                    </button>
+                    <button class="project-action-btn archive" data-action="archive" data-id="${project.id}" title="Archive">
+                        <i class="fas fa-box-archive"></i>
+                    </button>
+                    `}
                     <button class="project-action-btn delete" data-action="delete" data-id="${project.id}" title="Delete">
                         <i class="fas fa-trash"></i>
                     </button>
@@ -290,7 +382,8 @@ class Projects {
     bindProjectEvents() {
         const actionButtons = [
             ...this.activeProjectsList?.querySelectorAll('.project-action-btn') || [],
-            ...this.completedProjectsList?.querySelectorAll('.project-action-btn') || []
+            ...this.completedProjectsList?.querySelectorAll('.project-action-btn') || [],
+            ...this.archivedProjectsList?.querySelectorAll('.project-action-btn') || []
         ];
         
         actionButtons.forEach(btn => {
@@ -316,7 +409,8 @@ class Projects {
         // Open project URLs in the system default browser (not inside the app webview)
         const urlLinks = [
             ...this.activeProjectsList?.querySelectorAll('.project-url') || [],
-            ...this.completedProjectsList?.querySelectorAll('.project-url') || []
+            ...this.completedProjectsList?.querySelectorAll('.project-url') || [],
+            ...this.archivedProjectsList?.querySelectorAll('.project-url') || []
         ];
 
         urlLinks.forEach(link => {
@@ -336,7 +430,8 @@ class Projects {
         // Directory links - open folder in OS file explorer using backend
         const dirLinks = [
             ...this.activeProjectsList?.querySelectorAll('.project-directory') || [],
-            ...this.completedProjectsList?.querySelectorAll('.project-directory') || []
+            ...this.completedProjectsList?.querySelectorAll('.project-directory') || [],
+            ...this.archivedProjectsList?.querySelectorAll('.project-directory') || []
         ];
 
         dirLinks.forEach(link => {
@@ -366,7 +461,8 @@ class Projects {
         // Discord links - attempt to open in Discord app using discord:// protocol
         const discordLinks = [
             ...this.activeProjectsList?.querySelectorAll('.project-discord') || [],
-            ...this.completedProjectsList?.querySelectorAll('.project-discord') || []
+            ...this.completedProjectsList?.querySelectorAll('.project-discord') || [],
+            ...this.archivedProjectsList?.querySelectorAll('.project-discord') || []
         ];
 
         discordLinks.forEach(link => {
@@ -503,11 +599,70 @@ class Projects {
                 console.error('Error fetching duration for project', p.id, err);
                 this.projectDurations.set(p.id, 0);
             }
+            if (!p.estimated_hours) {
+                this.burnDowns.delete(p.id);
+                return;
+            }
+            try {
+                this.burnDowns.set(p.id, await API.getProjectBurnDown(p.id));
+            } catch (err) {
+                console.error('Error fetching burn-down for project', p.id, err);
+                this.burnDowns.delete(p.id);
+            }
         });
 
         await Promise.all(promises);
     }
 
+    renderBurnDown(burnDown) {
+        const projected = burnDown.projected_completion
+            ? ` · done around ${Utils.formatDate(burnDown.projected_completion)}`
+            : '';
+        return `
+            <div class="project-meta-item project-budget ${burnDown.percent_burned >= 100 ? 'over-budget' : ''}">
+                <i class="fas fa-hourglass-half"></i>
+                <span>Budget: ${burnDown.consumed_hours}h of ${burnDown.estimated_hours}h (${burnDown.percent_burned}%)${projected}</span>
+            </div>
+        `;
+    }
+
+    handleBudgetAlert(alert) {
+        const burnDown = alert.burn_down;
+        Utils.showNotification(
+            'Budget Alert',
+            `${burnDown.name} has used ${burnDown.percent_burned}% of its ${burnDown.estimated_hours}h budget`,
+            'warning'
+        );
+        this.renderProjects();
+    }
+
+    // Shows the earliest open milestone with how many of the project's milestones are done
+    renderNextMilestone(milestones) {
+        if (!milestones || milestones.length === 0) return '';
+        const next = milestones.find(milestone => !milestone.done);
+        const done = milestones.filter(milestone => milestone.done).length;
+        const progress = `${done}/${milestones.length} done`;
+        return `
+            <div class="project-meta-item">
+                <i class="fas fa-flag"></i>
+                <span>${next
+                    ? `Next milestone: ${Utils.escapeHtml(next.title)} · ${Utils.formatDate(next.due_date)} (${progress})`
+                    : `Milestones: ${progress}`}</span>
+            </div>
+        `;
+    }
+
+    handleDeadlineReminder(reminder) {
+        const deadline = reminder.deadline;
+        const name = deadline.milestone_id
+            ? `${deadline.project_name}: ${deadline.title}`
+            : deadline.project_name;
+        const when = reminder.days_left === 0 ? 'today'
+            : reminder.days_left === 1 ? 'tomorrow'
+            : `in ${reminder.days_left} days`;
+        Utils.showNotification('Deadline Reminder', `${name} is due ${when}`, 'warning');
+    }
+
     async handleProjectAction(action, id) {
         try {
             switch (action) {
@@ -526,6 +681,18 @@ class Projects {
                 case 'uncomplete':
                     await this.updateProjectStatus(id, 'active');
                     break;
+                case 'duplicate':
+                    await this.duplicateProject(id);
+                    break;
+                case 'template':
+                    this.templates.openSave(this.getProjectById(id));
+                    break;
+                case 'archive':
+                    await this.archiveProject(id);
+                    break;
+                case 'restore':
+                    await this.restoreProject(id);
+                    break;
                 case 'delete':
                     await this.deleteProject(id);
                     break;
@@ -547,12 +714,17 @@ class Projects {
         // Populate form
         this.nameField.value = project.name;
         this.descriptionField.value = project.description || '';
-        this.url1Field.value = project.url1 || '';
-        this.url2Field.value = project.url2 || '';
-        this.url3Field.value = project.url3 || '';
-        if (this.discordField) this.discordField.value = project.discord || '';
+        this.linkList.load(project.links);
+        this.milestoneList.load(project.milestones);
     this.directoryField.value = project.directory || '';
         this.deadlineField.value = project.deadline ? Utils.formatDateForInput(project.deadline) : '';
+        this.hourlyRateField.value = project.hourly_rate || '';
+        this.estimatedHoursField.value = project.estimated_hours || '';
+        this.billableField.checked = !!project.billable;
+        this.clientField.value = project.client_id || '';
+        this.tagSelector.setSelected(project.tags);
+        this.fieldInputs.setValues(project.custom_fields);
+        this.taskList.load(id);
         
         this.openModal();
     }
@@ -563,10 +735,34 @@ class Projects {
         Utils.showNotification('Success', `Project ${status} successfully!`, 'success');
     }
 
+    async duplicateProject(id) {
+        const project = await API.duplicateProject(id);
+        Utils.showNotification('Success', 'Project duplicated; time blocks are not copied', 'success');
+        await this.openCreatedProject(project);
+    }
+
+    // Shows a project created from another one or a template in the editor, for the details that differ
+    async openCreatedProject(project) {
+        await this.loadProjects();
+        await this.editProject(project.id);
+    }
+
+    async archiveProject(id) {
+        await API.archiveProject(id);
+        await this.loadProjects();
+        Utils.showNotification('Success', 'Project archived; its time stays in reports', 'success');
+    }
+
+    async restoreProject(id) {
+        await API.restoreProject(id);
+        await this.loadProjects();
+        Utils.showNotification('Success', 'Project restored successfully!', 'success');
+    }
+
     async deleteProject(id) {
         const confirmed = await Dialog.confirm(
             'Delete Project',
-            'Are you sure you want to delete this project? This action cannot be undone and will delete all associated time blocks.',
+            'Delete this project and its time blocks? They are moved to the trash and can be restored from the settings page.',
             {
                 confirmText: 'Delete',
                 cancelText: 'Cancel',
@@ -582,6 +778,8 @@ class Projects {
     }
 
     openModal() {
+        this.tagSelector.refresh();
+        this.fieldInputs.refresh();
         this.projectModal.show();
         this.nameField.focus();
     }
@@ -595,6 +793,11 @@ class Projects {
                 this.currentEditingId = null;
                 // reset the form fields
                 this.projectModal.resetForm('project-form');
+                this.tagSelector.setSelected([]);
+                this.taskList.clear();
+                this.linkList.clear();
+                this.milestoneList.clear();
+                this.fieldInputs.setValues({});
                 
                 // Reset title and icon to add mode
                 this.projectModal.setTitle('Add Project');
@@ -613,20 +816,6 @@ class Projects {
 
         const formData = new FormData(this.projectForm);
 
-        const rawUrl1 = formData.get('url1');
-        const rawUrl2 = formData.get('url2');
-        const rawUrl3 = formData.get('url3');
-        const rawDiscord = formData.get('discord');
-
-        const processUrl = (raw) => {
-            if (this.currentEditingId) return raw === null ? '' : raw.trim();
-            return raw && raw.trim() !== '' ? raw.trim() : null;
-        };
-
-        let url1Value = processUrl(rawUrl1);
-        let url2Value = processUrl(rawUrl2);
-        let url3Value = processUrl(rawUrl3);
-
         // Handle deadline: create a local-midnight Date to avoid UTC shifts
         const rawDeadline = formData.get('deadline');
         let deadlineValue = null;
@@ -650,12 +839,14 @@ class Projects {
         const projectData = {
             name: formData.get('name').trim(),
             description: formData.get('description').trim() || null,
-            url1: url1Value,
-            url2: url2Value,
-            url3: url3Value,
-            discord: (rawDiscord && String(rawDiscord).trim() !== '') ? String(rawDiscord).trim() : (this.currentEditingId ? '' : null),
             directory: directoryValue,
-            deadline: deadlineValue
+            deadline: deadlineValue,
+            billable: this.billableField.checked,
+            hourly_rate: parseFloat(formData.get('hourly_rate')) || 0,
+            // 0 removes the budget
+            estimated_hours: parseFloat(formData.get('estimated_hours')) || 0,
+            // 0 detaches the project from its client on update
+            client_id: parseInt(formData.get('client_id'), 10) || (this.currentEditingId ? 0 : null)
         };
 
         // Validation
@@ -664,19 +855,34 @@ class Projects {
             return;
         }
 
-        // Validate URLs
-        const urlsToValidate = [projectData.url1, projectData.url2, projectData.url3];
-        for (const url of urlsToValidate) {
-            if (url && url.length > 0 && !Utils.isValidURL(url)) {
-                Utils.showNotification('Error', 'Please enter a valid URL', 'error');
-                return;
-            }
+        if (projectData.hourly_rate < 0) {
+            Utils.showNotification('Error', 'Hourly rate cannot be negative', 'error');
+            return;
+        }
+
+        if (projectData.estimated_hours < 0) {
+            Utils.showNotification('Error', 'Estimated hours cannot be negative', 'error');
+            return;
+        }
+
+        // Validate links
+        const linkError = this.linkList.validate();
+        if (linkError) {
+            Utils.showNotification('Error', linkError, 'error');
+            return;
+        }
+
+        const milestoneError = this.milestoneList.validate();
+        if (milestoneError) {
+            Utils.showNotification('Error', milestoneError, 'error');
+            return;
         }
 
         try {
+            let saved;
             if (this.currentEditingId) {
                 // Update existing project
-                await API.updateProject(this.currentEditingId, projectData);
+                saved = await API.updateProject(this.currentEditingId, projectData);
                 Utils.showNotification('Success', 'Project updated successfully!', 'success');
             } else {
                 // Create new project - calculate order
@@ -684,10 +890,17 @@ class Projects {
                 const maxOrder = activeProjects.length > 0 ? Math.max(...activeProjects.map(p => p.order || 0)) : -1;
                 projectData.order = maxOrder + 1;
                 
-                await API.createProject(projectData);
+                saved = await API.createProject(projectData);
                 Utils.showNotification('Success', 'Project created successfully!', 'success');
             }
 
+            if (saved) {
+                await API.setProjectTags(saved.id, this.tagSelector.getSelected());
+                await this.linkList.save(saved.id);
+                await this.milestoneList.save(saved.id);
+                await API.setProjectFieldValues(saved.id, this.fieldInputs.getValues());
+            }
+
             this.closeModal();
             await this.loadProjects();
         } catch (error) {
@@ -714,21 +927,11 @@ class Projects {
                         const option = document.createElement('option');
                         option.value = project.id;
                         option.textContent = project.name;
-                        // Store the project url and directory on the option for quick access from other modules
-                        if (project.url1) option.setAttribute('data-url1', project.url1);
-                        else option.removeAttribute('data-url1');
-                        
-                        if (project.url2) option.setAttribute('data-url2', project.url2);
-                        else option.removeAttribute('data-url2');
-
-                        if (project.url3) option.setAttribute('data-url3', project.url3);
-                        else option.removeAttribute('data-url3');
+                        // Store the project links and directory on the option for quick access from other modules
+                        option.dataset.links = JSON.stringify(project.links || []);
 
                         if (project.directory) option.setAttribute('data-directory', project.directory);
                         else option.removeAttribute('data-directory');
-
-                        if (project.discord) option.setAttribute('data-discord', project.discord);
-                        else option.removeAttribute('data-discord');
                         selector.appendChild(option);
                     });
                 
@@ -790,6 +993,22 @@ class Projects {
         }
     }
 
+    updateArchivedProjectsVisibility() {
+        if (this.archivedProjectsList) {
+            this.archivedProjectsList.style.display = this.archivedProjectsExpanded ? 'block' : 'none';
+        }
+        this.archivedProjectsHeader?.classList.toggle('collapsed', !this.archivedProjectsExpanded);
+        const icon = this.archivedProjectsHeader?.querySelector('.toggle-btn i');
+        if (icon) {
+            icon.className = this.archivedProjectsExpanded ? 'fas fa-chevron-up' : 'fas fa-chevron-down';
+        }
+    }
+
+    toggleArchivedProjects() {
+        this.archivedProjectsExpanded = !this.archivedProjectsExpanded;
+        this.updateArchivedProjectsVisibility();
+    }
+
     async toggleCompletedProjects() {
         this.completedProjectsExpanded = !this.completedProjectsExpanded;
         this.updateCompletedProjectsVisibility();
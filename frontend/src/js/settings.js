@@ -1,6 +1,7 @@
 // Settings Module - Handles application settings
 import API from './api.js';
 import Utils from './utils.js';
+import Dialog from './dialog.js';
 
 class Settings {
     constructor() {
@@ -9,12 +10,23 @@ class Settings {
             language: 'en',
             timeFormat: '24',
             customUrl: '',
-            trelloUrl: ''
+            trelloUrl: '',
+            allowParallelTimers: false,
+            idleThresholdMinutes: 15,
+            pomodoroWorkMinutes: 25,
+            pomodoroShortBreakMinutes: 5,
+            pomodoroLongBreakMinutes: 15,
+            pomodoroLongBreakEvery: 4,
+            currency: 'USD',
+            budgetAlertThreshold: 80,
+            trashRetentionDays: 30,
+            deadlineReminderDays: 1
         };
         
         this.initializeElements();
         this.bindEvents();
         this.loadSettings();
+        this.loadTags();
     }
 
     initializeElements() {
@@ -24,6 +36,14 @@ class Settings {
         this.notificationsToggleLabel = document.getElementById('notifications-toggle-label');
         this.customUrlInput = document.getElementById('custom-url-input');
         this.trelloUrlInput = document.getElementById('trello-url-input');
+        this.parallelTimersToggle = document.getElementById('parallel-timers-toggle');
+        this.idleThresholdInput = document.getElementById('idle-threshold-input');
+        this.pomodoroInputs = document.querySelectorAll('.pomodoro-settings input[data-setting]');
+        this.currencyInput = document.getElementById('currency-input');
+        this.budgetAlertThresholdInput = document.getElementById('budget-alert-threshold-input');
+        this.trashRetentionInput = document.getElementById('trash-retention-input');
+        this.deadlineReminderInput = document.getElementById('deadline-reminder-days-input');
+        this.tagsManager = document.getElementById('tags-manager');
     }
 
     bindEvents() {
@@ -54,6 +74,55 @@ class Settings {
         this.trelloUrlInput?.addEventListener('blur', (e) => {
             this.updateTrelloUrl(e.target.value);
         });
+
+        this.parallelTimersToggle?.addEventListener('change', (e) => {
+            this.updateAllowParallelTimers(!!e.target.checked);
+        });
+
+        this.idleThresholdInput?.addEventListener('change', (e) => {
+            this.updateIdleThreshold(parseInt(e.target.value, 10));
+        });
+
+        this.pomodoroInputs?.forEach((input) => {
+            input.addEventListener('change', (e) => {
+                this.updatePomodoroSetting(e.target.dataset.setting, parseInt(e.target.value, 10), e.target);
+            });
+        });
+
+        this.currencyInput?.addEventListener('change', (e) => {
+            this.updateCurrency(e.target.value);
+        });
+
+        this.budgetAlertThresholdInput?.addEventListener('change', (e) => {
+            this.updateBudgetAlertThreshold(parseInt(e.target.value, 10));
+        });
+
+        this.trashRetentionInput?.addEventListener('change', (e) => {
+            this.updateTrashRetention(parseInt(e.target.value, 10));
+        });
+
+        this.deadlineReminderInput?.addEventListener('change', (e) => {
+            this.updateDeadlineReminderDays(parseInt(e.target.value, 10));
+        });
+
+        this.tagsManager?.addEventListener('change', (e) => {
+            const row = e.target.closest('.tag-row');
+            if (!row) return;
+            const id = parseInt(row.dataset.id, 10);
+            if (e.target.classList.contains('tag-name-input')) {
+                this.updateTag(id, { name: e.target.value });
+            } else if (e.target.classList.contains('tag-color-input')) {
+                this.updateTag(id, { color: e.target.value });
+            } else if (e.target.classList.contains('tag-merge-select') && e.target.value) {
+                this.mergeTag(id, parseInt(e.target.value, 10));
+            }
+        });
+
+        this.tagsManager?.addEventListener('click', (e) => {
+            const btn = e.target.closest('.tag-action-btn.delete');
+            if (!btn) return;
+            this.deleteTag(parseInt(btn.closest('.tag-row').dataset.id, 10));
+        });
     }
 
     async loadSettings() {
@@ -91,6 +160,34 @@ class Settings {
             this.trelloUrlInput.value = this.settings.trelloUrl || '';
         }
 
+        if (this.parallelTimersToggle) {
+            this.parallelTimersToggle.checked = !!this.settings.allowParallelTimers;
+        }
+
+        if (this.idleThresholdInput) {
+            this.idleThresholdInput.value = this.settings.idleThresholdMinutes ?? 15;
+        }
+
+        this.pomodoroInputs?.forEach((input) => {
+            input.value = this.settings[input.dataset.setting] ?? '';
+        });
+
+        if (this.currencyInput) {
+            this.currencyInput.value = this.settings.currency || 'USD';
+        }
+
+        if (this.budgetAlertThresholdInput) {
+            this.budgetAlertThresholdInput.value = this.settings.budgetAlertThreshold ?? 80;
+        }
+
+        if (this.trashRetentionInput) {
+            this.trashRetentionInput.value = this.settings.trashRetentionDays ?? 30;
+        }
+
+        if (this.deadlineReminderInput) {
+            this.deadlineReminderInput.value = this.settings.deadlineReminderDays ?? 1;
+        }
+
         // Update the URL button visibility and dispatch event
         this.updateUrlButtonVisibility();
 
@@ -197,6 +294,202 @@ class Settings {
         }
     }
 
+    async updateAllowParallelTimers(enabled) {
+        try {
+            // The backend refuses to disable this while several time blocks are running
+            const settings = await API.updateSettings({ allowParallelTimers: enabled });
+            this.settings.allowParallelTimers = !!settings.allowParallelTimers;
+
+            Utils.showNotification('Success', `Parallel timers ${enabled ? 'enabled' : 'disabled'}`, 'success');
+        } catch (error) {
+            console.error('Error updating parallel timers setting:', error);
+            if (this.parallelTimersToggle) {
+                this.parallelTimersToggle.checked = !!this.settings.allowParallelTimers;
+            }
+            Utils.showNotification('Error', String(error || 'Failed to update parallel timers setting'), 'error');
+        }
+    }
+
+    async updateIdleThreshold(minutes) {
+        try {
+            const settings = await API.updateSettings({ idleThresholdMinutes: isNaN(minutes) ? 0 : minutes });
+            this.settings.idleThresholdMinutes = settings.idleThresholdMinutes;
+
+            Utils.showNotification('Success', settings.idleThresholdMinutes > 0
+                ? `Idle detection after ${settings.idleThresholdMinutes} minutes`
+                : 'Idle detection disabled', 'success');
+        } catch (error) {
+            console.error('Error updating idle threshold:', error);
+            if (this.idleThresholdInput) {
+                this.idleThresholdInput.value = this.settings.idleThresholdMinutes ?? 15;
+            }
+            Utils.showNotification('Error', String(error || 'Failed to update idle threshold'), 'error');
+        }
+    }
+
+    async updatePomodoroSetting(key, value, input) {
+        try {
+            const settings = await API.updateSettings({ [key]: isNaN(value) ? 0 : value });
+            this.settings[key] = settings[key];
+            Utils.showNotification('Success', 'Pomodoro intervals updated', 'success');
+        } catch (error) {
+            console.error('Error updating pomodoro setting:', error);
+            if (input) {
+                input.value = this.settings[key] ?? '';
+            }
+            Utils.showNotification('Error', String(error || 'Failed to update Pomodoro intervals'), 'error');
+        }
+    }
+
+    async updateCurrency(currency) {
+        try {
+            const settings = await API.updateSettings({ currency });
+            this.settings.currency = settings.currency;
+            if (this.currencyInput) {
+                this.currencyInput.value = settings.currency;
+            }
+            Utils.showNotification('Success', `Currency set to ${settings.currency}`, 'success');
+        } catch (error) {
+            console.error('Error updating currency:', error);
+            if (this.currencyInput) {
+                this.currencyInput.value = this.settings.currency || 'USD';
+            }
+            Utils.showNotification('Error', String(error || 'Failed to update currency'), 'error');
+        }
+    }
+
+    async updateBudgetAlertThreshold(percent) {
+        try {
+            const settings = await API.updateSettings({ budgetAlertThreshold: isNaN(percent) ? 0 : percent });
+            this.settings.budgetAlertThreshold = settings.budgetAlertThreshold;
+            Utils.showNotification('Success', settings.budgetAlertThreshold > 0
+                ? `Budget alerts at ${settings.budgetAlertThreshold}%`
+                : 'Budget alerts disabled', 'success');
+        } catch (error) {
+            console.error('Error updating budget alert threshold:', error);
+            if (this.budgetAlertThresholdInput) {
+                this.budgetAlertThresholdInput.value = this.settings.budgetAlertThreshold ?? 80;
+            }
+            Utils.showNotification('Error', String(error || 'Failed to update budget alert threshold'), 'error');
+        }
+    }
+
+    async updateTrashRetention(days) {
+        try {
+            const settings = await API.updateSettings({ trashRetentionDays: isNaN(days) ? 0 : days });
+            this.settings.trashRetentionDays = settings.trashRetentionDays;
+            Utils.showNotification('Success', settings.trashRetentionDays > 0
+                ? `Deleted items are kept for ${settings.trashRetentionDays} days`
+                : 'Deleted items are kept until the trash is emptied', 'success');
+            window.dispatchEvent(new CustomEvent('trashRetentionChanged'));
+        } catch (error) {
+            console.error('Error updating trash retention:', error);
+            if (this.trashRetentionInput) {
+                this.trashRetentionInput.value = this.settings.trashRetentionDays ?? 30;
+            }
+            Utils.showNotification('Error', String(error || 'Failed to update trash retention'), 'error');
+        }
+    }
+
+    async updateDeadlineReminderDays(days) {
+        try {
+            const settings = await API.updateSettings({ deadlineReminderDays: isNaN(days) ? 0 : days });
+            this.settings.deadlineReminderDays = settings.deadlineReminderDays;
+            Utils.showNotification('Success', settings.deadlineReminderDays > 0
+                ? `Deadlines are announced ${settings.deadlineReminderDays} day${settings.deadlineReminderDays === 1 ? '' : 's'} ahead`
+                : 'Deadline reminders disabled', 'success');
+        } catch (error) {
+            console.error('Error updating deadline reminders:', error);
+            if (this.deadlineReminderInput) {
+                this.deadlineReminderInput.value = this.settings.deadlineReminderDays ?? 1;
+            }
+            Utils.showNotification('Error', String(error || 'Failed to update deadline reminders'), 'error');
+        }
+    }
+
+    async loadTags() {
+        if (!this.tagsManager) return;
+        try {
+            this.tags = await API.getAllTags() || [];
+        } catch (error) {
+            console.error('Error loading tags:', error);
+            this.tags = [];
+        }
+        this.renderTags();
+    }
+
+    renderTags() {
+        if (this.tags.length === 0) {
+            this.tagsManager.innerHTML = '<span class="no-tags">No tags yet</span>';
+            return;
+        }
+
+        this.tagsManager.innerHTML = this.tags.map(tag => `
+            <div class="tag-row" data-id="${tag.id}">
+                <input type="color" class="tag-color-input" value="${tag.color}" title="Color">
+                <input type="text" class="tag-name-input" value="${Utils.escapeHtml(tag.name)}" autocomplete="off">
+                <select class="tag-merge-select" title="Merge into another tag">
+                    <option value="">Merge into...</option>
+                    ${this.tags.filter(other => other.id !== tag.id).map(other => `
+                        <option value="${other.id}">${Utils.escapeHtml(other.name)}</option>
+                    `).join('')}
+                </select>
+                <button type="button" class="tag-action-btn delete" title="Delete tag">
+                    <i class="fas fa-trash"></i>
+                </button>
+            </div>
+        `).join('');
+    }
+
+    async updateTag(id, tagData) {
+        try {
+            await API.updateTag(id, tagData);
+            Utils.showNotification('Success', 'Tag updated', 'success');
+        } catch (error) {
+            console.error('Error updating tag:', error);
+            Utils.showNotification('Error', String(error || 'Failed to update tag'), 'error');
+        }
+        await this.loadTags();
+    }
+
+    async mergeTag(sourceId, targetId) {
+        const source = this.tags.find(tag => tag.id === sourceId);
+        const target = this.tags.find(tag => tag.id === targetId);
+        const confirmed = await Dialog.confirm(
+            'Merge Tags',
+            `Move every use of "${source?.name}" to "${target?.name}" and delete "${source?.name}"?`,
+            { confirmText: 'Merge', cancelText: 'Cancel', confirmType: 'primary', icon: 'fa-object-group' }
+        );
+        if (confirmed) {
+            try {
+                await API.mergeTags({ source_ids: [sourceId], target_id: targetId });
+                Utils.showNotification('Success', 'Tags merged', 'success');
+            } catch (error) {
+                console.error('Error merging tags:', error);
+                Utils.showNotification('Error', String(error || 'Failed to merge tags'), 'error');
+            }
+        }
+        await this.loadTags();
+    }
+
+    async deleteTag(id) {
+        const confirmed = await Dialog.confirm(
+            'Delete Tag',
+            'Delete this tag? It will be removed from every project and time block.',
+            { confirmText: 'Delete', cancelText: 'Cancel', confirmType: 'danger' }
+        );
+        if (!confirmed) return;
+
+        try {
+            await API.deleteTag(id);
+            Utils.showNotification('Success', 'Tag deleted', 'success');
+        } catch (error) {
+            console.error('Error deleting tag:', error);
+            Utils.showNotification('Error', String(error || 'Failed to delete tag'), 'error');
+        }
+        await this.loadTags();
+    }
+
     async updateCustomUrl(url) {
         try {
             // Update local settings
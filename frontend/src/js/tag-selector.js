@@ -0,0 +1,192 @@
+/**
+ * Tag Selector
+ * Chip input used by the project and time block forms to pick, add and create tags
+ */
+
+import API from './api.js';
+import Utils from './utils.js';
+
+class TagSelector {
+    constructor(containerId, options = {}) {
+        this.container = document.getElementById(containerId);
+        this.options = {
+            placeholder: 'Add a tag...',
+            ...options
+        };
+        this.tags = [];
+        this.selected = [];
+
+        if (!this.container) {
+            console.error('TagSelector: Container not found');
+            return;
+        }
+
+        this.render();
+        this.bindEvents();
+    }
+
+    render() {
+        const inputId = `${this.container.id}-input`;
+        this.container.innerHTML = `
+            <div class="selected-tags"></div>
+            <div class="tag-input-container">
+                <input type="text" id="${inputId}" placeholder="${Utils.escapeHtml(this.options.placeholder)}" autocomplete="off">
+                <div class="tag-dropdown"></div>
+            </div>
+        `;
+        this.selectedEl = this.container.querySelector('.selected-tags');
+        this.input = this.container.querySelector('input');
+        this.dropdown = this.container.querySelector('.tag-dropdown');
+        this.renderSelected();
+    }
+
+    bindEvents() {
+        this.input.addEventListener('focus', () => this.showDropdown());
+        this.input.addEventListener('input', () => this.showDropdown());
+        this.input.addEventListener('blur', () => {
+            // Delay so a click on a dropdown item lands before it disappears
+            setTimeout(() => this.hideDropdown(), 150);
+        });
+        this.input.addEventListener('keydown', (e) => {
+            if (e.key === 'Enter') {
+                // Keep Enter from submitting the surrounding form
+                e.preventDefault();
+                this.addFromInput();
+            } else if (e.key === 'Escape') {
+                e.stopPropagation();
+                this.hideDropdown();
+            } else if (e.key === 'Backspace' && this.input.value === '' && this.selected.length > 0) {
+                this.selected.pop();
+                this.renderSelected();
+            }
+        });
+
+        this.dropdown.addEventListener('mousedown', (e) => {
+            const item = e.target.closest('.tag-dropdown-item');
+            if (!item) return;
+            e.preventDefault();
+            if (item.classList.contains('create-new')) {
+                this.addFromInput();
+            } else {
+                this.select(parseInt(item.dataset.id, 10));
+            }
+        });
+
+        this.selectedEl.addEventListener('click', (e) => {
+            const btn = e.target.closest('.remove-tag');
+            if (!btn) return;
+            const id = parseInt(btn.dataset.id, 10);
+            this.selected = this.selected.filter(tag => tag.id !== id);
+            this.renderSelected();
+        });
+    }
+
+    async refresh() {
+        try {
+            this.tags = await API.getAllTags() || [];
+        } catch (error) {
+            this.tags = [];
+        }
+    }
+
+    setSelected(tags) {
+        this.selected = (tags || []).map(tag => ({ ...tag }));
+        this.input.value = '';
+        this.renderSelected();
+    }
+
+    getSelected() {
+        return this.selected.map(tag => tag.id);
+    }
+
+    select(id) {
+        const tag = this.tags.find(t => t.id === id);
+        if (tag && !this.selected.some(t => t.id === id)) {
+            this.selected.push(tag);
+        }
+        this.input.value = '';
+        this.renderSelected();
+        this.showDropdown();
+    }
+
+    async addFromInput() {
+        const name = this.input.value.trim();
+        if (!name) return;
+
+        const existing = this.tags.find(t => t.name.toLowerCase() === name.toLowerCase());
+        if (existing) {
+            this.select(existing.id);
+            return;
+        }
+
+        try {
+            const tag = await API.createTag({ name });
+            this.tags.push(tag);
+            this.select(tag.id);
+        } catch (error) {
+            Utils.showNotification('Error', `Failed to create tag: ${error}`, 'error');
+        }
+    }
+
+    renderSelected() {
+        if (this.selected.length === 0) {
+            this.selectedEl.innerHTML = '<span class="no-tags">No tags</span>';
+            return;
+        }
+        this.selectedEl.innerHTML = this.selected.map(tag => `
+            <span class="selected-tag" style="background-color: ${tag.color}">
+                ${Utils.escapeHtml(tag.name)}
+                <button type="button" class="remove-tag" data-id="${tag.id}" title="Remove tag">
+                    <i class="fas fa-times"></i>
+                </button>
+            </span>
+        `).join('');
+    }
+
+    showDropdown() {
+        const query = this.input.value.trim().toLowerCase();
+        const available = this.tags.filter(tag =>
+            !this.selected.some(t => t.id === tag.id) &&
+            tag.name.toLowerCase().includes(query)
+        );
+        const exact = this.tags.some(tag => tag.name.toLowerCase() === query);
+
+        let html = available.map(tag => `
+            <div class="tag-dropdown-item" data-id="${tag.id}">
+                <span class="tag-color" style="background-color: ${tag.color}"></span>
+                ${Utils.escapeHtml(tag.name)}
+            </div>
+        `).join('');
+        if (query && !exact) {
+            html += `
+                <div class="tag-dropdown-item create-new">
+                    <i class="fas fa-plus"></i>
+                    Create "${Utils.escapeHtml(this.input.value.trim())}"
+                </div>
+            `;
+        }
+
+        this.dropdown.innerHTML = html;
+        this.dropdown.style.display = html ? 'block' : 'none';
+    }
+
+    hideDropdown() {
+        this.dropdown.style.display = 'none';
+    }
+
+    // Render read-only tag chips for cards
+    static renderChips(tags) {
+        if (!tags || tags.length === 0) return '';
+        return `
+            <div class="project-tags">
+                ${tags.map(tag => `
+                    <span class="project-tag" style="background-color: ${tag.color}">
+                        <i class="fas fa-tag"></i>${Utils.escapeHtml(tag.name)}
+                    </span>
+                `).join('')}
+            </div>
+        `;
+    }
+}
+
+export default TagSelector;
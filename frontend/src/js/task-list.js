@@ -0,0 +1,250 @@
+/**
+ * Task List
+ * Task tree editor shown in the project form; changes are saved as they are made
+ */
+
+import API from './api.js';
+import Utils from './utils.js';
+import Dialog from './dialog.js';
+import DragReorder from './drag-reorder.js';
+
+class TaskList {
+    constructor(containerId) {
+        this.container = document.getElementById(containerId);
+        this.projectId = null;
+        this.tree = null;
+        this.reorder = null;
+
+        if (!this.container) {
+            console.error('TaskList: Container not found');
+            return;
+        }
+
+        this.render();
+        this.bindEvents();
+        this.renderTasks();
+    }
+
+    render() {
+        this.container.innerHTML = `
+            <div class="task-rows"></div>
+            <div class="task-add">
+                <select class="task-parent" title="Parent task">
+                    <option value="">Top level</option>
+                </select>
+                <input type="text" class="task-name-input" placeholder="Add a task..." autocomplete="off">
+            </div>
+        `;
+        this.rowsEl = this.container.querySelector('.task-rows');
+        this.parentField = this.container.querySelector('.task-parent');
+        this.input = this.container.querySelector('.task-name-input');
+    }
+
+    bindEvents() {
+        this.input.addEventListener('keydown', (e) => {
+            if (e.key === 'Enter') {
+                // Keep Enter from submitting the surrounding form
+                e.preventDefault();
+                this.addFromInput();
+            }
+        });
+
+        this.rowsEl.addEventListener('change', (e) => {
+            const row = e.target.closest('.task-row');
+            if (!row) return;
+            const id = parseInt(row.dataset.id, 10);
+            if (e.target.classList.contains('task-done')) {
+                this.saveTask(id, { done: e.target.checked });
+            } else if (e.target.classList.contains('task-name-field')) {
+                this.renameTask(id, e.target.value);
+            } else if (e.target.classList.contains('task-estimate-field')) {
+                const hours = parseFloat(e.target.value) || 0;
+                if (hours < 0) {
+                    Utils.showNotification('Error', 'Estimated hours cannot be negative', 'error');
+                    this.renderTasks();
+                    return;
+                }
+                // 0 removes the budget
+                this.saveTask(id, { estimated_hours: hours });
+            }
+        });
+
+        this.rowsEl.addEventListener('keydown', (e) => {
+            if (e.key === 'Enter' && e.target.matches('.task-name-field, .task-estimate-field')) {
+                e.preventDefault();
+                e.target.blur();
+            }
+        });
+
+        this.rowsEl.addEventListener('click', (e) => {
+            const btn = e.target.closest('.tag-action-btn');
+            if (!btn) return;
+            if (btn.dataset.action === 'delete') {
+                this.deleteTask(parseInt(btn.closest('.task-row').dataset.id, 10));
+            }
+        });
+    }
+
+    async load(projectId) {
+        this.projectId = projectId;
+        if (!projectId) {
+            this.tree = null;
+            this.renderTasks();
+            return;
+        }
+
+        try {
+            this.tree = await API.getTaskTree(projectId);
+        } catch (error) {
+            console.error('Error loading tasks:', error);
+            this.tree = null;
+        }
+        this.renderTasks();
+    }
+
+    clear() {
+        this.load(null);
+    }
+
+    renderTasks() {
+        const rows = TaskList.flatten(this.tree ? this.tree.tasks : []);
+
+        this.parentField.innerHTML = '<option value="">Top level</option>' + rows.map(({ task, depth }) =>
+            `<option value="${task.id}">${'— '.repeat(depth)}${Utils.escapeHtml(task.name)}</option>`
+        ).join('');
+
+        if (rows.length === 0) {
+            this.rowsEl.innerHTML = `<span class="no-tags">${this.projectId ? 'No tasks yet' : 'Save the project to add tasks'}</span>`;
+        } else {
+            this.rowsEl.innerHTML = rows.map(({ task, depth }) => `
+                <div class="task-row ${task.done ? 'done' : ''}" data-id="${task.id}" style="padding-left: ${depth * 1.25}rem">
+                    <span class="task-drag-handle js-task-drag-handle" title="Drag to reorder"><i class="fas fa-grip-vertical"></i></span>
+                    <input type="checkbox" class="task-done" ${task.done ? 'checked' : ''} title="Done">
+                    <input type="text" class="task-name-field" autocomplete="off">
+                    <span class="task-duration ${task.estimated_hours && task.total_duration >= task.estimated_hours * 3600 ? 'over-budget' : ''}" title="Including subtasks">${Utils.formatDurationShort(task.total_duration)}</span>
+                    <input type="number" class="task-estimate-field" min="0" step="0.25" placeholder="Budget h" value="${task.estimated_hours || ''}" title="Hour budget">
+                    <button type="button" class="tag-action-btn delete" data-action="delete" title="Delete task">
+                        <i class="fas fa-trash"></i>
+                    </button>
+                </div>
+            `).join('');
+            // Names are set as values so quotes in them cannot break the markup
+            this.rowsEl.querySelectorAll('.task-name-field').forEach((field, index) => {
+                field.value = rows[index].task.name;
+            });
+        }
+
+        this.input.disabled = !this.projectId;
+        this.parentField.disabled = !this.projectId;
+        this.initReorder();
+    }
+
+    initReorder() {
+        if (this.reorder) {
+            this.reorder.destroy();
+            this.reorder = null;
+        }
+        if (!this.rowsEl.querySelector('.task-row')) return;
+
+        this.reorder = new DragReorder({
+            container: this.rowsEl,
+            itemSelector: '.task-row',
+            handleSelector: '.js-task-drag-handle',
+            onReorder: (newOrder) => this.handleReorder(newOrder)
+        });
+    }
+
+    async handleReorder(newOrder) {
+        // Tasks are sorted among their siblings, so list positions keep the same relative order
+        const taskOrders = {};
+        newOrder.forEach((item, index) => {
+            taskOrders[parseInt(item.element.dataset.id, 10)] = index;
+        });
+
+        try {
+            await API.updateTasksOrder(taskOrders);
+        } catch (error) {
+            console.error('Error updating task order:', error);
+            Utils.showNotification('Error', 'Failed to update task order', 'error');
+        }
+        await this.load(this.projectId);
+    }
+
+    async addFromInput() {
+        const name = this.input.value.trim();
+        if (!name || !this.projectId) return;
+
+        const parentId = parseInt(this.parentField.value, 10) || null;
+        const siblings = parentId
+            ? (TaskList.find(this.tree.tasks, parentId)?.children || [])
+            : this.tree.tasks;
+
+        try {
+            await API.createTask({
+                project_id: this.projectId,
+                parent_id: parentId,
+                name,
+                order: siblings.length
+            });
+            this.input.value = '';
+            await this.load(this.projectId);
+            this.parentField.value = parentId || '';
+            window.dispatchEvent(new CustomEvent('tasksUpdated'));
+        } catch (error) {
+            Utils.showNotification('Error', `Failed to create task: ${error}`, 'error');
+        }
+    }
+
+    async renameTask(id, value) {
+        const task = TaskList.find(this.tree.tasks, id);
+        const name = value.trim();
+        if (!task || name === task.name) return;
+        if (!name) {
+            Utils.showNotification('Error', 'Task name is required', 'error');
+            this.renderTasks();
+            return;
+        }
+        await this.saveTask(id, { name });
+    }
+
+    async saveTask(id, updates) {
+        try {
+            await API.updateTask(id, updates);
+            window.dispatchEvent(new CustomEvent('tasksUpdated'));
+        } catch (error) {
+            Utils.showNotification('Error', `Failed to update task: ${error}`, 'error');
+        }
+        await this.load(this.projectId);
+    }
+
+    async deleteTask(id) {
+        const confirmed = await Dialog.confirm(
+            'Delete Task',
+            'Delete this task and its subtasks? Their time blocks are kept on the project without a task.',
+            { confirmText: 'Delete', cancelText: 'Cancel', confirmType: 'danger' }
+        );
+        if (!confirmed) return;
+
+        try {
+            await API.deleteTask(id);
+            window.dispatchEvent(new CustomEvent('tasksUpdated'));
+        } catch (error) {
+            Utils.showNotification('Error', `Failed to delete task: ${error}`, 'error');
+        }
+        await this.load(this.projectId);
+    }
+
+    // Flatten a task tree into rows with their nesting depth
+    static flatten(tasks, depth = 0) {
+        return (tasks || []).flatMap(task => [
+            { task, depth },
+            ...TaskList.flatten(task.children, depth + 1)
+        ]);
+    }
+
+    static find(tasks, id) {
+        return TaskList.flatten(tasks).map(row => row.task).find(task => task.id === id) || null;
+    }
+}
+
+export default TaskList;
@@ -5,6 +5,8 @@ import Settings from './settings.js';
 import Dialog from './dialog.js';
 import Tooltip from './tooltip.js';
 import StandardModal from './standard-modal.js';
+import TagSelector from './tag-selector.js';
+import FieldInputs from './field-inputs.js';
 
 class TimeBlocks {
     constructor(projectsInstance) {
@@ -32,6 +34,25 @@ class TimeBlocks {
         });
         
         this.timeBlockForm = document.getElementById('timeblock-form');
+
+        this.splitModal = new StandardModal('split-timeblock-modal', {
+            title: 'Split Time Block',
+            icon: 'fas fa-cut',
+            iconType: 'timeblock-edit'
+        });
+        this.splitForm = document.getElementById('split-timeblock-form');
+
+        this.historyModal = new StandardModal('timeblock-history-modal', {
+            title: 'Time Block History',
+            icon: 'fas fa-clock-rotate-left',
+            iconType: 'timeblock'
+        });
+        this.historyModal.setActions([
+            { text: 'Close', onclick: () => this.historyModal.hide() }
+        ]);
+        this.splitAtField = document.getElementById('split-timeblock-at');
+        this.splitProjectField = document.getElementById('split-timeblock-project');
+        this.splittingId = null;
         this.currentDateDisplay = document.getElementById('current-date');
         if (this.currentDateDisplay) {
             // Use the app's custom tooltip system instead of native title
@@ -50,9 +71,16 @@ class TimeBlocks {
         
         // Form fields
         this.projectField = document.getElementById('timeblock-project');
+        this.taskField = document.getElementById('timeblock-task');
         this.startTimeField = document.getElementById('timeblock-start');
         this.endTimeField = document.getElementById('timeblock-end');
         this.descriptionField = document.getElementById('timeblock-description');
+        this.durationField = document.getElementById('timeblock-duration');
+        this.hourlyRateField = document.getElementById('timeblock-hourly-rate');
+        this.billableField = document.getElementById('timeblock-billable');
+        this.tagSelector = new TagSelector('timeblock-tags');
+        // An empty value falls back to the project's value
+        this.fieldInputs = new FieldInputs('timeblock-fields', { emptyChoice: 'Same as project' });
     }
 
     bindEvents() {
@@ -76,8 +104,12 @@ class TimeBlocks {
         this.closeTimeBlockBtn = this.timeBlockModal.modal.querySelector('.standard-modal-close');
         
         this.cancelTimeBlockBtn?.addEventListener('click', () => this.closeModal());
+        this.projectField?.addEventListener('change', () => this.loadTasksIntoSelector(this.projectField.value));
         this.closeTimeBlockBtn?.addEventListener('click', () => this.closeModal());
 
+        this.splitModal.setFormHandler('split-timeblock-form', (e) => this.handleSplitSubmit(e));
+        document.getElementById('cancel-split-timeblock')?.addEventListener('click', () => this.splitModal.hide());
+
         // Event delegation for action buttons
         this.timeBlocksList?.addEventListener('click', (e) => {
             const actionBtn = e.target.closest('.time-block-action-btn');
@@ -96,6 +128,8 @@ class TimeBlocks {
         window.addEventListener('timeBlockUpdated', () => {
             this.loadTimeBlocks();
         });
+
+        window.addEventListener('customFieldsUpdated', () => this.fieldInputs.refresh());
         
         // Listen for timer state changes (pause/resume)
         window.addEventListener('timerStateChanged', () => {
@@ -138,6 +172,30 @@ class TimeBlocks {
         this.timer = timer;
     }
 
+    // Fill the task selector with the tasks of a project, subtasks indented under their parent
+    async loadTasksIntoSelector(projectId, selectedTaskId = null) {
+        if (!this.taskField) return;
+
+        this.taskField.innerHTML = '<option value="">No task</option>';
+        if (!projectId) return;
+
+        try {
+            const tree = await API.getTaskTree(parseInt(projectId, 10));
+            const addOptions = (tasks, depth) => (tasks || []).forEach(task => {
+                const option = document.createElement('option');
+                option.value = task.id;
+                option.textContent = `${'— '.repeat(depth)}${task.name}${task.done ? ' (done)' : ''}`;
+                this.taskField.appendChild(option);
+                addOptions(task.children, depth + 1);
+            });
+            addOptions(tree.tasks, 0);
+        } catch (error) {
+            console.error('Error loading tasks:', error);
+        }
+
+        this.taskField.value = selectedTaskId || '';
+    }
+
     async loadProjectsIntoSelector() {
         try {
             const projects = await API.getAllProjects();
@@ -161,6 +219,16 @@ class TimeBlocks {
                     this.projectField.value = currentValue;
                 }
             }
+
+            if (this.splitProjectField) {
+                this.splitProjectField.innerHTML = '<option value="">Same project</option>';
+                activeProjects.forEach(project => {
+                    const option = document.createElement('option');
+                    option.value = project.id;
+                    option.textContent = project.name;
+                    this.splitProjectField.appendChild(option);
+                });
+            }
         } catch (error) {
             console.error('Error loading projects into selector:', error);
         }
@@ -212,11 +280,23 @@ class TimeBlocks {
         }
 
         this.timeBlocksList.innerHTML = this.timeBlocks
-            .map(timeBlock => this.createTimeBlockCard(timeBlock))
+            .map(timeBlock => this.createTimeBlockCard(timeBlock, this.findMergeCandidate(timeBlock)))
             .join('');
     }
 
-    createTimeBlockCard(timeBlock) {
+    // Find the stopped block of the same project right before this one, if any
+    findMergeCandidate(timeBlock) {
+        if (!timeBlock.end_time) return null;
+
+        const start = new Date(timeBlock.start_time);
+        const previous = this.timeBlocks
+            .filter(tb => tb.end_time && new Date(tb.start_time) < start)
+            .sort((a, b) => new Date(b.start_time) - new Date(a.start_time))[0];
+
+        return previous && previous.project_id === timeBlock.project_id ? previous : null;
+    }
+
+    createTimeBlockCard(timeBlock, mergeCandidate = null) {
         const isRunning = !timeBlock.end_time;
         
         // Check if this time block is paused by checking timer state
@@ -225,8 +305,8 @@ class TimeBlocks {
         
         let duration;
         if (isRunning) {
-            if (isTimerPaused && this.timer && this.timer.currentTimeBlockId === timeBlock.id) {
-                // Use timer's elapsed seconds when paused
+            if (this.timer && this.timer.currentTimeBlockId === timeBlock.id) {
+                // Use timer's elapsed seconds, which excludes paused segments
                 duration = this.timer.elapsedSeconds;
             } else if (isCurrentlyRunning) {
                 // Calculate current duration for running timer
@@ -244,6 +324,14 @@ class TimeBlocks {
             (isTimerPaused ? ' - Paused' : ' - Running')
         }`;
 
+        // Show each worked interval when the block was paused at least once
+        const segments = timeBlock.segments || [];
+        const workedRanges = segments.length > 1
+            ? segments.map(segment => `${Utils.formatTime(segment.start_time)} - ${
+                segment.end_time ? Utils.formatTime(segment.end_time) : '...'
+            }`).join(', ')
+            : '';
+
         return `
             <div class="time-block ${isCurrentlyRunning ? 'running' : ''} ${isTimerPaused ? 'paused' : ''}" data-id="${timeBlock.id}">
                 <div class="time-block-info">
@@ -251,7 +339,7 @@ class TimeBlocks {
                         <div class="time-block-content">
                             <div class="time-block-project">
                                 <i class="fas fa-folder"></i>
-                                <span>${Utils.escapeHtml(timeBlock.project_name)}</span>
+                                <span>${Utils.escapeHtml(timeBlock.project_name)}${timeBlock.task_name ? ` · ${Utils.escapeHtml(timeBlock.task_name)}` : ''}</span>
                             </div>
                             <div class="time-block-duration">
                                 <i class="fas fa-clock"></i>
@@ -265,6 +353,24 @@ class TimeBlocks {
                             <i class="fas fa-calendar-alt"></i>
                             <span>${timeRange}</span>
                         </div>
+                        ${workedRanges ? `
+                            <div class="time-block-meta-item">
+                                <i class="fas fa-stream"></i>
+                                <span>Worked ${workedRanges}${timeBlock.paused_duration ? ` (paused ${Utils.formatDurationShort(timeBlock.paused_duration)})` : ''}</span>
+                            </div>
+                        ` : ''}
+                        ${timeBlock.duration_overridden ? `
+                            <div class="time-block-meta-item">
+                                <i class="fas fa-hourglass-half"></i>
+                                <span>Duration set by hand</span>
+                            </div>
+                        ` : ''}
+                        ${timeBlock.billable !== null && timeBlock.billable !== undefined || timeBlock.hourly_rate !== null && timeBlock.hourly_rate !== undefined ? `
+                            <div class="time-block-meta-item">
+                                <i class="fas fa-coins"></i>
+                                <span>${timeBlock.billable === false ? 'Not billable' : 'Billable'}${timeBlock.hourly_rate !== null && timeBlock.hourly_rate !== undefined ? ` at ${Utils.formatMoney(timeBlock.hourly_rate)}/h` : ''}</span>
+                            </div>
+                        ` : ''}
                         ${timeBlock.description ? `
                             <div class="time-block-meta-item">
                                 <i class="fas fa-sticky-note"></i>
@@ -272,9 +378,23 @@ class TimeBlocks {
                             </div>
                         ` : ''}
                     </div>
+                    ${TagSelector.renderChips(timeBlock.tags)}
                 </div>
                 
                 <div class="time-block-actions">
+                    ${mergeCandidate ? `
+                        <button class="time-block-action-btn merge" data-action="merge" data-id="${timeBlock.id}" title="Merge with the previous block">
+                            <i class="fas fa-object-group"></i>
+                        </button>
+                    ` : ''}
+                    ${!isRunning ? `
+                        <button class="time-block-action-btn split" data-action="split" data-id="${timeBlock.id}" title="Split">
+                            <i class="fas fa-cut"></i>
+                        </button>
+                    ` : ''}
+                    <button class="time-block-action-btn history" data-action="history" data-id="${timeBlock.id}" title="History">
+                        <i class="fas fa-clock-rotate-left"></i>
+                    </button>
                     <button class="time-block-action-btn edit" data-action="edit" data-id="${timeBlock.id}">
                         <i class="fas fa-edit"></i>
                     </button>
@@ -301,6 +421,15 @@ class TimeBlocks {
                 case 'delete':
                     await this.deleteTimeBlock(numericId);
                     break;
+                case 'split':
+                    this.openSplitModal(numericId);
+                    break;
+                case 'merge':
+                    await this.mergeWithPrevious(numericId);
+                    break;
+                case 'history':
+                    await this.showHistory(numericId);
+                    break;
                 default:
                     console.warn('Unknown action:', action);
             }
@@ -310,6 +439,89 @@ class TimeBlocks {
         }
     }
 
+    async showHistory(id) {
+        const history = await API.getTimeBlockHistory(id) || [];
+        const actions = {
+            create: 'Created',
+            update: 'Edited',
+            delete: 'Moved to trash',
+            restore: 'Restored',
+            purge: 'Deleted permanently'
+        };
+
+        const body = history.length === 0
+            ? '<span class="no-tags">No changes recorded. Blocks created before history tracking only show changes made since.</span>'
+            : `<div class="time-block-history">${history.slice().reverse().map(change => `
+                <div class="history-entry">
+                    <div class="history-entry-header">
+                        <span class="history-action ${change.action}">${actions[change.action] || change.action}</span>
+                        <span class="history-time">${Utils.formatDate(change.changed_at)} ${Utils.formatTime(change.changed_at)}</span>
+                    </div>
+                    ${change.action === 'update' ? `
+                        <ul class="history-changes">
+                            ${change.changed.filter(column => column !== 'heartbeat_at').map(column => `
+                                <li>
+                                    <span class="history-field">${Utils.escapeHtml(TimeBlocks.historyLabel(column))}</span>
+                                    <span class="history-before">${Utils.escapeHtml(this.formatHistoryValue(column, change.before[column]))}</span>
+                                    <i class="fas fa-arrow-right"></i>
+                                    <span class="history-after">${Utils.escapeHtml(this.formatHistoryValue(column, change.after[column]))}</span>
+                                </li>
+                            `).join('')}
+                        </ul>
+                    ` : ''}
+                </div>
+            `).join('')}</div>`;
+
+        this.historyModal.setBody(body);
+        this.historyModal.show();
+    }
+
+    static historyLabel(column) {
+        const labels = {
+            project_id: 'Project',
+            task_id: 'Task',
+            start_time: 'Start',
+            end_time: 'End',
+            duration: 'Duration',
+            duration_override: 'Duration set manually',
+            is_manual: 'Manual entry',
+            description: 'Description',
+            billable: 'Billable',
+            hourly_rate: 'Hourly rate',
+            deleted_at: 'Deleted'
+        };
+        return labels[column] || column;
+    }
+
+    // Render a value stored in the history snapshot, which keeps the raw database values
+    formatHistoryValue(column, value) {
+        if (value === null || value === undefined || value === '') return '—';
+
+        switch (column) {
+            case 'project_id': {
+                const project = this.projects?.projects?.find(p => p.id === value);
+                return project ? project.name : `#${value}`;
+            }
+            case 'duration':
+                return Utils.formatDurationShort(value);
+            case 'start_time':
+            case 'end_time':
+            case 'deleted_at': {
+                // SQLite keeps a space between date and time, which not every engine parses
+                const date = new Date(String(value).replace(' ', 'T'));
+                return isNaN(date) ? String(value) : `${Utils.formatDate(date)} ${Utils.formatTime(date)}`;
+            }
+            case 'duration_override':
+            case 'is_manual':
+            case 'billable':
+                return value ? 'Yes' : 'No';
+            case 'hourly_rate':
+                return `${Utils.formatMoney(value)}/h`;
+            default:
+                return String(value);
+        }
+    }
+
     async editTimeBlock(id) {
         try {
             let timeBlock = this.timeBlocks.find(tb => tb.id === id);
@@ -337,9 +549,23 @@ class TimeBlocks {
             
             // Populate form
             this.projectField.value = timeBlock.project_id;
+            await this.loadTasksIntoSelector(timeBlock.project_id, timeBlock.task_id);
             this.startTimeField.value = Utils.formatDateTimeForInput(timeBlock.start_time);
             this.endTimeField.value = timeBlock.end_time ? Utils.formatDateTimeForInput(timeBlock.end_time) : '';
             this.descriptionField.value = timeBlock.description || '';
+            if (this.durationField) {
+                // Only an explicit duration is editable; derived ones follow the start and end times
+                this.durationField.value = timeBlock.duration_overridden ? Math.round(timeBlock.duration / 60) : '';
+                this.durationField.dataset.overridden = timeBlock.duration_overridden ? 'true' : '';
+            }
+            if (this.hourlyRateField) {
+                this.hourlyRateField.value = timeBlock.hourly_rate ?? '';
+            }
+            if (this.billableField) {
+                this.billableField.value = timeBlock.billable === null || timeBlock.billable === undefined ? '' : String(timeBlock.billable);
+            }
+            this.tagSelector.setSelected(timeBlock.tags);
+            this.fieldInputs.setValues(timeBlock.custom_fields);
             
             this.openModal('edit');
         } catch (error) {
@@ -348,6 +574,66 @@ class TimeBlocks {
         }
     }
 
+    openSplitModal(id) {
+        const timeBlock = this.timeBlocks.find(tb => tb.id === id);
+        if (!timeBlock || !timeBlock.end_time) return;
+
+        this.splittingId = id;
+        const start = new Date(timeBlock.start_time).getTime();
+        const end = new Date(timeBlock.end_time).getTime();
+        this.splitAtField.value = Utils.formatDateTimeForInput(new Date(start + (end - start) / 2));
+        this.splitProjectField.value = '';
+        this.splitModal.show();
+        this.splitAtField.focus();
+    }
+
+    async handleSplitSubmit(e) {
+        e.preventDefault();
+
+        try {
+            const at = new Date(this.splitAtField.value);
+            if (isNaN(at.getTime())) {
+                Utils.showNotification('Error', 'Please enter a valid split time', 'error');
+                return;
+            }
+
+            const newProjectId = parseInt(this.splitProjectField.value) || 0;
+            await API.splitTimeBlock(this.splittingId, at, newProjectId);
+
+            this.splitModal.hide();
+            this.splittingId = null;
+            await this.loadTimeBlocks();
+            Utils.showNotification('Success', 'Time block split successfully!', 'success');
+            window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
+        } catch (error) {
+            console.error('Error splitting time block:', error);
+            Utils.showNotification('Error', `Failed to split time block: ${error}`, 'error');
+        }
+    }
+
+    async mergeWithPrevious(id) {
+        const timeBlock = this.timeBlocks.find(tb => tb.id === id);
+        const previous = timeBlock ? this.findMergeCandidate(timeBlock) : null;
+        if (!previous) return;
+
+        const confirmed = await Dialog.confirm(
+            'Merge Time Blocks',
+            `Merge this block with the previous ${timeBlock.project_name} block (${Utils.formatTime(previous.start_time)} - ${Utils.formatTime(previous.end_time)})? The time between them is kept as a pause.`,
+            { confirmText: 'Merge', cancelText: 'Cancel', confirmType: 'primary', icon: 'fa-object-group' }
+        );
+        if (!confirmed) return;
+
+        try {
+            await API.mergeTimeBlocks([previous.id, id]);
+            await this.loadTimeBlocks();
+            Utils.showNotification('Success', 'Time blocks merged successfully!', 'success');
+            window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
+        } catch (error) {
+            console.error('Error merging time blocks:', error);
+            Utils.showNotification('Error', `Failed to merge time blocks: ${error}`, 'error');
+        }
+    }
+
     async deleteTimeBlock(id) {
         try {
             // Ensure id is valid
@@ -359,7 +645,7 @@ class TimeBlocks {
             try {
                 const confirmed = await Dialog.confirm(
                     'Delete Time Block',
-                    'Are you sure you want to delete this time block? This action cannot be undone.',
+                    'Delete this time block? It is moved to the trash and can be restored from the settings page.',
                     {
                         confirmText: 'Delete',
                         cancelText: 'Cancel',
@@ -373,7 +659,7 @@ class TimeBlocks {
             } catch (dialogError) {
                 console.error('Error in Dialog.confirm:', dialogError);
                 // Fallback to native confirm
-                const confirmed = confirm('Are you sure you want to delete this time block? This action cannot be undone.');
+                const confirmed = confirm('Delete this time block? It is moved to the trash and can be restored from the settings page.');
                 if (!confirmed) {
                     return;
                 }
@@ -484,6 +770,8 @@ class TimeBlocks {
             this.timeBlockModal.setIcon('fas fa-plus-circle', 'timeblock');
         }
         
+        this.tagSelector.refresh();
+        this.fieldInputs.refresh();
         this.timeBlockModal.show();
         
         // Set default times
@@ -506,6 +794,12 @@ class TimeBlocks {
         setTimeout(() => {
             this.timeBlockModal.resetForm('timeblock-form');
             this.timeBlockModal.setIcon('fas fa-plus-circle', 'timeblock');
+            if (this.durationField) {
+                this.durationField.dataset.overridden = '';
+            }
+            this.tagSelector.setSelected([]);
+            this.fieldInputs.setValues({});
+            this.loadTasksIntoSelector(null);
         }, 200); // Wait for modal close animation to complete
     }
 
@@ -520,9 +814,14 @@ class TimeBlocks {
             const startTimeValue = formData.get('start_time');
             const endTimeValue = formData.get('end_time');
             const descriptionValue = formData.get('description');
+            const durationValue = formData.get('duration');
+            const hourlyRateValue = formData.get('hourly_rate');
+            const billableValue = formData.get('billable');
+            const taskId = parseInt(formData.get('task_id'), 10) || null;
             
             const timeBlockData = {
                 project_id: parseInt(projectIdValue),
+                task_id: taskId,
                 start_time: new Date(startTimeValue),
                 end_time: new Date(endTimeValue),
                 description: descriptionValue?.trim() || null
@@ -564,23 +863,72 @@ class TimeBlocks {
                 return;
             }
 
-            // Calculate duration
-            timeBlockData.duration = Utils.calculateDuration(timeBlockData.start_time, timeBlockData.end_time);
+            // An empty duration is derived from the interval by the backend
+            const durationOverride = durationValue !== null && durationValue !== ''
+                ? Math.round(parseFloat(durationValue) * 60)
+                : null;
+            if (durationOverride !== null && (isNaN(durationOverride) || durationOverride < 0)) {
+                Utils.showNotification('Error', 'Please enter a valid duration', 'error');
+                return;
+            }
+            if (durationOverride !== null && durationOverride > Utils.calculateDuration(timeBlockData.start_time, timeBlockData.end_time)) {
+                Utils.showNotification('Error', 'Duration cannot exceed the time between start and end', 'error');
+                return;
+            }
+
+            timeBlockData.duration = durationOverride || 0;
             timeBlockData.is_manual = true;
 
-            if (this.currentEditingId) {
-                // Update existing time block
-                await API.updateTimeBlock(this.currentEditingId, {
-                    start_time: timeBlockData.start_time,
-                    end_time: timeBlockData.end_time,
-                    duration: timeBlockData.duration,
-                    description: timeBlockData.description
-                });
-                Utils.showNotification('Success', 'Time block updated successfully!', 'success');
-            } else {
-                // Create new time block
-                const result = await API.createTimeBlock(timeBlockData);
-                Utils.showNotification('Success', 'Time block created successfully!', 'success');
+            // Empty billing fields follow the project
+            const hourlyRate = hourlyRateValue !== null && hourlyRateValue !== '' ? parseFloat(hourlyRateValue) : null;
+            if (hourlyRate !== null && (isNaN(hourlyRate) || hourlyRate < 0)) {
+                Utils.showNotification('Error', 'Please enter a valid hourly rate', 'error');
+                return;
+            }
+            const billable = billableValue ? billableValue === 'true' : null;
+            timeBlockData.hourly_rate = hourlyRate;
+            timeBlockData.billable = billable;
+
+            const updateData = {
+                project_id: timeBlockData.project_id,
+                // 0 detaches the block from its task
+                task_id: taskId || 0,
+                start_time: timeBlockData.start_time,
+                end_time: timeBlockData.end_time,
+                duration: durationOverride,
+                reset_duration: durationOverride === null && this.durationField?.dataset.overridden === 'true',
+                description: timeBlockData.description,
+                // Clear both overrides first so an emptied field falls back to the project
+                reset_billing: true,
+                hourly_rate: hourlyRate,
+                billable
+            };
+
+            let saved;
+            try {
+                if (this.currentEditingId) {
+                    // Update existing time block
+                    saved = await API.updateTimeBlock(this.currentEditingId, updateData);
+                    Utils.showNotification('Success', 'Time block updated successfully!', 'success');
+                } else {
+                    // Create new time block
+                    saved = await API.createTimeBlock(timeBlockData);
+                    Utils.showNotification('Success', 'Time block created successfully!', 'success');
+                }
+            } catch (saveError) {
+                const overlap = String(saveError).match(/overlaps time blocks ([\d, ]+)/);
+                if (!overlap) {
+                    throw saveError;
+                }
+                saved = await this.resolveOverlaps(overlap[1], timeBlockData, updateData);
+                if (!saved) {
+                    return;
+                }
+            }
+
+            if (saved) {
+                await API.setTimeBlockTags(saved.id, this.tagSelector.getSelected());
+                await API.setTimeBlockFieldValues(saved.id, this.fieldInputs.getValues());
             }
 
             this.closeModal();
@@ -594,6 +942,42 @@ class TimeBlocks {
         }
     }
 
+    // Ask how to handle the blocks a save would overlap and save again resolving them.
+    // Returns the saved time block, or null when the user cancels.
+    async resolveOverlaps(conflictIds, timeBlockData, updateData) {
+        const count = conflictIds.split(',').length;
+        const proceed = await Dialog.confirm(
+            'Overlapping Time Blocks',
+            `This time block overlaps ${count} other time block${count === 1 ? '' : 's'}. Save it and adjust the others?`,
+            { confirmText: 'Adjust', cancelText: 'Cancel', confirmType: 'primary', icon: 'fa-layer-group' }
+        );
+        if (!proceed) {
+            return null;
+        }
+
+        let strategy = 'merge';
+        const merge = await Dialog.confirm(
+            'Merge Time Blocks',
+            'Merge the overlapping blocks into this one, or keep them separate and remove the overlapped time from them?',
+            { confirmText: 'Merge', cancelText: 'Keep separate', confirmType: 'primary', icon: 'fa-object-group' }
+        );
+        if (!merge) {
+            const split = await Dialog.confirm(
+                'Keep Separate',
+                'Blocks that surround this one can be split in two, or trimmed so they end when this one starts.',
+                { confirmText: 'Split', cancelText: 'Trim', confirmType: 'primary', icon: 'fa-cut' }
+            );
+            strategy = split ? 'split' : 'trim';
+        }
+
+        const request = this.currentEditingId
+            ? { time_block_id: this.currentEditingId, update: updateData, strategy }
+            : { create: timeBlockData, strategy };
+        const saved = await API.resolveTimeBlockOverlaps(request);
+        Utils.showNotification('Success', 'Time block saved and overlaps resolved', 'success');
+        return saved;
+    }
+
     // Get time blocks for a specific date (used by calendar)
     async getTimeBlocksForDate(date) {
         try {
@@ -1,6 +1,8 @@
 // Timer Module - Handles timer functionality
 import API from './api.js';
 import Utils from './utils.js';
+import Dialog from './dialog.js';
+import { EventsOn } from '../../wailsjs/runtime/runtime.js';
 
 class Timer {
     constructor() {
@@ -8,27 +10,30 @@ class Timer {
         this.isPaused = false;
         this.currentProjectId = null;
         this.startTime = null;
-        this.pausedTime = 0;
-        this.totalPausedTime = 0; // Track total time spent paused
-        this.pauseStartTime = null; // When the current pause started
         this.currentTimeBlockId = null;
         this.interval = null;
         this.elapsedSeconds = 0;
+        this.elapsedBase = 0; // Elapsed seconds reported by the backend
+        this.elapsedSyncedAt = null; // When elapsedBase was received
 
         // Wait a bit to ensure DOM is ready
         setTimeout(() => {
             this.initializeElements();
             this.bindEvents();
             this.updateDisplay();
+            this.syncState()
+                .then(() => API.getPomodoroState())
+                .then((state) => this.applyPomodoroState(state, false))
+                .then(() => this.recoverOrphanedTimeBlocks())
+                .then(() => API.getIdlePeriod())
+                .then((period) => this.handleIdlePeriod(period))
+                .catch((error) => console.error('Error restoring timer:', error));
         }, 100);
     }
 
     initializeElements() {
         this.projectSelector = document.getElementById('project-selector');
-        this.openProjectDiscordBtn = document.getElementById('open-project-discord');
-        this.openProjectUrlBtn1 = document.getElementById('open-project-url1');
-        this.openProjectUrlBtn2 = document.getElementById('open-project-url2');
-        this.openProjectUrlBtn3 = document.getElementById('open-project-url3');
+        this.projectLinkButtons = document.getElementById('project-link-buttons');
         this.openProjectDirBtn = document.getElementById('open-project-dir');
         this.editProjectBtn = document.getElementById('edit-project-btn');
         this.timerDisplay = document.getElementById('timer-display');
@@ -36,6 +41,8 @@ class Timer {
         this.pauseBtn = document.getElementById('pause-timer');
         this.stopBtn = document.getElementById('stop-timer');
         this.resetBtn = document.getElementById('reset-timer');
+        this.pomodoroBtn = document.getElementById('pomodoro-toggle');
+        this.pomodoroStatus = document.getElementById('pomodoro-status');
         this.timerContainer = document.querySelector('.timer-section');
     }
 
@@ -44,36 +51,21 @@ class Timer {
         this.pauseBtn.addEventListener('click', () => this.pause());
         this.stopBtn.addEventListener('click', () => this.stop());
         this.resetBtn.addEventListener('click', () => this.reset());
+        if (this.pomodoroBtn) {
+            this.pomodoroBtn.addEventListener('click', () => this.togglePomodoro());
+        }
         // When project selection changes, update the project-URL button visibility
         if (this.projectSelector) {
             this.projectSelector.addEventListener('change', () => this.updateProjectUrlButton());
         }
 
-        // Open project URLs when the buttons are clicked
-        if (this.openProjectUrlBtn1) {
-            this.openProjectUrlBtn1.addEventListener('click', async (e) => {
-                e.preventDefault();
-                await this.openSelectedProjectUrl(1);
-            });
-        }
-        if (this.openProjectUrlBtn2) {
-            this.openProjectUrlBtn2.addEventListener('click', async (e) => {
-                e.preventDefault();
-                await this.openSelectedProjectUrl(2);
-            });
-        }
-        if (this.openProjectUrlBtn3) {
-            this.openProjectUrlBtn3.addEventListener('click', async (e) => {
-                e.preventDefault();
-                await this.openSelectedProjectUrl(3);
-            });
-        }
-
-        // Open project discord when the button is clicked
-        if (this.openProjectDiscordBtn) {
-            this.openProjectDiscordBtn.addEventListener('click', async (e) => {
+        // Open a project link when its button is clicked
+        if (this.projectLinkButtons) {
+            this.projectLinkButtons.addEventListener('click', async (e) => {
+                const btn = e.target.closest('.project-url-btn');
+                if (!btn) return;
                 e.preventDefault();
-                await this.openSelectedProjectDiscord();
+                await this.openSelectedProjectLink(parseInt(btn.dataset.index, 10));
             });
         }
 
@@ -93,33 +85,27 @@ class Timer {
             });
         }
 
-        // Listen for project list updates so option data-urls are available/updated
-        window.addEventListener('projectsUpdated', () => this.updateProjectUrlButton());
-    }
+        // Listen for project list updates so option data-links are available/updated
+        window.addEventListener('projectsUpdated', () => {
+            // Restore the selection of an active timer once the options exist
+            if (this.currentProjectId && this.projectSelector) {
+                this.projectSelector.value = String(this.currentProjectId);
+            }
+            this.updateProjectUrlButton();
+        });
 
-    async openSelectedProjectDiscord() {
-        try {
-            if (!this.projectSelector || !this.projectSelector.value) return;
+        // Keep every window in sync with the timer owned by the backend
+        EventsOn('timer:state', (state) => this.applyState(state));
 
-            const selectedOption = this.projectSelector.options[this.projectSelector.selectedIndex];
-            let discordRaw = selectedOption?.dataset?.discord || null;
-
-            // Fallback: ask API for project details
-            if (!discordRaw) {
-                try {
-                    const projectId = parseInt(this.projectSelector.value);
-                    const proj = await API.getProjectByID(projectId);
-                    discordRaw = proj?.discord || null;
-                } catch (err) {
-                    console.error('Error fetching project for discord:', err);
-                }
-            }
+        // The backend reports when the user walked away from a running timer
+        EventsOn('timer:idle', (period) => this.handleIdlePeriod(period));
 
-            if (!discordRaw) {
-                Utils.showNotification('Warning', 'No Discord channel set for selected project', 'warning');
-                return;
-            }
+        // Pomodoro phases are scheduled by the backend
+        EventsOn('pomodoro:state', (state) => this.applyPomodoroState(state, true));
+    }
 
+    async openDiscordLink(discordRaw) {
+        try {
             // parse and build protocol URL
             const discordRegex = /(?:discord:\/\/-\/channels\/|https?:\/\/[^\s\/]+\/channels\/)(\d+)\/(\d+)/i;
             const m = String(discordRaw).match(discordRegex);
@@ -158,67 +144,40 @@ class Timer {
             try { window.open(protocolURL, '_blank'); return; } catch (e) { Utils.showNotification('Error', 'Failed to open Discord link', 'error'); }
 
         } catch (err) {
-            console.error('openSelectedProjectDiscord error:', err);
+            console.error('openDiscordLink error:', err);
             Utils.showNotification('Error', 'Failed to open Discord link', 'error');
         }
     }
 
     async start() {
         
-        if (!this.projectSelector.value) {
+        if (!this.isPaused && !this.projectSelector.value) {
             Utils.showNotification('Error', 'Please select a project first', 'error');
             return;
         }
 
         try {
             if (!this.isRunning && !this.isPaused) {
-                // Starting new timer
-                this.currentProjectId = parseInt(this.projectSelector.value);
-                this.startTime = new Date();
-                
-                // Create new time block
-                const timeBlockData = {
-                    project_id: this.currentProjectId,
-                    start_time: this.startTime,
-                    end_time: null,
-                    duration: 0,
-                    is_manual: false,
+                // Starting new timer; the backend opens the time block
+                const state = await API.startTimer({
+                    project_id: parseInt(this.projectSelector.value),
                     description: null
-                };
-
-                const timeBlock = await API.createTimeBlock(timeBlockData);
-                this.currentTimeBlockId = timeBlock.id;
-                this.elapsedSeconds = 0;
-                
+                });
+                this.applyState(state);
                 
                 // Dispatch event to refresh time blocks
                 window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
                 
                 Utils.showNotification('Timer Started', 'Time tracking has begun!', 'success');
             } else if (this.isPaused) {
-                // Add the time spent paused to total paused time
-                if (this.pauseStartTime) {
-                    const pauseDuration = (Date.now() - this.pauseStartTime.getTime()) / 1000;
-                    this.totalPausedTime += pauseDuration;
-                    this.pauseStartTime = null;
-                }
+                const state = await API.resumeTimer();
+                this.applyState(state);
                 Utils.showNotification('Timer Resumed', 'Time tracking resumed!', 'success');
             }
-
-            this.isRunning = true;
-            this.isPaused = false;
-            this.updateButtons();
-            this.updateContainerClass();
-            this.startInterval();
-            
-            // Dispatch timer state change event
-            window.dispatchEvent(new CustomEvent('timerStateChanged', {
-                detail: { isRunning: this.isRunning, isPaused: this.isPaused }
-            }));
-            
         } catch (error) {
             console.error('Error starting timer:', error);
             Utils.showNotification('Error', 'Failed to start timer', 'error');
+            await this.syncState();
         }
     }
 
@@ -230,82 +189,266 @@ class Timer {
         }
 
         try {
-            this.isRunning = false;
-            this.isPaused = true;
-            this.pauseStartTime = new Date(); // Record when pause started
-            this.stopInterval();
-            this.updateButtons();
-            this.updateContainerClass();
+            const state = await API.pauseTimer();
+            this.applyState(state);
             
             Utils.showNotification('Timer Paused', 'Time tracking paused', 'warning');
-            
-            // Dispatch timer state change event
-            window.dispatchEvent(new CustomEvent('timerStateChanged', {
-                detail: { isRunning: this.isRunning, isPaused: this.isPaused }
-            }));
         } catch (error) {
             console.error('Error pausing timer:', error);
             Utils.showNotification('Error', 'Failed to pause timer', 'error');
+            await this.syncState();
         }
     }
 
     async stop() {
         if (!this.isRunning && !this.isPaused) return;
 
-        if (this.currentTimeBlockId) {
-            try {
-                // If currently paused, add the current pause time to total paused time
-                if (this.isPaused && this.pauseStartTime) {
-                    const pauseDuration = (Date.now() - this.pauseStartTime.getTime()) / 1000;
-                    this.totalPausedTime += pauseDuration;
-                }
-                                
-                // Stop the time block with the calculated duration
-                await API.stopTimeBlockWithDuration(this.currentTimeBlockId, this.elapsedSeconds);
-                
-                // Dispatch event to refresh time blocks
-                window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
-                
-                Utils.showNotification('Timer Stopped', 'Time block saved successfully!', 'success');
-            } catch (error) {
-                console.error('Error stopping timer:', error);
-                Utils.showNotification('Error', 'Failed to stop timer', 'error');
-            }
+        try {
+            // The backend closes the time block with the net elapsed duration
+            await API.stopTimer();
+            
+            // Dispatch event to refresh time blocks
+            window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
+            
+            Utils.showNotification('Timer Stopped', 'Time block saved successfully!', 'success');
+        } catch (error) {
+            console.error('Error stopping timer:', error);
+            Utils.showNotification('Error', 'Failed to stop timer', 'error');
         }
 
-        this.resetTimer();
+        await this.syncState();
     }
 
     async reset() {
         try {
-            if (this.isRunning && this.currentTimeBlockId) {
-                // Stop and delete the current time block
-                await API.deleteTimeBlock(this.currentTimeBlockId);
+            if (this.isRunning || this.isPaused) {
+                // Discard the current timer and its time block
+                const state = await API.resetTimer();
+                this.applyState(state);
+                window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
+            } else {
+                this.resetTimer();
             }
 
-            this.resetTimer();
             Utils.showNotification('Timer Reset', 'Timer has been reset', 'success');
-            
-            // Dispatch timer state change event
-            window.dispatchEvent(new CustomEvent('timerStateChanged', {
-                detail: { isRunning: this.isRunning, isPaused: this.isPaused }
-            }));
         } catch (error) {
             console.error('Error resetting timer:', error);
             Utils.showNotification('Error', 'Failed to reset timer', 'error');
         }
     }
 
+    // Load the timer state owned by the backend (survives reloads and restarts)
+    async syncState() {
+        try {
+            const state = await API.getTimerState();
+            this.applyState(state);
+        } catch (error) {
+            console.error('Error loading timer state:', error);
+        }
+    }
+
+    // Ask what to do with time blocks left running by a crash or sleep
+    async recoverOrphanedTimeBlocks() {
+        let orphaned = [];
+        try {
+            orphaned = await API.getOrphanedTimeBlocks() || [];
+        } catch (error) {
+            return;
+        }
+
+        for (const orphan of orphaned) {
+            const block = orphan.time_block;
+            const lastSeen = Utils.formatDateTime(orphan.recovered_end_time);
+            let action = null;
+
+            const resume = await Dialog.confirm(
+                'Unfinished Time Block',
+                `"${block.project_name}" was still running when ThinkTimer closed (last activity ${lastSeen}). Keep tracking it?`,
+                { confirmText: 'Resume', cancelText: 'No', confirmType: 'primary', icon: 'fa-history' }
+            );
+            if (resume) {
+                action = 'resume';
+            } else {
+                const stop = await Dialog.confirm(
+                    'Unfinished Time Block',
+                    `Stop it at ${lastSeen} with ${Utils.formatDuration(orphan.recovered_duration)} tracked? Choose Discard to delete it.`,
+                    { confirmText: 'Stop', cancelText: 'Discard', confirmType: 'primary', icon: 'fa-history' }
+                );
+                action = stop ? 'stop' : 'discard';
+            }
+
+            try {
+                const state = await API.recoverTimeBlock({ time_block_id: block.id, action });
+                this.applyState(state);
+                window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
+            } catch (error) {
+                Utils.showNotification('Error', 'Failed to recover time block', 'error');
+            }
+        }
+    }
+
+    // Ask whether to keep, trim or split the time spent away from the computer
+    async handleIdlePeriod(period) {
+        if (!period || this.handlingIdle) return;
+        this.handlingIdle = true;
+
+        try {
+            const idleSince = Utils.formatTime(period.idle_start);
+            let action = 'keep';
+
+            const keep = await Dialog.confirm(
+                'Welcome Back',
+                `You have been idle since ${idleSince}. Keep that time in the running time block?`,
+                { confirmText: 'Keep', cancelText: 'No', confirmType: 'primary', icon: 'fa-bed' }
+            );
+            if (!keep) {
+                const split = await Dialog.confirm(
+                    'Idle Time',
+                    `Log the idle time as a separate block and keep tracking, or stop the block at ${idleSince}?`,
+                    { confirmText: 'Split', cancelText: 'Stop at ' + idleSince, confirmType: 'primary', icon: 'fa-cut' }
+                );
+                action = split ? 'split' : 'trim';
+            }
+
+            const state = await API.resolveIdle({ action });
+            this.applyState(state);
+            if (action !== 'keep') {
+                window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
+            }
+        } catch (error) {
+            console.error('Error resolving idle time:', error);
+            Utils.showNotification('Error', 'Failed to handle idle time', 'error');
+        } finally {
+            this.handlingIdle = false;
+        }
+    }
+
+    async togglePomodoro() {
+        try {
+            if (this.pomodoroPhase && this.pomodoroPhase !== 'idle') {
+                const state = await API.stopPomodoro();
+                this.applyPomodoroState(state, false);
+                window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
+                Utils.showNotification('Pomodoro Stopped', 'Pomodoro session ended', 'success');
+                return;
+            }
+
+            if (!this.projectSelector.value) {
+                Utils.showNotification('Error', 'Please select a project first', 'error');
+                return;
+            }
+
+            const state = await API.startPomodoro({
+                project_id: parseInt(this.projectSelector.value),
+                description: null
+            });
+            this.applyPomodoroState(state, false);
+            window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
+            Utils.showNotification('Pomodoro Started', 'Focus until the next break!', 'success');
+        } catch (error) {
+            console.error('Error toggling pomodoro:', error);
+            Utils.showNotification('Error', 'Failed to toggle Pomodoro', 'error');
+        }
+    }
+
+    // Apply a backend Pomodoro state; notify when the phase changed on its own
+    applyPomodoroState(state, fromBackend) {
+        const previousPhase = this.pomodoroPhase;
+        this.pomodoroPhase = state && state.phase ? state.phase : 'idle';
+        this.pomodoroRemaining = state && state.remaining ? state.remaining : 0;
+        this.pomodoroSyncedAt = Date.now();
+        this.pomodoroCompletedToday = state && state.completed_today ? state.completed_today : 0;
+
+        if (this.pomodoroBtn) {
+            this.pomodoroBtn.classList.toggle('active', this.pomodoroPhase !== 'idle');
+            this.pomodoroBtn.innerHTML = this.pomodoroPhase !== 'idle'
+                ? '<i class="fas fa-hourglass-end"></i> End Pomodoro'
+                : '<i class="fas fa-hourglass-half"></i> Pomodoro';
+        }
+
+        if (this.pomodoroInterval) {
+            clearInterval(this.pomodoroInterval);
+            this.pomodoroInterval = null;
+        }
+        if (this.pomodoroPhase !== 'idle') {
+            this.pomodoroInterval = setInterval(() => this.updatePomodoroStatus(), 1000);
+        }
+        this.updatePomodoroStatus();
+
+        if (fromBackend && previousPhase && previousPhase !== this.pomodoroPhase) {
+            window.dispatchEvent(new CustomEvent('timeBlockUpdated'));
+            if (this.pomodoroPhase === 'short_break' || this.pomodoroPhase === 'long_break') {
+                Utils.showNotification('Break Time', 'Work interval saved. Take a break!', 'success');
+            } else if (this.pomodoroPhase === 'work') {
+                Utils.showNotification('Back to Work', 'Break is over, tracking resumed', 'success');
+            }
+        }
+    }
+
+    updatePomodoroStatus() {
+        if (!this.pomodoroStatus) return;
+
+        if (!this.pomodoroPhase || this.pomodoroPhase === 'idle') {
+            this.pomodoroStatus.style.display = 'none';
+            return;
+        }
+
+        // Work intervals do not count down while the timer is paused
+        let remaining = this.pomodoroRemaining;
+        if (this.pomodoroPhase !== 'work' || this.isRunning) {
+            remaining -= Math.floor((Date.now() - this.pomodoroSyncedAt) / 1000);
+        }
+
+        const labels = { work: 'Work', short_break: 'Short break', long_break: 'Long break' };
+        this.pomodoroStatus.textContent = `${labels[this.pomodoroPhase] || this.pomodoroPhase} · ${
+            Utils.formatDuration(Math.max(0, remaining))
+        } left · ${this.pomodoroCompletedToday} today`;
+        this.pomodoroStatus.style.display = '';
+    }
+
+    // Apply a backend timer state to the local view
+    applyState(state) {
+        const status = state && state.status ? state.status : 'idle';
+
+        this.isRunning = status === 'running';
+        this.isPaused = status === 'paused';
+        this.currentTimeBlockId = state && state.time_block_id ? state.time_block_id : null;
+        this.currentProjectId = state && state.project_id ? state.project_id : null;
+        this.startTime = state && state.start_time ? new Date(state.start_time) : null;
+        this.elapsedSeconds = state && state.elapsed ? state.elapsed : 0;
+        this.elapsedBase = this.elapsedSeconds;
+        this.elapsedSyncedAt = Date.now();
+
+        if (this.isRunning) {
+            this.startInterval();
+        } else {
+            this.stopInterval();
+        }
+
+        if (this.currentProjectId && this.projectSelector) {
+            this.projectSelector.value = String(this.currentProjectId);
+            this.updateProjectUrlButton();
+        }
+
+        this.updateDisplay();
+        this.updateButtons();
+        this.updateContainerClass();
+
+        // Dispatch timer state change event
+        window.dispatchEvent(new CustomEvent('timerStateChanged', {
+            detail: { isRunning: this.isRunning, isPaused: this.isPaused }
+        }));
+    }
+
     resetTimer() {
         this.isRunning = false;
         this.isPaused = false;
         this.currentProjectId = null;
         this.startTime = null;
-        this.pausedTime = 0;
-        this.totalPausedTime = 0;
-        this.pauseStartTime = null;
         this.currentTimeBlockId = null;
         this.elapsedSeconds = 0;
+        this.elapsedBase = 0;
+        this.elapsedSyncedAt = null;
         this.stopInterval();
         this.updateDisplay();
         this.updateButtons();
@@ -320,16 +463,10 @@ class Timer {
     startInterval() {
         this.stopInterval(); // Clear any existing interval
         this.interval = setInterval(() => {
-            if (this.isRunning && this.startTime) {
-                // Calculate elapsed time excluding paused time
-                const now = new Date();
-                const totalElapsed = (now.getTime() - this.startTime.getTime()) / 1000;
-                this.elapsedSeconds = Math.floor(totalElapsed - this.totalPausedTime);
-                
-                // Ensure we don't show negative time
-                if (this.elapsedSeconds < 0) {
-                    this.elapsedSeconds = 0;
-                }
+            if (this.isRunning && this.elapsedSyncedAt) {
+                // Elapsed time excluding pauses comes from the backend; only count locally since the last sync
+                const sinceSync = Math.floor((Date.now() - this.elapsedSyncedAt) / 1000);
+                this.elapsedSeconds = this.elapsedBase + sinceSync;
                 
                 this.updateDisplay();
             }
@@ -423,23 +560,20 @@ class Timer {
             if (!this.projectSelector) return;
             const selectedOption = this.projectSelector.options[this.projectSelector.selectedIndex];
             
-            const processUrlButton = (btn, urlDataAttr) => {
-                if (!btn) return;
-                const url = selectedOption ? selectedOption.getAttribute(urlDataAttr) : null;
-                if (url && url.trim()) {
-                    btn.style.display = 'inline-flex';
-                    btn.title = '';
-                    btn.setAttribute('aria-label', `Open project ${urlDataAttr.replace('data-', '')}`);
-                    const iconClass = this.getUrlIcon(url);
-                    btn.innerHTML = `<i class="${iconClass}"></i>`;
-                } else {
-                    btn.style.display = 'none';
-                }
-            };
-
-            processUrlButton(this.openProjectUrlBtn1, 'data-url1');
-            processUrlButton(this.openProjectUrlBtn2, 'data-url2');
-            processUrlButton(this.openProjectUrlBtn3, 'data-url3');
+            // One button per link, in the project's link order
+            if (this.projectLinkButtons) {
+                const links = this.getSelectedProjectLinks();
+                this.projectLinkButtons.innerHTML = links.map((link, index) => `
+                    <button type="button" class="btn project-url-btn" data-index="${index}" data-tooltip-position="bottom">
+                        <i class="${link.kind === 'discord' ? 'fab fa-discord' : this.getUrlIcon(link.url)}"></i>
+                    </button>
+                `).join('');
+                // Labels are set as attributes so quotes in them cannot break the markup
+                this.projectLinkButtons.querySelectorAll('.project-url-btn').forEach((btn, index) => {
+                    btn.dataset.tooltip = links[index].label || links[index].url;
+                    btn.setAttribute('aria-label', `Open ${links[index].label || 'project link'}`);
+                });
+            }
 
             // Also handle directory button
             if (this.openProjectDirBtn) {
@@ -452,18 +586,6 @@ class Timer {
                     this.openProjectDirBtn.style.display = 'none';
                 }
             }
-            // Also handle discord button
-            if (this.openProjectDiscordBtn) {
-                const discord = selectedOption ? selectedOption.getAttribute('data-discord') : null;
-                if (discord && String(discord).trim()) {
-                    this.openProjectDiscordBtn.style.display = 'inline-flex';
-                    this.openProjectDiscordBtn.title = '';
-                    this.openProjectDiscordBtn.setAttribute('aria-label', 'Open project Discord channel');
-                } else {
-                    this.openProjectDiscordBtn.style.display = 'none';
-                }
-            }
-
             // Also handle edit button visibility
             if (this.editProjectBtn) {
                 const hasSelection = this.projectSelector && this.projectSelector.value;
@@ -510,15 +632,29 @@ class Timer {
         }
     }
 
-    async openSelectedProjectUrl(urlIndex) {
+    // Links of the selected project, stored as JSON on its selector option
+    getSelectedProjectLinks() {
+        if (!this.projectSelector) return [];
+        const selectedOption = this.projectSelector.options[this.projectSelector.selectedIndex];
         try {
-            if (!this.projectSelector) return;
-            const selectedOption = this.projectSelector.options[this.projectSelector.selectedIndex];
-            const url = selectedOption ? selectedOption.getAttribute(`data-url${urlIndex}`) : null;
-            if (!url) {
+            return JSON.parse(selectedOption?.dataset?.links || '[]');
+        } catch (err) {
+            return [];
+        }
+    }
+
+    async openSelectedProjectLink(index) {
+        try {
+            const link = this.getSelectedProjectLinks()[index];
+            if (!link || !link.url) {
                 Utils.showNotification('Warning', 'Selected project has no URL', 'warning');
                 return;
             }
+            if (link.kind === 'discord') {
+                await this.openDiscordLink(link.url);
+                return;
+            }
+            const url = link.url;
 
             // Use Wails runtime to open in default browser
             try {
@@ -569,6 +705,10 @@ class Timer {
     // Clean up when page unloads
     cleanup() {
         this.stopInterval();
+        if (this.pomodoroInterval) {
+            clearInterval(this.pomodoroInterval);
+            this.pomodoroInterval = null;
+        }
     }
 }
 
@@ -0,0 +1,125 @@
+/**
+ * Timesheet Export
+ * Saves a printable timesheet of a date range and a set of projects as PDF or HTML
+ */
+
+import API from './api.js';
+import Utils from './utils.js';
+import StandardModal from './standard-modal.js';
+
+class TimesheetExport {
+    constructor(projectsInstance, calendarInstance) {
+        this.projects = projectsInstance;
+        this.calendar = calendarInstance;
+
+        this.initializeElements();
+        this.bindEvents();
+    }
+
+    initializeElements() {
+        this.exportBtn = document.getElementById('export-timesheet');
+
+        this.timesheetModal = new StandardModal('timesheet-modal', {
+            title: 'Export Timesheet',
+            icon: 'fas fa-file-export',
+            iconType: 'project'
+        });
+
+        this.startField = document.getElementById('timesheet-start');
+        this.endField = document.getElementById('timesheet-end');
+        this.projectsEl = document.getElementById('timesheet-projects');
+        this.formatField = document.getElementById('timesheet-format');
+    }
+
+    bindEvents() {
+        this.exportBtn?.addEventListener('click', () => this.open());
+
+        this.timesheetModal.setFormHandler('timesheet-form', (e) => this.handleSubmit(e));
+        document.getElementById('cancel-timesheet')?.addEventListener('click', () => this.close());
+        this.timesheetModal.modal.querySelector('.standard-modal-close')?.addEventListener('click', () => this.close());
+
+        document.addEventListener('keydown', (e) => {
+            if (e.key === 'Escape' && this.timesheetModal.isVisible) {
+                this.close();
+            }
+        });
+    }
+
+    // Opens the modal on the Monday to Sunday week of the date selected in the calendar
+    open() {
+        const selected = Utils.getStartOfDay(this.calendar?.selectedDate || new Date());
+        const monday = new Date(selected);
+        monday.setDate(monday.getDate() - ((monday.getDay() + 6) % 7));
+        const sunday = new Date(monday);
+        sunday.setDate(sunday.getDate() + 6);
+
+        this.startField.value = Utils.formatDateForInput(monday);
+        this.endField.value = Utils.formatDateForInput(sunday);
+        this.renderProjects();
+
+        this.timesheetModal.show();
+    }
+
+    close() {
+        this.timesheetModal.hide();
+    }
+
+    renderProjects() {
+        const projects = this.projects.getProjects().filter(project => project.status !== 'archived');
+        if (projects.length === 0) {
+            this.projectsEl.innerHTML = '<span class="no-tags">No projects yet</span>';
+            return;
+        }
+
+        this.projectsEl.innerHTML = projects.map(project => `
+            <label>
+                <input type="checkbox" value="${project.id}">
+                ${Utils.escapeHtml(project.name)}
+            </label>
+        `).join('');
+    }
+
+    // Parses a YYYY-MM-DD input as local midnight
+    parseDate(value) {
+        const [y, m, d] = value.split('-').map(Number);
+        return new Date(y, m - 1, d);
+    }
+
+    async handleSubmit(e) {
+        e.preventDefault();
+
+        if (!this.startField.value || !this.endField.value) {
+            Utils.showNotification('Error', 'Please choose a start and end date', 'error');
+            return;
+        }
+
+        const startDate = this.parseDate(this.startField.value);
+        const endDate = this.parseDate(this.endField.value);
+        if (endDate < startDate) {
+            Utils.showNotification('Error', 'The end date cannot be before the start date', 'error');
+            return;
+        }
+        // The end date is included up to its last second
+        endDate.setHours(23, 59, 59, 999);
+
+        const projectIds = [...this.projectsEl.querySelectorAll('input:checked')].map(input => parseInt(input.value, 10));
+
+        try {
+            const path = await API.exportTimesheet({
+                start_date: startDate,
+                end_date: endDate,
+                project_ids: projectIds,
+                format: this.formatField.value
+            });
+            // An empty path means the save dialog was cancelled
+            if (!path) return;
+            Utils.showNotification('Success', `Timesheet saved to ${path}`, 'success');
+            this.close();
+        } catch (error) {
+            console.error('Error exporting timesheet:', error);
+            Utils.showNotification('Error', String(error || 'Failed to export timesheet'), 'error');
+        }
+    }
+}
+
+export default TimesheetExport;
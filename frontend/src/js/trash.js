@@ -0,0 +1,166 @@
+// Trash Module - Lists deleted projects and time blocks on the settings page for restore or permanent deletion
+import API from './api.js';
+import Utils from './utils.js';
+import Dialog from './dialog.js';
+
+class Trash {
+    constructor() {
+        this.trash = null;
+
+        this.trashList = document.getElementById('trash-list');
+        this.emptyTrashBtn = document.getElementById('empty-trash');
+
+        this.bindEvents();
+    }
+
+    bindEvents() {
+        this.emptyTrashBtn?.addEventListener('click', () => this.emptyTrash());
+
+        this.trashList?.addEventListener('click', (e) => {
+            const btn = e.target.closest('.tag-action-btn');
+            if (!btn) return;
+            const row = btn.closest('.trash-row');
+            const id = parseInt(row.dataset.id, 10);
+            if (btn.dataset.action === 'restore') {
+                this.restore(row.dataset.kind, id);
+            } else if (btn.dataset.action === 'purge') {
+                this.purge(row.dataset.kind, id);
+            }
+        });
+
+        window.addEventListener('trashRetentionChanged', () => this.loadTrash());
+    }
+
+    async loadTrash() {
+        try {
+            this.trash = await API.getTrash();
+        } catch (error) {
+            console.error('Error loading trash:', error);
+            this.trash = null;
+        }
+        this.renderTrash();
+    }
+
+    renderTrash() {
+        if (!this.trashList) return;
+
+        const projects = this.trash?.projects || [];
+        const timeBlocks = this.trash?.time_blocks || [];
+        if (this.emptyTrashBtn) {
+            this.emptyTrashBtn.disabled = projects.length === 0 && timeBlocks.length === 0;
+        }
+
+        if (projects.length === 0 && timeBlocks.length === 0) {
+            this.trashList.innerHTML = '<span class="no-tags">The trash is empty</span>';
+            return;
+        }
+
+        const projectRows = projects.map(({ project, time_block_count: count, duration }) => this.renderRow(
+            'project', project.id, 'fa-folder', project.name,
+            [
+                `${count} time block${count === 1 ? '' : 's'}`,
+                count ? Utils.formatDurationShort(duration) : '',
+                this.expiryText(project.deleted_at)
+            ]
+        ));
+        const timeBlockRows = timeBlocks.map(block => this.renderRow(
+            'timeblock', block.id, 'fa-clock', block.description || block.project_name,
+            [
+                block.description ? block.project_name : '',
+                `${Utils.formatDate(block.start_time)} ${Utils.formatTime(block.start_time)}`,
+                Utils.formatDurationShort(block.duration),
+                this.expiryText(block.deleted_at)
+            ]
+        ));
+
+        this.trashList.innerHTML = [...projectRows, ...timeBlockRows].join('');
+    }
+
+    renderRow(kind, id, icon, title, meta) {
+        return `
+            <div class="trash-row" data-kind="${kind}" data-id="${id}">
+                <i class="fas ${icon} trash-kind"></i>
+                <div class="trash-details">
+                    <span class="trash-name">${Utils.escapeHtml(title || '')}</span>
+                    <span class="trash-meta">${meta.filter(Boolean).map(part => Utils.escapeHtml(part)).join(' · ')}</span>
+                </div>
+                <button type="button" class="tag-action-btn" data-action="restore" title="Restore">
+                    <i class="fas fa-rotate-left"></i>
+                </button>
+                <button type="button" class="tag-action-btn delete" data-action="purge" title="Delete permanently">
+                    <i class="fas fa-trash"></i>
+                </button>
+            </div>
+        `;
+    }
+
+    // When an item will be purged automatically, based on the retention setting
+    expiryText(deletedAt) {
+        const retentionDays = this.trash?.retention_days || 0;
+        if (!retentionDays || !deletedAt) return '';
+
+        const purgeAt = new Date(deletedAt).getTime() + retentionDays * 24 * 60 * 60 * 1000;
+        const daysLeft = Math.max(0, Math.ceil((purgeAt - Date.now()) / (24 * 60 * 60 * 1000)));
+        return daysLeft <= 1 ? 'removed within a day' : `removed in ${daysLeft} days`;
+    }
+
+    async restore(kind, id) {
+        try {
+            if (kind === 'project') {
+                await API.restoreProjectFromTrash(id);
+                Utils.showNotification('Success', 'Project restored with its time blocks', 'success');
+            } else {
+                await API.restoreTimeBlockFromTrash(id);
+                Utils.showNotification('Success', 'Time block restored', 'success');
+            }
+            window.dispatchEvent(new CustomEvent('trashUpdated'));
+        } catch (error) {
+            console.error('Error restoring from trash:', error);
+            Utils.showNotification('Error', String(error || 'Failed to restore'), 'error');
+        }
+        await this.loadTrash();
+    }
+
+    async purge(kind, id) {
+        const confirmed = await Dialog.confirm(
+            'Delete Permanently',
+            kind === 'project'
+                ? 'Permanently delete this project with all of its time blocks and tasks? This action cannot be undone.'
+                : 'Permanently delete this time block? This action cannot be undone.',
+            { confirmText: 'Delete', cancelText: 'Cancel', confirmType: 'danger' }
+        );
+        if (!confirmed) return;
+
+        try {
+            if (kind === 'project') {
+                await API.purgeProject(id);
+            } else {
+                await API.purgeTimeBlock(id);
+            }
+        } catch (error) {
+            console.error('Error purging from trash:', error);
+            Utils.showNotification('Error', String(error || 'Failed to delete permanently'), 'error');
+        }
+        await this.loadTrash();
+    }
+
+    async emptyTrash() {
+        const confirmed = await Dialog.confirm(
+            'Empty Trash',
+            'Permanently delete everything in the trash? This action cannot be undone.',
+            { confirmText: 'Empty Trash', cancelText: 'Cancel', confirmType: 'danger' }
+        );
+        if (!confirmed) return;
+
+        try {
+            await API.emptyTrash();
+            Utils.showNotification('Success', 'Trash emptied', 'success');
+        } catch (error) {
+            console.error('Error emptying trash:', error);
+            Utils.showNotification('Error', String(error || 'Failed to empty trash'), 'error');
+        }
+        await this.loadTrash();
+    }
+}
+
+export default Trash;
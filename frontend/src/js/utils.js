@@ -25,6 +25,16 @@ class Utils {
         return `${minutes}m`;
     }
 
+    // Format an amount in the currency chosen in settings
+    static formatMoney(amount, currency = null) {
+        const code = currency || window.appSettings?.settings?.currency || 'USD';
+        try {
+            return new Intl.NumberFormat(undefined, { style: 'currency', currency: code }).format(amount || 0);
+        } catch (_) {
+            return `${(amount || 0).toFixed(2)} ${code}`;
+        }
+    }
+
     static formatDurationFriendly(seconds) {
         if (seconds === null || seconds === undefined) return '0m';
         const totalSeconds = Math.floor(seconds);
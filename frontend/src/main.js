@@ -4,6 +4,10 @@ import Projects from './js/projects.js';
 import TimeBlocks from './js/timeblocks.js';
 import Calendar from './js/calendar.js';
 import Settings from './js/settings.js';
+import Clients from './js/clients.js';
+import Trash from './js/trash.js';
+import CustomFields from './js/custom-fields.js';
+import TimesheetExport from './js/timesheet-export.js';
 import NavBar from './js/navbar.js';
 import Utils from './js/utils.js';
 import API from './js/api.js';
@@ -39,9 +43,13 @@ class App {
 
 
         this.projects = new Projects();
+        this.clients = new Clients();
+        this.trash = new Trash();
+        this.customFields = new CustomFields();
         this.timeBlocks = new TimeBlocks(this.projects);
         this.timer = new Timer();
         this.calendar = new Calendar(this.projects, this.timeBlocks);
+        this.timesheetExport = new TimesheetExport(this.projects, this.calendar);
 
 
         try {
@@ -603,6 +611,10 @@ class App {
                 break;
             case 'settings':
 
+                this.settings.loadTags();
+                this.clients.loadClients();
+                this.customFields.loadFields();
+                this.trash.loadTrash();
                 break;
         }
     }
@@ -643,6 +655,19 @@ class App {
                         e.preventDefault();
                         this.settings.toggleTheme();
                         break;
+                    case 'z':
+                    case 'Z':
+                        e.preventDefault();
+                        if (e.shiftKey) {
+                            this.redo();
+                        } else {
+                            this.undo();
+                        }
+                        break;
+                    case 'y':
+                        e.preventDefault();
+                        this.redo();
+                        break;
                 }
             }
 
@@ -674,6 +699,35 @@ class App {
         });
     }
 
+    async undo() {
+        try {
+            const command = await API.undo();
+            Utils.showNotification('Undone', command.label, 'success');
+            this.reloadAfterHistoryChange();
+        } catch (error) {
+            Utils.showNotification('Undo', String(error || 'Failed to undo'), 'warning');
+        }
+    }
+
+    async redo() {
+        try {
+            const command = await API.redo();
+            Utils.showNotification('Redone', command.label, 'success');
+            this.reloadAfterHistoryChange();
+        } catch (error) {
+            Utils.showNotification('Redo', String(error || 'Failed to redo'), 'warning');
+        }
+    }
+
+    // Undo and redo can touch any data, so refresh the settings, the project list everyone listens to and the current page
+    reloadAfterHistoryChange() {
+        this.settings.loadSettings();
+        if (this.currentPage !== 'projects') {
+            this.projects.loadProjects();
+        }
+        this.handlePageSwitch(this.currentPage);
+    }
+
     disableNativeContextMenu() {
 
         document.addEventListener('contextmenu', (e) => {
@@ -3,38 +3,194 @@
 import {models} from '../models';
 import {time} from '../models';
 
+export function ArchiveProject(arg1:number):Promise<models.Project>;
+
+export function CreateClient(arg1:models.CreateClientRequest):Promise<models.Client>;
+
+export function CreateCustomField(arg1:models.CreateCustomFieldRequest):Promise<models.CustomField>;
+
+export function CreateMilestone(arg1:models.CreateMilestoneRequest):Promise<models.Milestone>;
+
 export function CreateProject(arg1:models.CreateProjectRequest):Promise<models.Project>;
 
+export function CreateProjectFromTemplate(arg1:number,arg2:string):Promise<models.Project>;
+
+export function CreateProjectLink(arg1:models.CreateProjectLinkRequest):Promise<models.ProjectLink>;
+
+export function CreateTag(arg1:models.CreateTagRequest):Promise<models.Tag>;
+
+export function CreateTask(arg1:models.CreateTaskRequest):Promise<models.Task>;
+
 export function CreateTimeBlock(arg1:models.CreateTimeBlockRequest):Promise<models.TimeBlock>;
 
+export function DeleteClient(arg1:number):Promise<void>;
+
+export function DeleteCustomField(arg1:number):Promise<void>;
+
+export function DeleteMilestone(arg1:number):Promise<void>;
+
 export function DeleteProject(arg1:number):Promise<void>;
 
+export function DeleteProjectLink(arg1:number):Promise<void>;
+
+export function DeleteProjectTemplate(arg1:number):Promise<void>;
+
+export function DeleteTag(arg1:number):Promise<void>;
+
+export function DeleteTask(arg1:number):Promise<void>;
+
 export function DeleteTimeBlock(arg1:number):Promise<void>;
 
+export function DuplicateProject(arg1:number):Promise<models.Project>;
+
+export function EmptyTrash():Promise<void>;
+
+export function ExportTimesheet(arg1:models.TimesheetRequest):Promise<string>;
+
+export function GetAllClients():Promise<Array<models.Client>>;
+
+export function GetAllCustomFields():Promise<Array<models.CustomField>>;
+
+export function GetAllProjectTemplates():Promise<Array<models.ProjectTemplate>>;
+
 export function GetAllProjects():Promise<Array<models.Project>>;
 
+export function GetAllTags():Promise<Array<models.Tag>>;
+
+export function GetArchivedProjects():Promise<Array<models.Project>>;
+
+export function GetBillableSummary(arg1:time.Time,arg2:time.Time,arg3:models.TimeBlockFilter):Promise<models.BillableSummary>;
+
+export function GetClientTotals(arg1:time.Time,arg2:time.Time):Promise<Array<models.ClientTotal>>;
+
+export function GetCustomFieldTotals(arg1:number,arg2:time.Time,arg3:time.Time,arg4:models.TimeBlockFilter):Promise<Array<models.CustomFieldTotal>>;
+
+export function GetDeadlinesByDateRange(arg1:time.Time,arg2:time.Time):Promise<Array<models.Deadline>>;
+
+export function GetIdlePeriod():Promise<models.IdlePeriod>;
+
+export function GetOrphanedTimeBlocks():Promise<Array<models.OrphanedTimeBlock>>;
+
+export function GetPomodoroCountsByDateRange(arg1:time.Time,arg2:time.Time):Promise<Array<models.PomodoroDayCount>>;
+
+export function GetPomodoroCyclesByDate(arg1:time.Time):Promise<Array<models.PomodoroCycle>>;
+
+export function GetPomodoroState():Promise<models.PomodoroState>;
+
+export function GetProjectBurnDown(arg1:number):Promise<models.BurnDown>;
+
 export function GetProjectByID(arg1:number):Promise<models.Project>;
 
+export function GetProjectLinks(arg1:number):Promise<Array<models.ProjectLink>>;
+
+export function GetProjectMilestones(arg1:number):Promise<Array<models.Milestone>>;
+
+export function GetReport(arg1:models.ReportGrouping,arg2:time.Time,arg3:time.Time,arg4:models.TimeBlockFilter):Promise<models.Report>;
+
 export function GetSettings():Promise<models.Settings>;
 
+export function GetTagTotals(arg1:time.Time,arg2:time.Time):Promise<Array<models.TagTotal>>;
+
+export function GetTaskBurnDown(arg1:number):Promise<models.BurnDown>;
+
+export function GetTaskTree(arg1:number):Promise<models.TaskTree>;
+
+export function GetTimeBlockHistory(arg1:number):Promise<Array<models.TimeBlockChange>>;
+
 export function GetTimeBlocksByDate(arg1:time.Time):Promise<Array<models.TimeBlock>>;
 
-export function GetTimeBlocksByDateRange(arg1:time.Time,arg2:time.Time):Promise<Array<models.TimeBlock>>;
+export function GetTimeBlocksByDateRange(arg1:time.Time,arg2:time.Time,arg3:models.TimeBlockFilter):Promise<Array<models.TimeBlock>>;
+
+export function GetTimerState():Promise<models.TimerState>;
 
 export function GetTotalDurationByProject(arg1:number):Promise<number>;
 
+export function GetTrash():Promise<models.Trash>;
+
+export function GetUndoState():Promise<models.UndoState>;
+
+export function MergeTags(arg1:models.MergeTagsRequest):Promise<models.Tag>;
+
+export function MergeTimeBlocks(arg1:Array<number>):Promise<models.TimeBlock>;
+
+export function MoveTimeBlocks(arg1:models.MoveTimeBlocksRequest):Promise<Array<models.TimeBlock>>;
+
 export function OpenDirectory(arg1:string):Promise<void>;
 
 export function OpenURL(arg1:string):Promise<void>;
 
+export function PauseTimer():Promise<models.TimerState>;
+
+export function PurgeProject(arg1:number):Promise<void>;
+
+export function PurgeTimeBlock(arg1:number):Promise<void>;
+
+export function RecoverTimeBlock(arg1:models.RecoverTimeBlockRequest):Promise<models.TimerState>;
+
+export function Redo():Promise<models.Command>;
+
+export function RepairTimeBlockDurations():Promise<number>;
+
+export function ResetTimer():Promise<models.TimerState>;
+
+export function ResolveIdle(arg1:models.ResolveIdleRequest):Promise<models.TimerState>;
+
+export function ResolveTimeBlockOverlaps(arg1:models.ResolveOverlapRequest):Promise<models.TimeBlock>;
+
+export function RestoreProject(arg1:number):Promise<models.Project>;
+
+export function RestoreProjectFromTrash(arg1:number):Promise<void>;
+
+export function RestoreTimeBlockFromTrash(arg1:number):Promise<models.TimeBlock>;
+
+export function ResumeTimer():Promise<models.TimerState>;
+
+export function SaveProjectAsTemplate(arg1:number,arg2:string):Promise<models.ProjectTemplate>;
+
+export function SetProjectFieldValues(arg1:number,arg2:Record<number, string>):Promise<Record<number, string>>;
+
+export function SetProjectTags(arg1:number,arg2:Array<number>):Promise<Array<models.Tag>>;
+
+export function SetTimeBlockFieldValues(arg1:number,arg2:Record<number, string>):Promise<Record<number, string>>;
+
+export function SetTimeBlockTags(arg1:number,arg2:Array<number>):Promise<Array<models.Tag>>;
+
+export function SplitTimeBlock(arg1:number,arg2:time.Time,arg3:number):Promise<Array<models.TimeBlock>>;
+
+export function StartPomodoro(arg1:models.StartPomodoroRequest):Promise<models.PomodoroState>;
+
+export function StartTimer(arg1:models.StartTimerRequest):Promise<models.TimerState>;
+
+export function StopPomodoro():Promise<models.PomodoroState>;
+
 export function StopRunningTimeBlock(arg1:number):Promise<models.TimeBlock>;
 
 export function StopTimeBlockWithDuration(arg1:number,arg2:number):Promise<models.TimeBlock>;
 
+export function StopTimer():Promise<models.TimeBlock>;
+
+export function Undo():Promise<models.Command>;
+
+export function UpdateClient(arg1:number,arg2:models.UpdateClientRequest):Promise<models.Client>;
+
+export function UpdateCustomField(arg1:number,arg2:models.UpdateCustomFieldRequest):Promise<models.CustomField>;
+
+export function UpdateMilestone(arg1:number,arg2:models.UpdateMilestoneRequest):Promise<models.Milestone>;
+
 export function UpdateProject(arg1:number,arg2:models.UpdateProjectRequest):Promise<models.Project>;
 
+export function UpdateProjectLink(arg1:number,arg2:models.UpdateProjectLinkRequest):Promise<models.ProjectLink>;
+
+export function UpdateProjectLinksOrder(arg1:Record<number, number>):Promise<void>;
+
 export function UpdateProjectsOrder(arg1:Record<number, number>):Promise<void>;
 
 export function UpdateSettings(arg1:models.UpdateSettingsRequest):Promise<models.Settings>;
 
+export function UpdateTag(arg1:number,arg2:models.UpdateTagRequest):Promise<models.Tag>;
+
+export function UpdateTask(arg1:number,arg2:models.UpdateTaskRequest):Promise<models.Task>;
+
+export function UpdateTasksOrder(arg1:Record<number, number>):Promise<void>;
+
 export function UpdateTimeBlock(arg1:number,arg2:models.UpdateTimeBlockRequest):Promise<models.TimeBlock>;
@@ -2,46 +2,230 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function ArchiveProject(arg1) {
+  return window['go']['main']['App']['ArchiveProject'](arg1);
+}
+
+export function CreateClient(arg1) {
+  return window['go']['main']['App']['CreateClient'](arg1);
+}
+
+export function CreateCustomField(arg1) {
+  return window['go']['main']['App']['CreateCustomField'](arg1);
+}
+
+export function CreateMilestone(arg1) {
+  return window['go']['main']['App']['CreateMilestone'](arg1);
+}
+
 export function CreateProject(arg1) {
   return window['go']['main']['App']['CreateProject'](arg1);
 }
 
+export function CreateProjectFromTemplate(arg1, arg2) {
+  return window['go']['main']['App']['CreateProjectFromTemplate'](arg1, arg2);
+}
+
+export function CreateProjectLink(arg1) {
+  return window['go']['main']['App']['CreateProjectLink'](arg1);
+}
+
+export function CreateTag(arg1) {
+  return window['go']['main']['App']['CreateTag'](arg1);
+}
+
+export function CreateTask(arg1) {
+  return window['go']['main']['App']['CreateTask'](arg1);
+}
+
 export function CreateTimeBlock(arg1) {
   return window['go']['main']['App']['CreateTimeBlock'](arg1);
 }
 
+export function DeleteClient(arg1) {
+  return window['go']['main']['App']['DeleteClient'](arg1);
+}
+
+export function DeleteCustomField(arg1) {
+  return window['go']['main']['App']['DeleteCustomField'](arg1);
+}
+
+export function DeleteMilestone(arg1) {
+  return window['go']['main']['App']['DeleteMilestone'](arg1);
+}
+
 export function DeleteProject(arg1) {
   return window['go']['main']['App']['DeleteProject'](arg1);
 }
 
+export function DeleteProjectLink(arg1) {
+  return window['go']['main']['App']['DeleteProjectLink'](arg1);
+}
+
+export function DeleteProjectTemplate(arg1) {
+  return window['go']['main']['App']['DeleteProjectTemplate'](arg1);
+}
+
+export function DeleteTag(arg1) {
+  return window['go']['main']['App']['DeleteTag'](arg1);
+}
+
+export function DeleteTask(arg1) {
+  return window['go']['main']['App']['DeleteTask'](arg1);
+}
+
 export function DeleteTimeBlock(arg1) {
   return window['go']['main']['App']['DeleteTimeBlock'](arg1);
 }
 
+export function DuplicateProject(arg1) {
+  return window['go']['main']['App']['DuplicateProject'](arg1);
+}
+
+export function EmptyTrash() {
+  return window['go']['main']['App']['EmptyTrash']();
+}
+
+export function ExportTimesheet(arg1) {
+  return window['go']['main']['App']['ExportTimesheet'](arg1);
+}
+
+export function GetAllClients() {
+  return window['go']['main']['App']['GetAllClients']();
+}
+
+export function GetAllCustomFields() {
+  return window['go']['main']['App']['GetAllCustomFields']();
+}
+
+export function GetAllProjectTemplates() {
+  return window['go']['main']['App']['GetAllProjectTemplates']();
+}
+
 export function GetAllProjects() {
   return window['go']['main']['App']['GetAllProjects']();
 }
 
+export function GetAllTags() {
+  return window['go']['main']['App']['GetAllTags']();
+}
+
+export function GetArchivedProjects() {
+  return window['go']['main']['App']['GetArchivedProjects']();
+}
+
+export function GetBillableSummary(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetBillableSummary'](arg1, arg2, arg3);
+}
+
+export function GetClientTotals(arg1, arg2) {
+  return window['go']['main']['App']['GetClientTotals'](arg1, arg2);
+}
+
+export function GetCustomFieldTotals(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetCustomFieldTotals'](arg1, arg2, arg3, arg4);
+}
+
+export function GetDeadlinesByDateRange(arg1, arg2) {
+  return window['go']['main']['App']['GetDeadlinesByDateRange'](arg1, arg2);
+}
+
+export function GetIdlePeriod() {
+  return window['go']['main']['App']['GetIdlePeriod']();
+}
+
+export function GetOrphanedTimeBlocks() {
+  return window['go']['main']['App']['GetOrphanedTimeBlocks']();
+}
+
+export function GetPomodoroCountsByDateRange(arg1, arg2) {
+  return window['go']['main']['App']['GetPomodoroCountsByDateRange'](arg1, arg2);
+}
+
+export function GetPomodoroCyclesByDate(arg1) {
+  return window['go']['main']['App']['GetPomodoroCyclesByDate'](arg1);
+}
+
+export function GetPomodoroState() {
+  return window['go']['main']['App']['GetPomodoroState']();
+}
+
+export function GetProjectBurnDown(arg1) {
+  return window['go']['main']['App']['GetProjectBurnDown'](arg1);
+}
+
 export function GetProjectByID(arg1) {
   return window['go']['main']['App']['GetProjectByID'](arg1);
 }
 
+export function GetProjectLinks(arg1) {
+  return window['go']['main']['App']['GetProjectLinks'](arg1);
+}
+
+export function GetProjectMilestones(arg1) {
+  return window['go']['main']['App']['GetProjectMilestones'](arg1);
+}
+
+export function GetReport(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetReport'](arg1, arg2, arg3, arg4);
+}
+
 export function GetSettings() {
   return window['go']['main']['App']['GetSettings']();
 }
 
+export function GetTagTotals(arg1, arg2) {
+  return window['go']['main']['App']['GetTagTotals'](arg1, arg2);
+}
+
+export function GetTaskBurnDown(arg1) {
+  return window['go']['main']['App']['GetTaskBurnDown'](arg1);
+}
+
+export function GetTaskTree(arg1) {
+  return window['go']['main']['App']['GetTaskTree'](arg1);
+}
+
+export function GetTimeBlockHistory(arg1) {
+  return window['go']['main']['App']['GetTimeBlockHistory'](arg1);
+}
+
 export function GetTimeBlocksByDate(arg1) {
   return window['go']['main']['App']['GetTimeBlocksByDate'](arg1);
 }
 
-export function GetTimeBlocksByDateRange(arg1, arg2) {
-  return window['go']['main']['App']['GetTimeBlocksByDateRange'](arg1, arg2);
+export function GetTimeBlocksByDateRange(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetTimeBlocksByDateRange'](arg1, arg2, arg3);
+}
+
+export function GetTimerState() {
+  return window['go']['main']['App']['GetTimerState']();
 }
 
 export function GetTotalDurationByProject(arg1) {
   return window['go']['main']['App']['GetTotalDurationByProject'](arg1);
 }
 
+export function GetTrash() {
+  return window['go']['main']['App']['GetTrash']();
+}
+
+export function GetUndoState() {
+  return window['go']['main']['App']['GetUndoState']();
+}
+
+export function MergeTags(arg1) {
+  return window['go']['main']['App']['MergeTags'](arg1);
+}
+
+export function MergeTimeBlocks(arg1) {
+  return window['go']['main']['App']['MergeTimeBlocks'](arg1);
+}
+
+export function MoveTimeBlocks(arg1) {
+  return window['go']['main']['App']['MoveTimeBlocks'](arg1);
+}
+
 export function OpenDirectory(arg1) {
   return window['go']['main']['App']['OpenDirectory'](arg1);
 }
@@ -50,6 +234,94 @@ export function OpenURL(arg1) {
   return window['go']['main']['App']['OpenURL'](arg1);
 }
 
+export function PauseTimer() {
+  return window['go']['main']['App']['PauseTimer']();
+}
+
+export function PurgeProject(arg1) {
+  return window['go']['main']['App']['PurgeProject'](arg1);
+}
+
+export function PurgeTimeBlock(arg1) {
+  return window['go']['main']['App']['PurgeTimeBlock'](arg1);
+}
+
+export function RecoverTimeBlock(arg1) {
+  return window['go']['main']['App']['RecoverTimeBlock'](arg1);
+}
+
+export function Redo() {
+  return window['go']['main']['App']['Redo']();
+}
+
+export function RepairTimeBlockDurations() {
+  return window['go']['main']['App']['RepairTimeBlockDurations']();
+}
+
+export function ResetTimer() {
+  return window['go']['main']['App']['ResetTimer']();
+}
+
+export function ResolveIdle(arg1) {
+  return window['go']['main']['App']['ResolveIdle'](arg1);
+}
+
+export function ResolveTimeBlockOverlaps(arg1) {
+  return window['go']['main']['App']['ResolveTimeBlockOverlaps'](arg1);
+}
+
+export function RestoreProject(arg1) {
+  return window['go']['main']['App']['RestoreProject'](arg1);
+}
+
+export function RestoreProjectFromTrash(arg1) {
+  return window['go']['main']['App']['RestoreProjectFromTrash'](arg1);
+}
+
+export function RestoreTimeBlockFromTrash(arg1) {
+  return window['go']['main']['App']['RestoreTimeBlockFromTrash'](arg1);
+}
+
+export function ResumeTimer() {
+  return window['go']['main']['App']['ResumeTimer']();
+}
+
+export function SaveProjectAsTemplate(arg1, arg2) {
+  return window['go']['main']['App']['SaveProjectAsTemplate'](arg1, arg2);
+}
+
+export function SetProjectFieldValues(arg1, arg2) {
+  return window['go']['main']['App']['SetProjectFieldValues'](arg1, arg2);
+}
+
+export function SetProjectTags(arg1, arg2) {
+  return window['go']['main']['App']['SetProjectTags'](arg1, arg2);
+}
+
+export function SetTimeBlockFieldValues(arg1, arg2) {
+  return window['go']['main']['App']['SetTimeBlockFieldValues'](arg1, arg2);
+}
+
+export function SetTimeBlockTags(arg1, arg2) {
+  return window['go']['main']['App']['SetTimeBlockTags'](arg1, arg2);
+}
+
+export function SplitTimeBlock(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SplitTimeBlock'](arg1, arg2, arg3);
+}
+
+export function StartPomodoro(arg1) {
+  return window['go']['main']['App']['StartPomodoro'](arg1);
+}
+
+export function StartTimer(arg1) {
+  return window['go']['main']['App']['StartTimer'](arg1);
+}
+
+export function StopPomodoro() {
+  return window['go']['main']['App']['StopPomodoro']();
+}
+
 export function StopRunningTimeBlock(arg1) {
   return window['go']['main']['App']['StopRunningTimeBlock'](arg1);
 }
@@ -58,10 +330,38 @@ export function StopTimeBlockWithDuration(arg1, arg2) {
   return window['go']['main']['App']['StopTimeBlockWithDuration'](arg1, arg2);
 }
 
+export function StopTimer() {
+  return window['go']['main']['App']['StopTimer']();
+}
+
+export function Undo() {
+  return window['go']['main']['App']['Undo']();
+}
+
+export function UpdateClient(arg1, arg2) {
+  return window['go']['main']['App']['UpdateClient'](arg1, arg2);
+}
+
+export function UpdateCustomField(arg1, arg2) {
+  return window['go']['main']['App']['UpdateCustomField'](arg1, arg2);
+}
+
+export function UpdateMilestone(arg1, arg2) {
+  return window['go']['main']['App']['UpdateMilestone'](arg1, arg2);
+}
+
 export function UpdateProject(arg1, arg2) {
   return window['go']['main']['App']['UpdateProject'](arg1, arg2);
 }
 
+export function UpdateProjectLink(arg1, arg2) {
+  return window['go']['main']['App']['UpdateProjectLink'](arg1, arg2);
+}
+
+export function UpdateProjectLinksOrder(arg1) {
+  return window['go']['main']['App']['UpdateProjectLinksOrder'](arg1);
+}
+
 export function UpdateProjectsOrder(arg1) {
   return window['go']['main']['App']['UpdateProjectsOrder'](arg1);
 }
@@ -70,6 +370,18 @@ export function UpdateSettings(arg1) {
   return window['go']['main']['App']['UpdateSettings'](arg1);
 }
 
+export function UpdateTag(arg1, arg2) {
+  return window['go']['main']['App']['UpdateTag'](arg1, arg2);
+}
+
+export function UpdateTask(arg1, arg2) {
+  return window['go']['main']['App']['UpdateTask'](arg1, arg2);
+}
+
+export function UpdateTasksOrder(arg1) {
+  return window['go']['main']['App']['UpdateTasksOrder'](arg1);
+}
+
 export function UpdateTimeBlock(arg1, arg2) {
   return window['go']['main']['App']['UpdateTimeBlock'](arg1, arg2);
 }
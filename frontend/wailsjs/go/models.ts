@@ -1,31 +1,49 @@
 export namespace models {
 	
-	export class CreateProjectRequest {
-	    name: string;
-	    description?: string;
-	    url1?: string;
-	    url2?: string;
-	    url3?: string;
-	    discord?: string;
-	    directory?: string;
-	    deadline?: time.Time;
-	    order: number;
+	export class ProjectBillable {
+	    project_id: number;
+	    project_name: string;
+	    client_id?: number;
+	    currency: string;
+	    duration: number;
+	    billable_duration: number;
+	    amount: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new CreateProjectRequest(source);
+	        return new ProjectBillable(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.description = source["description"];
-	        this.url1 = source["url1"];
-	        this.url2 = source["url2"];
-	        this.url3 = source["url3"];
-	        this.discord = source["discord"];
-	        this.directory = source["directory"];
-	        this.deadline = this.convertValues(source["deadline"], time.Time);
-	        this.order = source["order"];
+	        this.project_id = source["project_id"];
+	        this.project_name = source["project_name"];
+	        this.client_id = source["client_id"];
+	        this.currency = source["currency"];
+	        this.duration = source["duration"];
+	        this.billable_duration = source["billable_duration"];
+	        this.amount = source["amount"];
+	    }
+	}
+	export class BillableSummary {
+	    currency: string;
+	    duration: number;
+	    billable_duration: number;
+	    amount: number;
+	    amounts_by_currency: Record<string, number>;
+	    projects: ProjectBillable[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BillableSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currency = source["currency"];
+	        this.duration = source["duration"];
+	        this.billable_duration = source["billable_duration"];
+	        this.amount = source["amount"];
+	        this.amounts_by_currency = source["amounts_by_currency"];
+	        this.projects = this.convertValues(source["projects"], ProjectBillable);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -46,26 +64,34 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class CreateTimeBlockRequest {
+	export class BurnDown {
 	    project_id: number;
-	    start_time: time.Time;
-	    end_time?: time.Time;
-	    duration: number;
-	    is_manual: boolean;
-	    description?: string;
+	    task_id?: number;
+	    name: string;
+	    estimated_hours: number;
+	    consumed_hours: number;
+	    remaining_hours: number;
+	    percent_burned: number;
+	    daily_velocity: number;
+	    velocity_days: number;
+	    projected_completion?: time.Time;
 	
 	    static createFrom(source: any = {}) {
-	        return new CreateTimeBlockRequest(source);
+	        return new BurnDown(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.project_id = source["project_id"];
-	        this.start_time = this.convertValues(source["start_time"], time.Time);
-	        this.end_time = this.convertValues(source["end_time"], time.Time);
-	        this.duration = source["duration"];
-	        this.is_manual = source["is_manual"];
-	        this.description = source["description"];
+	        this.task_id = source["task_id"];
+	        this.name = source["name"];
+	        this.estimated_hours = source["estimated_hours"];
+	        this.consumed_hours = source["consumed_hours"];
+	        this.remaining_hours = source["remaining_hours"];
+	        this.percent_burned = source["percent_burned"];
+	        this.daily_velocity = source["daily_velocity"];
+	        this.velocity_days = source["velocity_days"];
+	        this.projected_completion = this.convertValues(source["projected_completion"], time.Time);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -86,38 +112,28 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class Project {
+	export class Client {
 	    id: number;
 	    name: string;
-	    description?: string;
-	    url1?: string;
-	    url2?: string;
-	    url3?: string;
-	    discord?: string;
-	    directory?: string;
-	    deadline?: time.Time;
-	    status: string;
-	    order: number;
+	    contact?: string;
+	    default_rate: number;
+	    currency: string;
+	    notes?: string;
 	    created_at: time.Time;
 	    updated_at: time.Time;
 	
 	    static createFrom(source: any = {}) {
-	        return new Project(source);
+	        return new Client(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
 	        this.name = source["name"];
-	        this.description = source["description"];
-	        this.url1 = source["url1"];
-	        this.url2 = source["url2"];
-	        this.url3 = source["url3"];
-	        this.discord = source["discord"];
-	        this.directory = source["directory"];
-	        this.deadline = this.convertValues(source["deadline"], time.Time);
-	        this.status = source["status"];
-	        this.order = source["order"];
+	        this.contact = source["contact"];
+	        this.default_rate = source["default_rate"];
+	        this.currency = source["currency"];
+	        this.notes = source["notes"];
 	        this.created_at = this.convertValues(source["created_at"], time.Time);
 	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
 	    }
@@ -140,56 +156,42 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class Settings {
-	    id: number;
-	    theme: string;
-	    language: string;
-	    timeFormat: string;
-	    customUrl: string;
-	    trelloUrl: string;
+	export class ClientTotal {
+	    client_id: number;
+	    client_name: string;
+	    duration: number;
+	    block_count: number;
+	    project_count: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Settings(source);
+	        return new ClientTotal(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.theme = source["theme"];
-	        this.language = source["language"];
-	        this.timeFormat = source["timeFormat"];
-	        this.customUrl = source["customUrl"];
-	        this.trelloUrl = source["trelloUrl"];
+	        this.client_id = source["client_id"];
+	        this.client_name = source["client_name"];
+	        this.duration = source["duration"];
+	        this.block_count = source["block_count"];
+	        this.project_count = source["project_count"];
 	    }
 	}
-	export class TimeBlock {
+	export class Command {
 	    id: number;
-	    project_id: number;
-	    project_name: string;
-	    start_time: time.Time;
-	    end_time?: time.Time;
-	    duration: number;
-	    is_manual: boolean;
-	    description?: string;
+	    label: string;
+	    undone: boolean;
 	    created_at: time.Time;
-	    updated_at: time.Time;
 	
 	    static createFrom(source: any = {}) {
-	        return new TimeBlock(source);
+	        return new Command(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.project_id = source["project_id"];
-	        this.project_name = source["project_name"];
-	        this.start_time = this.convertValues(source["start_time"], time.Time);
-	        this.end_time = this.convertValues(source["end_time"], time.Time);
-	        this.duration = source["duration"];
-	        this.is_manual = source["is_manual"];
-	        this.description = source["description"];
+	        this.label = source["label"];
+	        this.undone = source["undone"];
 	        this.created_at = this.convertValues(source["created_at"], time.Time);
-	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -210,34 +212,126 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class UpdateProjectRequest {
-	    name?: string;
+	export class CreateClientRequest {
+	    name: string;
+	    contact?: string;
+	    default_rate: number;
+	    currency: string;
+	    notes?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateClientRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.contact = source["contact"];
+	        this.default_rate = source["default_rate"];
+	        this.currency = source["currency"];
+	        this.notes = source["notes"];
+	    }
+	}
+	export class CreateCustomFieldRequest {
+	    name: string;
+	    type: string;
+	    options: string[];
+	    order: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateCustomFieldRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.options = source["options"];
+	        this.order = source["order"];
+	    }
+	}
+	export class CreateMilestoneRequest {
+	    project_id: number;
+	    title: string;
+	    due_date: time.Time;
+	    estimated_hours?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateMilestoneRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project_id = source["project_id"];
+	        this.title = source["title"];
+	        this.due_date = this.convertValues(source["due_date"], time.Time);
+	        this.estimated_hours = source["estimated_hours"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CreateProjectLinkRequest {
+	    project_id: number;
+	    label: string;
+	    url: string;
+	    kind: string;
+	    order: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateProjectLinkRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project_id = source["project_id"];
+	        this.label = source["label"];
+	        this.url = source["url"];
+	        this.kind = source["kind"];
+	        this.order = source["order"];
+	    }
+	}
+	export class CreateProjectRequest {
+	    name: string;
 	    description?: string;
-	    url1?: string;
-	    url2?: string;
-	    url3?: string;
-	    discord?: string;
 	    directory?: string;
 	    deadline?: time.Time;
-	    status?: string;
-	    order?: number;
+	    order: number;
+	    billable: boolean;
+	    hourly_rate: number;
+	    client_id?: number;
+	    estimated_hours?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new UpdateProjectRequest(source);
+	        return new CreateProjectRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
 	        this.description = source["description"];
-	        this.url1 = source["url1"];
-	        this.url2 = source["url2"];
-	        this.url3 = source["url3"];
-	        this.discord = source["discord"];
 	        this.directory = source["directory"];
 	        this.deadline = this.convertValues(source["deadline"], time.Time);
-	        this.status = source["status"];
 	        this.order = source["order"];
+	        this.billable = source["billable"];
+	        this.hourly_rate = source["hourly_rate"];
+	        this.client_id = source["client_id"];
+	        this.estimated_hours = source["estimated_hours"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -258,42 +352,110 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class UpdateSettingsRequest {
-	    theme?: string;
-	    language?: string;
-	    timeFormat?: string;
-	    customUrl?: string;
-	    trelloUrl?: string;
+	export class CreateTagRequest {
+	    name: string;
+	    color: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new UpdateSettingsRequest(source);
+	        return new CreateTagRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.theme = source["theme"];
-	        this.language = source["language"];
-	        this.timeFormat = source["timeFormat"];
-	        this.customUrl = source["customUrl"];
-	        this.trelloUrl = source["trelloUrl"];
+	        this.name = source["name"];
+	        this.color = source["color"];
 	    }
 	}
-	export class UpdateTimeBlockRequest {
-	    start_time?: time.Time;
+	export class CreateTaskRequest {
+	    project_id: number;
+	    parent_id?: number;
+	    name: string;
+	    description?: string;
+	    order: number;
+	    estimated_hours?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateTaskRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project_id = source["project_id"];
+	        this.parent_id = source["parent_id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.order = source["order"];
+	        this.estimated_hours = source["estimated_hours"];
+	    }
+	}
+	export class CreateTimeBlockRequest {
+	    project_id: number;
+	    task_id?: number;
+	    start_time: time.Time;
 	    end_time?: time.Time;
-	    duration?: number;
+	    duration: number;
+	    is_manual: boolean;
 	    description?: string;
+	    billable?: boolean;
+	    hourly_rate?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new UpdateTimeBlockRequest(source);
+	        return new CreateTimeBlockRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project_id = source["project_id"];
+	        this.task_id = source["task_id"];
 	        this.start_time = this.convertValues(source["start_time"], time.Time);
 	        this.end_time = this.convertValues(source["end_time"], time.Time);
 	        this.duration = source["duration"];
+	        this.is_manual = source["is_manual"];
 	        this.description = source["description"];
+	        this.billable = source["billable"];
+	        this.hourly_rate = source["hourly_rate"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CustomField {
+	    id: number;
+	    name: string;
+	    type: string;
+	    options: string[];
+	    order: number;
+	    created_at: time.Time;
+	    updated_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new CustomField(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.options = source["options"];
+	        this.order = source["order"];
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -314,6 +476,1481 @@ export namespace models {
 		    return a;
 		}
 	}
+	export class CustomFieldTotal {
+	    value: string;
+	    duration: number;
+	    block_count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CustomFieldTotal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.duration = source["duration"];
+	        this.block_count = source["block_count"];
+	    }
+	}
+	export class Deadline {
+	    project_id: number;
+	    project_name: string;
+	    milestone_id?: number;
+	    title: string;
+	    due_date: time.Time;
+	    done: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Deadline(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project_id = source["project_id"];
+	        this.project_name = source["project_name"];
+	        this.milestone_id = source["milestone_id"];
+	        this.title = source["title"];
+	        this.due_date = this.convertValues(source["due_date"], time.Time);
+	        this.done = source["done"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class IdlePeriod {
+	    time_block_id: number;
+	    idle_start: time.Time;
+	    detected_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new IdlePeriod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time_block_id = source["time_block_id"];
+	        this.idle_start = this.convertValues(source["idle_start"], time.Time);
+	        this.detected_at = this.convertValues(source["detected_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MergeTagsRequest {
+	    source_ids: number[];
+	    target_id: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeTagsRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source_ids = source["source_ids"];
+	        this.target_id = source["target_id"];
+	    }
+	}
+	export class Milestone {
+	    id: number;
+	    project_id: number;
+	    title: string;
+	    due_date: time.Time;
+	    done: boolean;
+	    estimated_hours?: number;
+	    created_at: time.Time;
+	    updated_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new Milestone(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.project_id = source["project_id"];
+	        this.title = source["title"];
+	        this.due_date = this.convertValues(source["due_date"], time.Time);
+	        this.done = source["done"];
+	        this.estimated_hours = source["estimated_hours"];
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MoveTimeBlocksRequest {
+	    time_block_ids: number[];
+	    project_id: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MoveTimeBlocksRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time_block_ids = source["time_block_ids"];
+	        this.project_id = source["project_id"];
+	    }
+	}
+	export class Tag {
+	    id: number;
+	    name: string;
+	    color: string;
+	    created_at: time.Time;
+	    updated_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new Tag(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.color = source["color"];
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimeBlockSegment {
+	    id: number;
+	    time_block_id: number;
+	    start_time: time.Time;
+	    end_time?: time.Time;
+	    created_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeBlockSegment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.time_block_id = source["time_block_id"];
+	        this.start_time = this.convertValues(source["start_time"], time.Time);
+	        this.end_time = this.convertValues(source["end_time"], time.Time);
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimeBlock {
+	    id: number;
+	    project_id: number;
+	    project_name: string;
+	    task_id?: number;
+	    task_name?: string;
+	    start_time: time.Time;
+	    end_time?: time.Time;
+	    duration: number;
+	    duration_overridden: boolean;
+	    is_manual: boolean;
+	    description?: string;
+	    heartbeat_at?: time.Time;
+	    billable?: boolean;
+	    hourly_rate?: number;
+	    deleted_at?: time.Time;
+	    created_at: time.Time;
+	    updated_at: time.Time;
+	    segments: TimeBlockSegment[];
+	    paused_duration: number;
+	    tags: Tag[];
+	    custom_fields: Record<number, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeBlock(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.project_id = source["project_id"];
+	        this.project_name = source["project_name"];
+	        this.task_id = source["task_id"];
+	        this.task_name = source["task_name"];
+	        this.start_time = this.convertValues(source["start_time"], time.Time);
+	        this.end_time = this.convertValues(source["end_time"], time.Time);
+	        this.duration = source["duration"];
+	        this.duration_overridden = source["duration_overridden"];
+	        this.is_manual = source["is_manual"];
+	        this.description = source["description"];
+	        this.heartbeat_at = this.convertValues(source["heartbeat_at"], time.Time);
+	        this.billable = source["billable"];
+	        this.hourly_rate = source["hourly_rate"];
+	        this.deleted_at = this.convertValues(source["deleted_at"], time.Time);
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	        this.segments = this.convertValues(source["segments"], TimeBlockSegment);
+	        this.paused_duration = source["paused_duration"];
+	        this.tags = this.convertValues(source["tags"], Tag);
+	        this.custom_fields = source["custom_fields"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OrphanedTimeBlock {
+	    time_block: TimeBlock;
+	    last_heartbeat?: time.Time;
+	    recovered_end_time: time.Time;
+	    recovered_duration: number;
+	    is_timer_block: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new OrphanedTimeBlock(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time_block = this.convertValues(source["time_block"], TimeBlock);
+	        this.last_heartbeat = this.convertValues(source["last_heartbeat"], time.Time);
+	        this.recovered_end_time = this.convertValues(source["recovered_end_time"], time.Time);
+	        this.recovered_duration = source["recovered_duration"];
+	        this.is_timer_block = source["is_timer_block"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PomodoroCycle {
+	    id: number;
+	    time_block_id: number;
+	    project_id: number;
+	    started_at: time.Time;
+	    completed_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new PomodoroCycle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.time_block_id = source["time_block_id"];
+	        this.project_id = source["project_id"];
+	        this.started_at = this.convertValues(source["started_at"], time.Time);
+	        this.completed_at = this.convertValues(source["completed_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PomodoroDayCount {
+	    date: string;
+	    cycles: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PomodoroDayCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.cycles = source["cycles"];
+	    }
+	}
+	export class PomodoroState {
+	    phase: string;
+	    project_id?: number;
+	    time_block_id?: number;
+	    description?: string;
+	    phase_started_at?: time.Time;
+	    completed_cycles: number;
+	    remaining: number;
+	    completed_today: number;
+	    updated_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new PomodoroState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.phase = source["phase"];
+	        this.project_id = source["project_id"];
+	        this.time_block_id = source["time_block_id"];
+	        this.description = source["description"];
+	        this.phase_started_at = this.convertValues(source["phase_started_at"], time.Time);
+	        this.completed_cycles = source["completed_cycles"];
+	        this.remaining = source["remaining"];
+	        this.completed_today = source["completed_today"];
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProjectLink {
+	    id: number;
+	    project_id: number;
+	    label: string;
+	    url: string;
+	    kind: string;
+	    order: number;
+	    created_at: time.Time;
+	    updated_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectLink(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.project_id = source["project_id"];
+	        this.label = source["label"];
+	        this.url = source["url"];
+	        this.kind = source["kind"];
+	        this.order = source["order"];
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Project {
+	    id: number;
+	    name: string;
+	    description?: string;
+	    directory?: string;
+	    deadline?: time.Time;
+	    status: string;
+	    order: number;
+	    billable: boolean;
+	    hourly_rate: number;
+	    client_id?: number;
+	    estimated_hours?: number;
+	    archived_at?: time.Time;
+	    deleted_at?: time.Time;
+	    created_at: time.Time;
+	    updated_at: time.Time;
+	    tags: Tag[];
+	    links: ProjectLink[];
+	    custom_fields: Record<number, string>;
+	    milestones: Milestone[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Project(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.directory = source["directory"];
+	        this.deadline = this.convertValues(source["deadline"], time.Time);
+	        this.status = source["status"];
+	        this.order = source["order"];
+	        this.billable = source["billable"];
+	        this.hourly_rate = source["hourly_rate"];
+	        this.client_id = source["client_id"];
+	        this.estimated_hours = source["estimated_hours"];
+	        this.archived_at = this.convertValues(source["archived_at"], time.Time);
+	        this.deleted_at = this.convertValues(source["deleted_at"], time.Time);
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	        this.tags = this.convertValues(source["tags"], Tag);
+	        this.links = this.convertValues(source["links"], ProjectLink);
+	        this.custom_fields = source["custom_fields"];
+	        this.milestones = this.convertValues(source["milestones"], Milestone);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class TemplateTask {
+	    name: string;
+	    description?: string;
+	    estimated_hours?: number;
+	    children: TemplateTask[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TemplateTask(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.estimated_hours = source["estimated_hours"];
+	        this.children = this.convertValues(source["children"], TemplateTask);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TemplateLink {
+	    label: string;
+	    url: string;
+	    kind: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TemplateLink(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.label = source["label"];
+	        this.url = source["url"];
+	        this.kind = source["kind"];
+	    }
+	}
+	export class ProjectTemplate {
+	    id: number;
+	    name: string;
+	    description?: string;
+	    directory?: string;
+	    billable: boolean;
+	    hourly_rate: number;
+	    client_id?: number;
+	    estimated_hours?: number;
+	    links: TemplateLink[];
+	    tasks: TemplateTask[];
+	    created_at: time.Time;
+	    updated_at: time.Time;
+	    tags: Tag[];
+	    custom_fields: Record<number, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectTemplate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.directory = source["directory"];
+	        this.billable = source["billable"];
+	        this.hourly_rate = source["hourly_rate"];
+	        this.client_id = source["client_id"];
+	        this.estimated_hours = source["estimated_hours"];
+	        this.links = this.convertValues(source["links"], TemplateLink);
+	        this.tasks = this.convertValues(source["tasks"], TemplateTask);
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	        this.tags = this.convertValues(source["tags"], Tag);
+	        this.custom_fields = source["custom_fields"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RecoverTimeBlockRequest {
+	    time_block_id: number;
+	    action: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecoverTimeBlockRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time_block_id = source["time_block_id"];
+	        this.action = source["action"];
+	    }
+	}
+	export class ReportGroup {
+	    key: string;
+	    id: number;
+	    label: string;
+	    color: string;
+	    duration: number;
+	    block_count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReportGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.id = source["id"];
+	        this.label = source["label"];
+	        this.color = source["color"];
+	        this.duration = source["duration"];
+	        this.block_count = source["block_count"];
+	    }
+	}
+	export class Report {
+	    grouping: string;
+	    duration: number;
+	    block_count: number;
+	    groups: ReportGroup[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.grouping = source["grouping"];
+	        this.duration = source["duration"];
+	        this.block_count = source["block_count"];
+	        this.groups = this.convertValues(source["groups"], ReportGroup);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ResolveIdleRequest {
+	    action: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResolveIdleRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	    }
+	}
+	export class UpdateTimeBlockRequest {
+	    project_id?: number;
+	    task_id?: number;
+	    start_time?: time.Time;
+	    end_time?: time.Time;
+	    duration?: number;
+	    reset_duration: boolean;
+	    description?: string;
+	    billable?: boolean;
+	    hourly_rate?: number;
+	    reset_billing: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateTimeBlockRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project_id = source["project_id"];
+	        this.task_id = source["task_id"];
+	        this.start_time = this.convertValues(source["start_time"], time.Time);
+	        this.end_time = this.convertValues(source["end_time"], time.Time);
+	        this.duration = source["duration"];
+	        this.reset_duration = source["reset_duration"];
+	        this.description = source["description"];
+	        this.billable = source["billable"];
+	        this.hourly_rate = source["hourly_rate"];
+	        this.reset_billing = source["reset_billing"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ResolveOverlapRequest {
+	    time_block_id?: number;
+	    create?: CreateTimeBlockRequest;
+	    update?: UpdateTimeBlockRequest;
+	    strategy: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResolveOverlapRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time_block_id = source["time_block_id"];
+	        this.create = this.convertValues(source["create"], CreateTimeBlockRequest);
+	        this.update = this.convertValues(source["update"], UpdateTimeBlockRequest);
+	        this.strategy = source["strategy"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Settings {
+	    id: number;
+	    theme: string;
+	    language: string;
+	    timeFormat: string;
+	    customUrl: string;
+	    trelloUrl: string;
+	    allowParallelTimers: boolean;
+	    idleThresholdMinutes: number;
+	    pomodoroWorkMinutes: number;
+	    pomodoroShortBreakMinutes: number;
+	    pomodoroLongBreakMinutes: number;
+	    pomodoroLongBreakEvery: number;
+	    currency: string;
+	    budgetAlertThreshold: number;
+	    trashRetentionDays: number;
+	    deadlineReminderDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Settings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.theme = source["theme"];
+	        this.language = source["language"];
+	        this.timeFormat = source["timeFormat"];
+	        this.customUrl = source["customUrl"];
+	        this.trelloUrl = source["trelloUrl"];
+	        this.allowParallelTimers = source["allowParallelTimers"];
+	        this.idleThresholdMinutes = source["idleThresholdMinutes"];
+	        this.pomodoroWorkMinutes = source["pomodoroWorkMinutes"];
+	        this.pomodoroShortBreakMinutes = source["pomodoroShortBreakMinutes"];
+	        this.pomodoroLongBreakMinutes = source["pomodoroLongBreakMinutes"];
+	        this.pomodoroLongBreakEvery = source["pomodoroLongBreakEvery"];
+	        this.currency = source["currency"];
+	        this.budgetAlertThreshold = source["budgetAlertThreshold"];
+	        this.trashRetentionDays = source["trashRetentionDays"];
+	        this.deadlineReminderDays = source["deadlineReminderDays"];
+	    }
+	}
+	export class StartPomodoroRequest {
+	    project_id: number;
+	    description?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StartPomodoroRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project_id = source["project_id"];
+	        this.description = source["description"];
+	    }
+	}
+	export class StartTimerRequest {
+	    project_id: number;
+	    task_id?: number;
+	    description?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StartTimerRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project_id = source["project_id"];
+	        this.task_id = source["task_id"];
+	        this.description = source["description"];
+	    }
+	}
+	
+	export class TagTotal {
+	    tag_id: number;
+	    tag_name: string;
+	    color: string;
+	    duration: number;
+	    block_count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TagTotal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tag_id = source["tag_id"];
+	        this.tag_name = source["tag_name"];
+	        this.color = source["color"];
+	        this.duration = source["duration"];
+	        this.block_count = source["block_count"];
+	    }
+	}
+	export class Task {
+	    id: number;
+	    project_id: number;
+	    parent_id?: number;
+	    name: string;
+	    description?: string;
+	    done: boolean;
+	    order: number;
+	    estimated_hours?: number;
+	    created_at: time.Time;
+	    updated_at: time.Time;
+	    duration: number;
+	    total_duration: number;
+	    children: Task[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Task(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.project_id = source["project_id"];
+	        this.parent_id = source["parent_id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.done = source["done"];
+	        this.order = source["order"];
+	        this.estimated_hours = source["estimated_hours"];
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	        this.duration = source["duration"];
+	        this.total_duration = source["total_duration"];
+	        this.children = this.convertValues(source["children"], Task);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TaskTree {
+	    project_id: number;
+	    duration: number;
+	    unassigned_duration: number;
+	    tasks: Task[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskTree(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project_id = source["project_id"];
+	        this.duration = source["duration"];
+	        this.unassigned_duration = source["unassigned_duration"];
+	        this.tasks = this.convertValues(source["tasks"], Task);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	export class TimeBlockChange {
+	    id: number;
+	    time_block_id: number;
+	    action: string;
+	    before: Record<string, any>;
+	    after: Record<string, any>;
+	    changed: string[];
+	    changed_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeBlockChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.time_block_id = source["time_block_id"];
+	        this.action = source["action"];
+	        this.before = source["before"];
+	        this.after = source["after"];
+	        this.changed = source["changed"];
+	        this.changed_at = this.convertValues(source["changed_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimeBlockFilter {
+	    tag_ids: number[];
+	    match_all_tags: boolean;
+	    client_ids: number[];
+	    custom_fields: Record<number, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeBlockFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tag_ids = source["tag_ids"];
+	        this.match_all_tags = source["match_all_tags"];
+	        this.client_ids = source["client_ids"];
+	        this.custom_fields = source["custom_fields"];
+	    }
+	}
+	
+	export class TimerState {
+	    status: string;
+	    time_block_id?: number;
+	    project_id?: number;
+	    start_time?: time.Time;
+	    paused_at?: time.Time;
+	    paused_seconds: number;
+	    elapsed: number;
+	    updated_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimerState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.time_block_id = source["time_block_id"];
+	        this.project_id = source["project_id"];
+	        this.start_time = this.convertValues(source["start_time"], time.Time);
+	        this.paused_at = this.convertValues(source["paused_at"], time.Time);
+	        this.paused_seconds = source["paused_seconds"];
+	        this.elapsed = source["elapsed"];
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimesheetRequest {
+	    start_date: time.Time;
+	    end_date: time.Time;
+	    project_ids: number[];
+	    format: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimesheetRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start_date = this.convertValues(source["start_date"], time.Time);
+	        this.end_date = this.convertValues(source["end_date"], time.Time);
+	        this.project_ids = source["project_ids"];
+	        this.format = source["format"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TrashedProject {
+	    project: Project;
+	    time_block_count: number;
+	    duration: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrashedProject(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project = this.convertValues(source["project"], Project);
+	        this.time_block_count = source["time_block_count"];
+	        this.duration = source["duration"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Trash {
+	    projects: TrashedProject[];
+	    time_blocks: TimeBlock[];
+	    retention_days: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Trash(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.projects = this.convertValues(source["projects"], TrashedProject);
+	        this.time_blocks = this.convertValues(source["time_blocks"], TimeBlock);
+	        this.retention_days = source["retention_days"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class UndoState {
+	    undo?: Command;
+	    redo?: Command;
+	
+	    static createFrom(source: any = {}) {
+	        return new UndoState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.undo = this.convertValues(source["undo"], Command);
+	        this.redo = this.convertValues(source["redo"], Command);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UpdateClientRequest {
+	    name?: string;
+	    contact?: string;
+	    default_rate?: number;
+	    currency?: string;
+	    notes?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateClientRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.contact = source["contact"];
+	        this.default_rate = source["default_rate"];
+	        this.currency = source["currency"];
+	        this.notes = source["notes"];
+	    }
+	}
+	export class UpdateCustomFieldRequest {
+	    name?: string;
+	    options: string[];
+	    order?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateCustomFieldRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.options = source["options"];
+	        this.order = source["order"];
+	    }
+	}
+	export class UpdateMilestoneRequest {
+	    title?: string;
+	    due_date?: time.Time;
+	    done?: boolean;
+	    estimated_hours?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateMilestoneRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.due_date = this.convertValues(source["due_date"], time.Time);
+	        this.done = source["done"];
+	        this.estimated_hours = source["estimated_hours"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UpdateProjectLinkRequest {
+	    label?: string;
+	    url?: string;
+	    kind?: string;
+	    order?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateProjectLinkRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.label = source["label"];
+	        this.url = source["url"];
+	        this.kind = source["kind"];
+	        this.order = source["order"];
+	    }
+	}
+	export class UpdateProjectRequest {
+	    name?: string;
+	    description?: string;
+	    directory?: string;
+	    deadline?: time.Time;
+	    status?: string;
+	    order?: number;
+	    billable?: boolean;
+	    hourly_rate?: number;
+	    client_id?: number;
+	    estimated_hours?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateProjectRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.directory = source["directory"];
+	        this.deadline = this.convertValues(source["deadline"], time.Time);
+	        this.status = source["status"];
+	        this.order = source["order"];
+	        this.billable = source["billable"];
+	        this.hourly_rate = source["hourly_rate"];
+	        this.client_id = source["client_id"];
+	        this.estimated_hours = source["estimated_hours"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UpdateSettingsRequest {
+	    theme?: string;
+	    language?: string;
+	    timeFormat?: string;
+	    customUrl?: string;
+	    trelloUrl?: string;
+	    allowParallelTimers?: boolean;
+	    idleThresholdMinutes?: number;
+	    pomodoroWorkMinutes?: number;
+	    pomodoroShortBreakMinutes?: number;
+	    pomodoroLongBreakMinutes?: number;
+	    pomodoroLongBreakEvery?: number;
+	    currency?: string;
+	    budgetAlertThreshold?: number;
+	    trashRetentionDays?: number;
+	    deadlineReminderDays?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateSettingsRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.theme = source["theme"];
+	        this.language = source["language"];
+	        this.timeFormat = source["timeFormat"];
+	        this.customUrl = source["customUrl"];
+	        this.trelloUrl = source["trelloUrl"];
+	        this.allowParallelTimers = source["allowParallelTimers"];
+	        this.idleThresholdMinutes = source["idleThresholdMinutes"];
+	        this.pomodoroWorkMinutes = source["pomodoroWorkMinutes"];
+	        this.pomodoroShortBreakMinutes = source["pomodoroShortBreakMinutes"];
+	        this.pomodoroLongBreakMinutes = source["pomodoroLongBreakMinutes"];
+	        this.pomodoroLongBreakEvery = source["pomodoroLongBreakEvery"];
+	        this.currency = source["currency"];
+	        this.budgetAlertThreshold = source["budgetAlertThreshold"];
+	        this.trashRetentionDays = source["trashRetentionDays"];
+	        this.deadlineReminderDays = source["deadlineReminderDays"];
+	    }
+	}
+	export class UpdateTagRequest {
+	    name?: string;
+	    color?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateTagRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.color = source["color"];
+	    }
+	}
+	export class UpdateTaskRequest {
+	    parent_id?: number;
+	    name?: string;
+	    description?: string;
+	    done?: boolean;
+	    order?: number;
+	    estimated_hours?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateTaskRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.parent_id = source["parent_id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.done = source["done"];
+	        this.order = source["order"];
+	        this.estimated_hours = source["estimated_hours"];
+	    }
+	}
 
 }
 
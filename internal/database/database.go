@@ -1,9 +1,13 @@
 package database
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -21,8 +25,11 @@ func New() (*DB, error) {
 		return nil, err
 	}
 
-	dbPath := filepath.Join(filepath.Dir(exePath), "thinktimer.db")
+	return Open(filepath.Join(filepath.Dir(exePath), "thinktimer.db"))
+}
 
+// Open opens the database at the given path, creating and migrating it as needed
+func Open(dbPath string) (*DB, error) {
 	// Create database connection
 	conn, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
@@ -39,6 +46,25 @@ func New() (*DB, error) {
 	return db, nil
 }
 
+// RunOnce runs a data migration that needs the services, such as a repair of stored values, unless a
+// migration of that name already succeeded
+func (db *DB) RunOnce(name string, migration func() error) error {
+	var applied bool
+	if err := db.conn.QueryRow("SELECT EXISTS(SELECT 1 FROM data_migrations WHERE name = ?)", name).Scan(&applied); err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+
+	if err := migration(); err != nil {
+		return err
+	}
+
+	_, err := db.conn.Exec("INSERT INTO data_migrations (name, applied_at) VALUES (?, CURRENT_TIMESTAMP)", name)
+	return err
+}
+
 // Close closes the database connection
 func (db *DB) Close() error {
 	return db.conn.Close()
@@ -56,10 +82,6 @@ func (db *DB) migrate() error {
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			name TEXT NOT NULL,
 			description TEXT,
-			url1 TEXT,
-			url2 TEXT,
-			url3 TEXT,
-			discord TEXT,
 			directory TEXT,
 			deadline DATETIME,
 			status TEXT DEFAULT 'active',
@@ -78,6 +100,16 @@ func (db *DB) migrate() error {
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
 		)`,
+		`CREATE INDEX IF NOT EXISTS idx_time_blocks_start_time ON time_blocks (start_time)`,
+		`CREATE TABLE IF NOT EXISTS time_block_segments (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			time_block_id INTEGER NOT NULL,
+			start_time DATETIME NOT NULL,
+			end_time DATETIME,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (time_block_id) REFERENCES time_blocks (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_time_block_segments_time_block_id ON time_block_segments (time_block_id)`,
 		`CREATE TABLE IF NOT EXISTS settings (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			theme TEXT DEFAULT 'light',
@@ -85,6 +117,203 @@ func (db *DB) migrate() error {
 			timeformat TEXT DEFAULT '24'
 		)`,
 		`INSERT OR IGNORE INTO settings (id, theme, language, timeformat) VALUES (1, 'light', 'en', '24')`,
+		`CREATE TABLE IF NOT EXISTS timer_state (
+			id INTEGER PRIMARY KEY,
+			status TEXT DEFAULT 'idle',
+			time_block_id INTEGER,
+			project_id INTEGER,
+			start_time DATETIME,
+			paused_at DATETIME,
+			paused_seconds INTEGER DEFAULT 0,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (time_block_id) REFERENCES time_blocks (id) ON DELETE SET NULL
+		)`,
+		`INSERT OR IGNORE INTO timer_state (id, status, paused_seconds) VALUES (1, 'idle', 0)`,
+		`CREATE TABLE IF NOT EXISTS pomodoro_state (
+			id INTEGER PRIMARY KEY,
+			phase TEXT DEFAULT 'idle',
+			project_id INTEGER,
+			time_block_id INTEGER,
+			description TEXT,
+			phase_started_at DATETIME,
+			completed_cycles INTEGER DEFAULT 0,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`INSERT OR IGNORE INTO pomodoro_state (id, phase, completed_cycles) VALUES (1, 'idle', 0)`,
+		`CREATE TABLE IF NOT EXISTS pomodoro_cycles (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			time_block_id INTEGER NOT NULL,
+			project_id INTEGER NOT NULL,
+			started_at DATETIME NOT NULL,
+			completed_at DATETIME NOT NULL,
+			FOREIGN KEY (time_block_id) REFERENCES time_blocks (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_pomodoro_cycles_completed_at ON pomodoro_cycles (completed_at)`,
+		`CREATE TABLE IF NOT EXISTS tags (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			color TEXT DEFAULT '#1098F7',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS time_block_tags (
+			time_block_id INTEGER NOT NULL,
+			tag_id INTEGER NOT NULL,
+			PRIMARY KEY (time_block_id, tag_id),
+			FOREIGN KEY (time_block_id) REFERENCES time_blocks (id) ON DELETE CASCADE,
+			FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_time_block_tags_tag_id ON time_block_tags (tag_id)`,
+		`CREATE TABLE IF NOT EXISTS project_tags (
+			project_id INTEGER NOT NULL,
+			tag_id INTEGER NOT NULL,
+			PRIMARY KEY (project_id, tag_id),
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+			FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_project_tags_tag_id ON project_tags (tag_id)`,
+		`CREATE TABLE IF NOT EXISTS clients (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			contact TEXT,
+			default_rate REAL DEFAULT 0,
+			currency TEXT DEFAULT '',
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS tasks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			project_id INTEGER NOT NULL,
+			parent_id INTEGER,
+			name TEXT NOT NULL,
+			description TEXT,
+			done BOOLEAN DEFAULT FALSE,
+			"order" INTEGER DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+			FOREIGN KEY (parent_id) REFERENCES tasks (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id)`,
+		`CREATE TABLE IF NOT EXISTS project_links (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			project_id INTEGER NOT NULL,
+			label TEXT DEFAULT '',
+			url TEXT NOT NULL,
+			kind TEXT DEFAULT 'web',
+			"order" INTEGER DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_project_links_project_id ON project_links (project_id)`,
+		`CREATE TABLE IF NOT EXISTS custom_fields (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			type TEXT NOT NULL,
+			options TEXT DEFAULT '[]',
+			"order" INTEGER DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS project_field_values (
+			project_id INTEGER NOT NULL,
+			field_id INTEGER NOT NULL,
+			value TEXT NOT NULL,
+			PRIMARY KEY (project_id, field_id),
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+			FOREIGN KEY (field_id) REFERENCES custom_fields (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_project_field_values_field_id ON project_field_values (field_id, value)`,
+		`CREATE TABLE IF NOT EXISTS time_block_field_values (
+			time_block_id INTEGER NOT NULL,
+			field_id INTEGER NOT NULL,
+			value TEXT NOT NULL,
+			PRIMARY KEY (time_block_id, field_id),
+			FOREIGN KEY (time_block_id) REFERENCES time_blocks (id) ON DELETE CASCADE,
+			FOREIGN KEY (field_id) REFERENCES custom_fields (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_time_block_field_values_field_id ON time_block_field_values (field_id, value)`,
+		`CREATE TABLE IF NOT EXISTS milestones (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			project_id INTEGER NOT NULL,
+			title TEXT NOT NULL,
+			due_date DATETIME NOT NULL,
+			done BOOLEAN DEFAULT FALSE,
+			estimated_hours REAL,
+			reminded BOOLEAN DEFAULT FALSE,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_milestones_project_id ON milestones (project_id)`,
+		`CREATE TABLE IF NOT EXISTS project_templates (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			description TEXT,
+			directory TEXT,
+			billable BOOLEAN DEFAULT FALSE,
+			hourly_rate REAL DEFAULT 0,
+			client_id INTEGER,
+			estimated_hours REAL,
+			links TEXT DEFAULT '[]',
+			tasks TEXT DEFAULT '[]',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (client_id) REFERENCES clients (id) ON DELETE SET NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS project_template_tags (
+			template_id INTEGER NOT NULL,
+			tag_id INTEGER NOT NULL,
+			PRIMARY KEY (template_id, tag_id),
+			FOREIGN KEY (template_id) REFERENCES project_templates (id) ON DELETE CASCADE,
+			FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS project_template_field_values (
+			template_id INTEGER NOT NULL,
+			field_id INTEGER NOT NULL,
+			value TEXT NOT NULL,
+			PRIMARY KEY (template_id, field_id),
+			FOREIGN KEY (template_id) REFERENCES project_templates (id) ON DELETE CASCADE,
+			FOREIGN KEY (field_id) REFERENCES custom_fields (id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS command_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			label TEXT NOT NULL,
+			undone BOOLEAN DEFAULT FALSE,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS command_log_entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			command_id INTEGER NOT NULL,
+			statement TEXT NOT NULL,
+			FOREIGN KEY (command_id) REFERENCES command_log (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_command_log_entries_command_id ON command_log_entries (command_id)`,
+		`CREATE TABLE IF NOT EXISTS command_log_state (
+			id INTEGER PRIMARY KEY,
+			recording_id INTEGER,
+			schema_hash TEXT
+		)`,
+		`INSERT OR IGNORE INTO command_log_state (id) VALUES (1)`,
+		`CREATE TABLE IF NOT EXISTS time_block_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			time_block_id INTEGER NOT NULL,
+			action TEXT NOT NULL,
+			before_values TEXT,
+			after_values TEXT,
+			changed_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_time_block_history_time_block_id ON time_block_history (time_block_id)`,
+		`CREATE TRIGGER IF NOT EXISTS time_block_history_no_update BEFORE UPDATE ON time_block_history
+		BEGIN SELECT RAISE(ABORT, 'time block history is append-only'); END`,
+		`CREATE TRIGGER IF NOT EXISTS time_block_history_no_delete BEFORE DELETE ON time_block_history
+		BEGIN SELECT RAISE(ABORT, 'time block history is append-only'); END`,
+		`CREATE TABLE IF NOT EXISTS data_migrations (
+			name TEXT PRIMARY KEY,
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
 	}
 
 	for _, query := range queries {
@@ -108,11 +337,6 @@ func (db *DB) migrate() error {
 		return err
 	}
 
-	// Handle discord column migration for projects
-	if err := db.addProjectDiscordColumn(); err != nil {
-		return err
-	}
-
 	// Handle directory column migration for projects
 	if err := db.addProjectDirectoryColumn(); err != nil {
 		return err
@@ -123,8 +347,78 @@ func (db *DB) migrate() error {
 		return err
 	}
 
-	// Handle url to url1, and add url2, url3 migrations
-	if err := db.addProjectURLsColumns(); err != nil {
+	// Handle allow_parallel_timers column migration safely
+	if err := db.addAllowParallelTimersColumn(); err != nil {
+		return err
+	}
+
+	// Handle idle_threshold_minutes column migration safely
+	if err := db.addIdleThresholdColumn(); err != nil {
+		return err
+	}
+
+	// Handle pomodoro columns migration safely
+	if err := db.addPomodoroColumns(); err != nil {
+		return err
+	}
+
+	// Handle heartbeat column migration for time blocks
+	if err := db.addTimeBlockHeartbeatColumn(); err != nil {
+		return err
+	}
+
+	// Handle duration override column migration for time blocks
+	if err := db.addTimeBlockDurationOverrideColumn(); err != nil {
+		return err
+	}
+
+	// Handle billable flag, hourly rate and currency columns migration safely
+	if err := db.addBillingColumns(); err != nil {
+		return err
+	}
+
+	// Handle client column migration for projects
+	if err := db.addProjectClientColumn(); err != nil {
+		return err
+	}
+
+	// Handle task column migration for time blocks
+	if err := db.addTimeBlockTaskColumn(); err != nil {
+		return err
+	}
+
+	// Handle estimate and budget alert columns migration safely
+	if err := db.addBudgetColumns(); err != nil {
+		return err
+	}
+
+	// Handle archive columns migration for projects
+	if err := db.addProjectArchiveColumns(); err != nil {
+		return err
+	}
+
+	// Handle soft delete columns and trash retention setting migration
+	if err := db.addSoftDeleteColumns(); err != nil {
+		return err
+	}
+
+	// Handle deadline reminder flag and setting migration
+	if err := db.addDeadlineReminderColumns(); err != nil {
+		return err
+	}
+
+	// Handle moving the fixed url and discord columns of projects into project links
+	if err := db.moveProjectURLsToLinks(); err != nil {
+		return err
+	}
+
+	// Handle time block history triggers, rebuilt so they capture every current column
+	if err := db.setupTimeBlockHistory(); err != nil {
+		return err
+	}
+
+	// Keep last: the command log is cleared when a migration changed the schema above
+	if err := db.setupCommandLog(); err != nil {
 		return err
 	}
 
@@ -167,41 +461,131 @@ func (db *DB) addProjectDirectoryColumn() error {
 	return nil
 }
 
-// addTimeFormatColumn adds the timeformat column if it doesn't exist
-func (db *DB) addTimeFormatColumn() error {
-	// Check if timeformat column exists
-	query := "PRAGMA table_info(settings)"
-	rows, err := db.conn.Query(query)
+// addTimeBlockHeartbeatColumn adds the heartbeat_at column to time_blocks if it doesn't exist
+func (db *DB) addTimeBlockHeartbeatColumn() error {
+	existing, err := db.tableColumns("time_blocks")
 	if err != nil {
 		return err
 	}
-	defer rows.Close()
 
-	hasTimeFormat := false
-	for rows.Next() {
-		var cid int
-		var name, dataType string
-		var notNull, dfltValue, pk interface{}
+	if !existing["heartbeat_at"] {
+		if _, err := db.conn.Exec("ALTER TABLE time_blocks ADD COLUMN heartbeat_at DATETIME"); err != nil {
+			return err
+		}
+	}
 
-		if err := rows.Scan(&cid, &name, &dataType, &notNull, &dfltValue, &pk); err != nil {
+	return nil
+}
+
+// addTimeBlockDurationOverrideColumn adds the duration_override column to time_blocks if it doesn't exist
+func (db *DB) addTimeBlockDurationOverrideColumn() error {
+	existing, err := db.tableColumns("time_blocks")
+	if err != nil {
+		return err
+	}
+
+	if !existing["duration_override"] {
+		if _, err := db.conn.Exec("ALTER TABLE time_blocks ADD COLUMN duration_override BOOLEAN DEFAULT FALSE"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addBillingColumns adds the billable flag and hourly rate to projects and time blocks, and the currency setting
+func (db *DB) addBillingColumns() error {
+	columns := []struct {
+		table      string
+		name       string
+		definition string
+	}{
+		{"projects", "billable", "BOOLEAN DEFAULT FALSE"},
+		{"projects", "hourly_rate", "REAL DEFAULT 0"},
+		{"time_blocks", "billable", "BOOLEAN"},
+		{"time_blocks", "hourly_rate", "REAL"},
+		{"settings", "currency", "TEXT DEFAULT 'USD'"},
+	}
+
+	for _, column := range columns {
+		existing, err := db.tableColumns(column.table)
+		if err != nil {
+			return err
+		}
+		if existing[column.name] {
 			continue
 		}
 
-		if name == "timeformat" {
-			hasTimeFormat = true
-			break
+		_, err = db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.name, column.definition))
+		if err != nil {
+			return err
 		}
 	}
 
-	// Add column if it doesn't exist
-	if !hasTimeFormat {
-		_, err := db.conn.Exec("ALTER TABLE settings ADD COLUMN timeformat TEXT DEFAULT '24'")
+	return nil
+}
+
+// addProjectClientColumn adds the client_id column to projects if it doesn't exist
+func (db *DB) addProjectClientColumn() error {
+	existing, err := db.tableColumns("projects")
+	if err != nil {
+		return err
+	}
+
+	if !existing["client_id"] {
+		_, err := db.conn.Exec("ALTER TABLE projects ADD COLUMN client_id INTEGER REFERENCES clients (id) ON DELETE SET NULL")
 		if err != nil {
 			return err
 		}
+	}
 
-		// Update existing record to have timeformat
-		_, err = db.conn.Exec("UPDATE settings SET timeformat = '24' WHERE id = 1 AND timeformat IS NULL")
+	_, err = db.conn.Exec("CREATE INDEX IF NOT EXISTS idx_projects_client_id ON projects (client_id)")
+	return err
+}
+
+// addTimeBlockTaskColumn adds the task_id column to time_blocks if it doesn't exist
+func (db *DB) addTimeBlockTaskColumn() error {
+	existing, err := db.tableColumns("time_blocks")
+	if err != nil {
+		return err
+	}
+
+	if !existing["task_id"] {
+		_, err := db.conn.Exec("ALTER TABLE time_blocks ADD COLUMN task_id INTEGER REFERENCES tasks (id) ON DELETE SET NULL")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = db.conn.Exec("CREATE INDEX IF NOT EXISTS idx_time_blocks_task_id ON time_blocks (task_id)")
+	return err
+}
+
+// addBudgetColumns adds hour budgets to projects and tasks, the flag recording that their alert was sent,
+// and the alert threshold setting
+func (db *DB) addBudgetColumns() error {
+	columns := []struct {
+		table      string
+		name       string
+		definition string
+	}{
+		{"projects", "estimated_hours", "REAL"},
+		{"projects", "budget_alerted", "BOOLEAN DEFAULT FALSE"},
+		{"tasks", "estimated_hours", "REAL"},
+		{"tasks", "budget_alerted", "BOOLEAN DEFAULT FALSE"},
+		{"settings", "budget_alert_threshold", "INTEGER DEFAULT 80"},
+	}
+
+	for _, column := range columns {
+		existing, err := db.tableColumns(column.table)
+		if err != nil {
+			return err
+		}
+		if existing[column.name] {
+			continue
+		}
+
+		_, err = db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.name, column.definition))
 		if err != nil {
 			return err
 		}
@@ -210,71 +594,425 @@ func (db *DB) addTimeFormatColumn() error {
 	return nil
 }
 
-// addProjectURLsColumns handles transitioning from 'url' to 'url1', 'url2', 'url3'
-func (db *DB) addProjectURLsColumns() error {
-	query := "PRAGMA table_info(projects)"
-	rows, err := db.conn.Query(query)
+// addProjectArchiveColumns adds the archive time and the status to restore to projects if they don't exist
+func (db *DB) addProjectArchiveColumns() error {
+	existing, err := db.tableColumns("projects")
 	if err != nil {
 		return err
 	}
-	defer rows.Close()
 
-	hasURL := false
-	hasURL1 := false
-	hasURL2 := false
-	hasURL3 := false
+	if !existing["archived_at"] {
+		if _, err := db.conn.Exec("ALTER TABLE projects ADD COLUMN archived_at DATETIME"); err != nil {
+			return err
+		}
+	}
+	if !existing["status_before_archive"] {
+		if _, err := db.conn.Exec("ALTER TABLE projects ADD COLUMN status_before_archive TEXT"); err != nil {
+			return err
+		}
+	}
 
-	for rows.Next() {
-		var cid int
-		var name, dataType string
-		var notNull, dfltValue, pk interface{}
+	return nil
+}
 
-		if err := rows.Scan(&cid, &name, &dataType, &notNull, &dfltValue, &pk); err != nil {
+// addSoftDeleteColumns adds the trash timestamps to projects and time blocks and the trash retention setting
+func (db *DB) addSoftDeleteColumns() error {
+	columns := []struct {
+		table      string
+		name       string
+		definition string
+	}{
+		{"projects", "deleted_at", "DATETIME"},
+		{"time_blocks", "deleted_at", "DATETIME"},
+		{"settings", "trash_retention_days", "INTEGER DEFAULT 30"},
+	}
+
+	for _, column := range columns {
+		existing, err := db.tableColumns(column.table)
+		if err != nil {
+			return err
+		}
+		if existing[column.name] {
 			continue
 		}
 
-		switch name {
-		case "url":
-			hasURL = true
-		case "url1":
-			hasURL1 = true
-		case "url2":
-			hasURL2 = true
-		case "url3":
-			hasURL3 = true
+		_, err = db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.name, column.definition))
+		if err != nil {
+			return err
 		}
 	}
 
-	// Rename 'url' to 'url1' if 'url' exists but 'url1' does not
-	if hasURL && !hasURL1 {
-		_, err := db.conn.Exec("ALTER TABLE projects RENAME COLUMN url TO url1")
+	_, err := db.conn.Exec("CREATE INDEX IF NOT EXISTS idx_time_blocks_deleted_at ON time_blocks (deleted_at)")
+	return err
+}
+
+// addDeadlineReminderColumns adds the flag recording that a project's deadline reminder was sent and the
+// setting for how many days ahead deadlines are announced
+func (db *DB) addDeadlineReminderColumns() error {
+	columns := []struct {
+		table      string
+		name       string
+		definition string
+	}{
+		{"projects", "deadline_reminded", "BOOLEAN DEFAULT FALSE"},
+		{"settings", "deadline_reminder_days", "INTEGER DEFAULT 1"},
+	}
+
+	for _, column := range columns {
+		existing, err := db.tableColumns(column.table)
 		if err != nil {
-			// If SQLite version < 3.25, RENAME COLUMN might fail. In that case, add url1 and update.
-			_, errAdd := db.conn.Exec("ALTER TABLE projects ADD COLUMN url1 TEXT")
-			if errAdd != nil {
-				return errAdd
-			}
-			_, errUpd := db.conn.Exec("UPDATE projects SET url1 = url")
-			if errUpd != nil {
-				return errUpd
-			}
+			return err
 		}
-	} else if !hasURL1 && !hasURL {
-		_, err := db.conn.Exec("ALTER TABLE projects ADD COLUMN url1 TEXT")
+		if existing[column.name] {
+			continue
+		}
+
+		_, err = db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.name, column.definition))
 		if err != nil {
 			return err
 		}
 	}
 
-	if !hasURL2 {
-		_, err := db.conn.Exec("ALTER TABLE projects ADD COLUMN url2 TEXT")
+	return nil
+}
+
+// moveProjectURLsToLinks copies the legacy url, url1, url2, url3 and discord columns of projects into
+// project_links, keeping their order, then drops the columns
+func (db *DB) moveProjectURLsToLinks() error {
+	existing, err := db.tableColumns("projects")
+	if err != nil {
+		return err
+	}
+
+	legacy := []struct {
+		column string
+		label  string
+		kind   string
+		order  int
+	}{
+		{"url", "", "web", 0},
+		{"url1", "", "web", 0},
+		{"url2", "", "web", 1},
+		{"url3", "", "web", 2},
+		{"discord", "Discord", "discord", 3},
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var dropped []string
+	for _, link := range legacy {
+		if !existing[link.column] {
+			continue
+		}
+		dropped = append(dropped, link.column)
+		// An old url column only survives next to url1 when its rename failed, and then url1 holds a copy
+		if link.column == "url" && existing["url1"] {
+			continue
+		}
+
+		query := fmt.Sprintf(`
+			INSERT INTO project_links (project_id, label, url, kind, "order", created_at, updated_at)
+			SELECT id, ?, TRIM(%[1]s), ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+			FROM projects WHERE TRIM(COALESCE(%[1]s, '')) != ''
+		`, link.column)
+		if _, err := tx.Exec(query, link.label, link.kind, link.order); err != nil {
+			return err
+		}
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	// The command log triggers name every column of projects and would block dropping them; they are
+	// recreated once migrations are done
+	for _, event := range []string{"insert", "update", "delete"} {
+		if _, err := tx.Exec("DROP TRIGGER IF EXISTS command_log_projects_" + event); err != nil {
+			return err
+		}
+	}
+	for _, column := range dropped {
+		if _, err := tx.Exec("ALTER TABLE projects DROP COLUMN " + column); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// commandLogTables are the tables whose changes are recorded in the command log for undo and redo.
+// Timer and Pomodoro state is left out: it follows the clock rather than user edits. Completed Pomodoro
+// cycles are logged so that an edit deleting a block, like a merge, brings its cycles back on undo.
+var commandLogTables = []string{
+	"projects", "time_blocks", "time_block_segments", "tags", "time_block_tags", "project_tags", "clients", "tasks",
+	"project_links", "custom_fields", "project_field_values", "time_block_field_values",
+	"project_templates", "project_template_tags", "project_template_field_values", "milestones", "pomodoro_cycles",
+	"settings",
+}
+
+// commandLogDependents lists the columns of other tables that point at the rows of a table. Undoing the insert of
+// a row must not orphan the rows that came to depend on it outside the command log, like the time blocks the
+// timer started on a new project, so the revert leaves a row that is still referenced alone.
+var commandLogDependents = map[string][]string{
+	"projects": {
+		"time_blocks.project_id", "tasks.project_id", "project_tags.project_id", "project_links.project_id",
+		"project_field_values.project_id", "milestones.project_id", "pomodoro_cycles.project_id",
+		"timer_state.project_id", "pomodoro_state.project_id",
+	},
+	"time_blocks": {
+		"time_block_segments.time_block_id", "time_block_tags.time_block_id", "time_block_field_values.time_block_id",
+		"pomodoro_cycles.time_block_id", "timer_state.time_block_id", "pomodoro_state.time_block_id",
+	},
+	"tasks":             {"tasks.parent_id", "time_blocks.task_id"},
+	"tags":              {"time_block_tags.tag_id", "project_tags.tag_id", "project_template_tags.tag_id"},
+	"clients":           {"projects.client_id", "project_templates.client_id"},
+	"custom_fields":     {"project_field_values.field_id", "time_block_field_values.field_id", "project_template_field_values.field_id"},
+	"project_templates": {"project_template_tags.template_id", "project_template_field_values.template_id"},
+}
+
+// bookkeepingColumns are columns that change on their own, like heartbeats; an update touching only these
+// is neither recorded in the command log nor in the time block history
+var bookkeepingColumns = map[string]bool{
+	"updated_at":        true,
+	"heartbeat_at":      true,
+	"budget_alerted":    true,
+	"deadline_reminded": true,
+	"reminded":          true,
+}
+
+// setupCommandLog clears the undo history when the schema changed, since its recorded statements name the old
+// columns, and recreates the triggers that record the inverse of every change made while a command is recording
+func (db *DB) setupCommandLog() error {
+	rows, err := db.conn.Query("SELECT name, COALESCE(sql, '') FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	for rows.Next() {
+		var name, definition string
+		if err := rows.Scan(&name, &definition); err != nil {
+			rows.Close()
+			return err
+		}
+		hash.Write([]byte(name + "\n" + definition + "\n"))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	schemaHash := hex.EncodeToString(hash.Sum(nil))
+
+	var stored sql.NullString
+	if err := db.conn.QueryRow("SELECT schema_hash FROM command_log_state WHERE id = 1").Scan(&stored); err != nil {
+		return err
+	}
+	if stored.String != schemaHash {
+		for _, query := range []string{"DELETE FROM command_log_entries", "DELETE FROM command_log"} {
+			if _, err := db.conn.Exec(query); err != nil {
+				return err
+			}
+		}
+	}
+
+	// A crash while recording must not leave every later change recorded
+	if _, err := db.conn.Exec("UPDATE command_log_state SET recording_id = NULL, schema_hash = ? WHERE id = 1", schemaHash); err != nil {
+		return err
+	}
+
+	for _, table := range commandLogTables {
+		columns, err := db.tableColumnNames(table)
 		if err != nil {
 			return err
 		}
+		for _, query := range commandLogTriggers(table, columns) {
+			if _, err := db.conn.Exec(query); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// commandLogTriggers builds the statements that recreate the insert, update and delete triggers of a table.
+// Each trigger stores the SQL that reverts the change, keyed by rowid, under the command being recorded.
+// A revert only matches the row while it still holds the values the change left, so a row edited or removed
+// since then is left alone and the revert changes nothing, which undo reports as a conflict. The same goes for
+// deleting an inserted row that other rows now reference.
+func commandLogTriggers(table string, columns []string) []string {
+	const recording = "(SELECT recording_id FROM command_log_state WHERE id = 1) IS NOT NULL"
+	record := func(statement string) string {
+		return "INSERT INTO command_log_entries (command_id, statement) SELECT recording_id, " + statement +
+			" FROM command_log_state WHERE id = 1;"
+	}
+
+	// inserted matches every column of a new row and updated the columns an update changed; bookkeeping
+	// columns move on their own and are not compared
+	var changed, restore, names, values, inserted, updated []string
+	for _, column := range columns {
+		quoted := `"` + column + `"`
+		names = append(names, quoted)
+		values = append(values, "quote(OLD."+quoted+")")
+		restore = append(restore, fmt.Sprintf("CASE WHEN OLD.%[1]s IS NOT NEW.%[1]s THEN ', %[1]s = ' || quote(OLD.%[1]s) ELSE '' END", quoted))
+		if !bookkeepingColumns[column] {
+			changed = append(changed, fmt.Sprintf("OLD.%[1]s IS NOT NEW.%[1]s", quoted))
+			inserted = append(inserted, fmt.Sprintf(`' AND %[1]s IS ' || quote(NEW.%[1]s)`, quoted))
+			updated = append(updated, fmt.Sprintf("CASE WHEN OLD.%[1]s IS NOT NEW.%[1]s THEN ' AND %[1]s IS ' || quote(NEW.%[1]s) ELSE '' END", quoted))
+		}
+	}
+
+	for _, dependent := range commandLogDependents[table] {
+		child, column, _ := strings.Cut(dependent, ".")
+		inserted = append(inserted, fmt.Sprintf(`' AND NOT EXISTS (SELECT 1 FROM "%s" WHERE "%s" = ' || NEW.rowid || ')'`, child, column))
+	}
+
+	queries := []string{}
+	for _, event := range []string{"insert", "update", "delete"} {
+		queries = append(queries, fmt.Sprintf("DROP TRIGGER IF EXISTS command_log_%s_%s", table, event))
+	}
+
+	queries = append(queries,
+		fmt.Sprintf("CREATE TRIGGER command_log_%[1]s_insert AFTER INSERT ON %[1]s WHEN %[2]s BEGIN %[3]s END",
+			table, recording, record(fmt.Sprintf(`'DELETE FROM "%s" WHERE rowid = ' || NEW.rowid || %s`, table, strings.Join(inserted, " || ")))),
+		fmt.Sprintf("CREATE TRIGGER command_log_%[1]s_delete AFTER DELETE ON %[1]s WHEN %[2]s BEGIN %[3]s END",
+			table, recording, record(fmt.Sprintf(`'INSERT INTO "%s" (rowid, %s) VALUES (' || OLD.rowid || ', ' || %s || ')'`,
+				table, strings.Join(names, ", "), strings.Join(values, " || ', ' || ")))),
+	)
+	if len(changed) > 0 {
+		queries = append(queries, fmt.Sprintf("CREATE TRIGGER command_log_%[1]s_update AFTER UPDATE ON %[1]s WHEN %[2]s AND (%[3]s) BEGIN %[4]s END",
+			table, recording, strings.Join(changed, " OR "),
+			record(fmt.Sprintf(`'UPDATE "%s" SET ' || substr(%s, 3) || ' WHERE rowid = ' || OLD.rowid || %s`,
+				table, strings.Join(restore, " || "), strings.Join(updated, " || ")))))
+	}
+
+	return queries
+}
+
+// setupTimeBlockHistory recreates the triggers that append a snapshot of a time block before and after
+// every create, update, soft delete, restore and permanent removal to time_block_history
+func (db *DB) setupTimeBlockHistory() error {
+	columns, err := db.tableColumnNames("time_blocks")
+	if err != nil {
+		return err
+	}
+
+	snapshot := func(row string) string {
+		pairs := make([]string, 0, len(columns))
+		for _, column := range columns {
+			pairs = append(pairs, fmt.Sprintf(`'%s', %s."%s"`, column, row, column))
+		}
+		return "json_object(" + strings.Join(pairs, ", ") + ")"
+	}
+	var changed []string
+	for _, column := range columns {
+		if !bookkeepingColumns[column] {
+			changed = append(changed, fmt.Sprintf(`OLD."%[1]s" IS NOT NEW."%[1]s"`, column))
+		}
+	}
+
+	const insert = "INSERT INTO time_block_history (time_block_id, action, before_values, after_values, changed_at) VALUES"
+	queries := []string{
+		"DROP TRIGGER IF EXISTS time_block_history_insert",
+		"DROP TRIGGER IF EXISTS time_block_history_update",
+		"DROP TRIGGER IF EXISTS time_block_history_delete",
+		fmt.Sprintf("CREATE TRIGGER time_block_history_insert AFTER INSERT ON time_blocks BEGIN %s (NEW.id, 'create', NULL, %s, CURRENT_TIMESTAMP); END",
+			insert, snapshot("NEW")),
+		fmt.Sprintf(`CREATE TRIGGER time_block_history_update AFTER UPDATE ON time_blocks WHEN %s BEGIN %s (NEW.id,
+			CASE WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+			     WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'restore'
+			     ELSE 'update' END,
+			%s, %s, CURRENT_TIMESTAMP); END`,
+			strings.Join(changed, " OR "), insert, snapshot("OLD"), snapshot("NEW")),
+		fmt.Sprintf("CREATE TRIGGER time_block_history_delete AFTER DELETE ON time_blocks BEGIN %s (OLD.id, 'purge', %s, NULL, CURRENT_TIMESTAMP); END",
+			insert, snapshot("OLD")),
+	}
+
+	for _, query := range queries {
+		if _, err := db.conn.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tableColumns returns the names of the columns of a table
+func (db *DB) tableColumns(table string) (map[string]bool, error) {
+	names, err := db.tableColumnNames(table)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]bool{}
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	return existing, nil
+}
+
+// tableColumnNames returns the names of the columns of a table in their declared order
+func (db *DB) tableColumnNames(table string) ([]string, error) {
+	rows, err := db.conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var cid int
+		var name, dataType string
+		var notNull, dfltValue, pk interface{}
+
+		if err := rows.Scan(&cid, &name, &dataType, &notNull, &dfltValue, &pk); err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+// addTimeFormatColumn adds the timeformat column if it doesn't exist
+func (db *DB) addTimeFormatColumn() error {
+	// Check if timeformat column exists
+	query := "PRAGMA table_info(settings)"
+	rows, err := db.conn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	hasTimeFormat := false
+	for rows.Next() {
+		var cid int
+		var name, dataType string
+		var notNull, dfltValue, pk interface{}
+
+		if err := rows.Scan(&cid, &name, &dataType, &notNull, &dfltValue, &pk); err != nil {
+			continue
+		}
+
+		if name == "timeformat" {
+			hasTimeFormat = true
+			break
+		}
 	}
 
-	if !hasURL3 {
-		_, err := db.conn.Exec("ALTER TABLE projects ADD COLUMN url3 TEXT")
+	// Add column if it doesn't exist
+	if !hasTimeFormat {
+		_, err := db.conn.Exec("ALTER TABLE settings ADD COLUMN timeformat TEXT DEFAULT '24'")
+		if err != nil {
+			return err
+		}
+
+		// Update existing record to have timeformat
+		_, err = db.conn.Exec("UPDATE settings SET timeformat = '24' WHERE id = 1 AND timeformat IS NULL")
 		if err != nil {
 			return err
 		}
@@ -369,33 +1107,62 @@ func (db *DB) addTrelloURLColumn() error {
 	return nil
 }
 
-// addProjectDiscordColumn adds the discord column to projects if it doesn't exist
-func (db *DB) addProjectDiscordColumn() error {
-	query := "PRAGMA table_info(projects)"
-	rows, err := db.conn.Query(query)
+// addAllowParallelTimersColumn adds the allow_parallel_timers column if it doesn't exist
+func (db *DB) addAllowParallelTimersColumn() error {
+	existing, err := db.tableColumns("settings")
 	if err != nil {
 		return err
 	}
-	defer rows.Close()
-
-	hasDiscord := false
-	for rows.Next() {
-		var cid int
-		var name, dataType string
-		var notNull, dfltValue, pk interface{}
 
-		if err := rows.Scan(&cid, &name, &dataType, &notNull, &dfltValue, &pk); err != nil {
-			continue
+	// Single running timer stays the default for existing installs
+	if !existing["allow_parallel_timers"] {
+		if _, err := db.conn.Exec("ALTER TABLE settings ADD COLUMN allow_parallel_timers BOOLEAN DEFAULT FALSE"); err != nil {
+			return err
 		}
+	}
 
-		if name == "discord" {
-			hasDiscord = true
-			break
+	return nil
+}
+
+// addIdleThresholdColumn adds the idle_threshold_minutes column if it doesn't exist
+func (db *DB) addIdleThresholdColumn() error {
+	existing, err := db.tableColumns("settings")
+	if err != nil {
+		return err
+	}
+
+	if !existing["idle_threshold_minutes"] {
+		if _, err := db.conn.Exec("ALTER TABLE settings ADD COLUMN idle_threshold_minutes INTEGER DEFAULT 15"); err != nil {
+			return err
 		}
 	}
 
-	if !hasDiscord {
-		_, err := db.conn.Exec("ALTER TABLE projects ADD COLUMN discord TEXT DEFAULT ''")
+	return nil
+}
+
+// addPomodoroColumns adds the pomodoro interval columns to settings if they don't exist
+func (db *DB) addPomodoroColumns() error {
+	columns := []struct {
+		table      string
+		name       string
+		definition string
+	}{
+		{"settings", "pomodoro_work_minutes", "INTEGER DEFAULT 25"},
+		{"settings", "pomodoro_short_break_minutes", "INTEGER DEFAULT 5"},
+		{"settings", "pomodoro_long_break_minutes", "INTEGER DEFAULT 15"},
+		{"settings", "pomodoro_long_break_every", "INTEGER DEFAULT 4"},
+	}
+
+	for _, column := range columns {
+		existing, err := db.tableColumns(column.table)
+		if err != nil {
+			return err
+		}
+		if existing[column.name] {
+			continue
+		}
+
+		_, err = db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.name, column.definition))
 		if err != nil {
 			return err
 		}
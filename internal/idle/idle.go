@@ -0,0 +1,54 @@
+package idle
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrUnsupported is returned when no idle source is available on this system
+var ErrUnsupported = errors.New("idle detection is not supported on this system")
+
+// Source reports how long the user has been idle
+type Source interface {
+	IdleTime() (time.Duration, error)
+}
+
+// Fake is a Source whose idle time is set by hand, used for tests
+type Fake struct {
+	mu   sync.Mutex
+	idle time.Duration
+	err  error
+}
+
+// NewFake creates a fake idle source reporting no idle time
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+// SetIdle sets the idle time reported by the fake source
+func (f *Fake) SetIdle(idle time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.idle = idle
+}
+
+// SetError makes the fake source fail with err; nil clears it
+func (f *Fake) SetError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.err = err
+}
+
+// IdleTime returns the configured idle time
+func (f *Fake) IdleTime() (time.Duration, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return 0, f.err
+	}
+	return f.idle, nil
+}
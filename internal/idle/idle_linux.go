@@ -0,0 +1,83 @@
+//go:build linux
+// +build linux
+
+package idle
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// linuxSource queries the desktop for the user idle time.
+// Wayland compositors only expose it over D-Bus, so those are tried before X11.
+type linuxSource struct{}
+
+// NewSource returns the idle source for this platform
+func NewSource() Source {
+	return linuxSource{}
+}
+
+// IdleTime returns the time since the last user input
+func (linuxSource) IdleTime() (time.Duration, error) {
+	if idle, err := mutterIdleTime(); err == nil {
+		return idle, nil
+	}
+	if idle, err := screenSaverIdleTime(); err == nil {
+		return idle, nil
+	}
+	if idle, err := xprintidleIdleTime(); err == nil {
+		return idle, nil
+	}
+	return 0, ErrUnsupported
+}
+
+// mutterIdleTime asks GNOME (X11 and Wayland) for the idle time
+func mutterIdleTime() (time.Duration, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return 0, err
+	}
+
+	var ms uint64
+	obj := conn.Object("org.gnome.Mutter.IdleMonitor", "/org/gnome/Mutter/IdleMonitor/Core")
+	if err := obj.Call("org.gnome.Mutter.IdleMonitor.GetIdletime", 0).Store(&ms); err != nil {
+		return 0, err
+	}
+
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// screenSaverIdleTime asks KDE and other freedesktop screensavers for the idle time
+func screenSaverIdleTime() (time.Duration, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return 0, err
+	}
+
+	var ms uint32
+	obj := conn.Object("org.freedesktop.ScreenSaver", "/org/freedesktop/ScreenSaver")
+	if err := obj.Call("org.freedesktop.ScreenSaver.GetSessionIdleTime", 0).Store(&ms); err != nil {
+		return 0, err
+	}
+
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// xprintidleIdleTime falls back to the xprintidle tool on plain X11 sessions
+func xprintidleIdleTime() (time.Duration, error) {
+	out, err := exec.Command("xprintidle").Output()
+	if err != nil {
+		return 0, err
+	}
+
+	ms, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(ms) * time.Millisecond, nil
+}
@@ -0,0 +1,19 @@
+//go:build !linux && !windows
+// +build !linux,!windows
+
+package idle
+
+import "time"
+
+// unsupportedSource is used on platforms without an idle implementation
+type unsupportedSource struct{}
+
+// NewSource returns the idle source for this platform
+func NewSource() Source {
+	return unsupportedSource{}
+}
+
+// IdleTime always fails with ErrUnsupported
+func (unsupportedSource) IdleTime() (time.Duration, error) {
+	return 0, ErrUnsupported
+}
@@ -0,0 +1,44 @@
+//go:build windows
+// +build windows
+
+package idle
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	user32               = syscall.NewLazyDLL("user32.dll")
+	kernel32             = syscall.NewLazyDLL("kernel32.dll")
+	procGetLastInputInfo = user32.NewProc("GetLastInputInfo")
+	procGetTickCount     = kernel32.NewProc("GetTickCount")
+)
+
+// lastInputInfo mirrors the Win32 LASTINPUTINFO structure
+type lastInputInfo struct {
+	cbSize uint32
+	dwTime uint32
+}
+
+// windowsSource reads the idle time from GetLastInputInfo
+type windowsSource struct{}
+
+// NewSource returns the idle source for this platform
+func NewSource() Source {
+	return windowsSource{}
+}
+
+// IdleTime returns the time since the last user input
+func (windowsSource) IdleTime() (time.Duration, error) {
+	info := lastInputInfo{cbSize: uint32(unsafe.Sizeof(lastInputInfo{}))}
+	ret, _, err := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(&info)))
+	if ret == 0 {
+		return 0, err
+	}
+
+	// Both values are tick counts in milliseconds that wrap together
+	now, _, _ := procGetTickCount.Call()
+	return time.Duration(uint32(now)-info.dwTime) * time.Millisecond, nil
+}
@@ -0,0 +1,22 @@
+package models
+
+// ProjectBillable represents the billable time and amount of one project in a date range
+type ProjectBillable struct {
+	ProjectID        int     `json:"project_id"`
+	ProjectName      string  `json:"project_name"`
+	ClientID         *int    `json:"client_id"`
+	Currency         string  `json:"currency"`          // Client currency, or the settings currency
+	Duration         int     `json:"duration"`          // Tracked seconds, billable or not
+	BillableDuration int     `json:"billable_duration"` // Seconds of billable blocks
+	Amount           float64 `json:"amount"`            // Billable amount rounded to cents
+}
+
+// BillableSummary represents the billable amounts of a date range
+type BillableSummary struct {
+	Currency          string             `json:"currency"`
+	Duration          int                `json:"duration"`
+	BillableDuration  int                `json:"billable_duration"`
+	Amount            float64            `json:"amount"`              // Total of the projects billed in Currency
+	AmountsByCurrency map[string]float64 `json:"amounts_by_currency"` // Totals per currency when clients bill in other currencies
+	Projects          []ProjectBillable  `json:"projects"`
+}
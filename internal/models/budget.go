@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// BurnDown represents how much of an hour budget a project or task has used
+type BurnDown struct {
+	ProjectID           int        `json:"project_id"`
+	TaskID              *int       `json:"task_id"` // Nil for a project budget
+	Name                string     `json:"name"`
+	EstimatedHours      float64    `json:"estimated_hours"`
+	ConsumedHours       float64    `json:"consumed_hours"`       // Tracked hours, including a running block
+	RemainingHours      float64    `json:"remaining_hours"`      // Negative once the budget is exceeded
+	PercentBurned       float64    `json:"percent_burned"`       // Consumed hours as a percentage of the estimate, one decimal
+	DailyVelocity       float64    `json:"daily_velocity"`       // Hours tracked per day over the velocity window
+	VelocityDays        int        `json:"velocity_days"`        // Length of the velocity window in days
+	ProjectedCompletion *time.Time `json:"projected_completion"` // Nil when nothing is left or nothing was tracked recently
+}
+
+// BudgetAlert is emitted when a budget crosses the alert threshold
+type BudgetAlert struct {
+	Threshold int      `json:"threshold"` // Percentage from the settings
+	BurnDown  BurnDown `json:"burn_down"`
+}
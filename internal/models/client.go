@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+// Client represents who the work on a set of projects is done for
+type Client struct {
+	ID          int       `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Contact     *string   `json:"contact" db:"contact"`
+	DefaultRate float64   `json:"default_rate" db:"default_rate"` // Hourly rate for projects without their own rate
+	Currency    string    `json:"currency" db:"currency"`         // ISO 4217 code; empty uses the settings currency
+	Notes       *string   `json:"notes" db:"notes"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateClientRequest represents the request to create a new client
+type CreateClientRequest struct {
+	Name        string  `json:"name"`
+	Contact     *string `json:"contact"`
+	DefaultRate float64 `json:"default_rate"`
+	Currency    string  `json:"currency"`
+	Notes       *string `json:"notes"`
+}
+
+// UpdateClientRequest represents the request to update a client
+type UpdateClientRequest struct {
+	Name        *string  `json:"name"`
+	Contact     *string  `json:"contact"`
+	DefaultRate *float64 `json:"default_rate"`
+	Currency    *string  `json:"currency"`
+	Notes       *string  `json:"notes"`
+}
+
+// ClientTotal represents the time tracked for a client in a date range
+type ClientTotal struct {
+	ClientID     int    `json:"client_id"` // 0 for projects without a client
+	ClientName   string `json:"client_name"`
+	Duration     int    `json:"duration"` // Duration in seconds
+	BlockCount   int    `json:"block_count"`
+	ProjectCount int    `json:"project_count"`
+}
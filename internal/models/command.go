@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Command is a recorded data change that can be undone and redone
+type Command struct {
+	ID        int       `json:"id" db:"id"`
+	Label     string    `json:"label" db:"label"` // What the change did, e.g. "Delete project"
+	Undone    bool      `json:"undone" db:"undone"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// UndoState tells which commands undo and redo would apply next
+type UndoState struct {
+	Undo *Command `json:"undo"` // nil when there is nothing to undo
+	Redo *Command `json:"redo"` // nil when there is nothing to redo
+}
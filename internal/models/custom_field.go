@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// CustomFieldType is the kind of value a custom field holds
+type CustomFieldType string
+
+const (
+	FieldText   CustomFieldType = "text"
+	FieldNumber CustomFieldType = "number"
+	FieldDate   CustomFieldType = "date"   // Stored as YYYY-MM-DD
+	FieldSelect CustomFieldType = "select" // One of the field's options
+)
+
+// CustomField represents a user-defined field that projects and time blocks can carry a value for
+type CustomField struct {
+	ID        int             `json:"id" db:"id"`
+	Name      string          `json:"name" db:"name"`
+	Type      CustomFieldType `json:"type" db:"type"`
+	Options   []string        `json:"options" db:"options"` // Choices of a select field, empty for other types
+	Order     int             `json:"order" db:"order"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// CreateCustomFieldRequest represents the request to define a new custom field
+type CreateCustomFieldRequest struct {
+	Name    string          `json:"name"`
+	Type    CustomFieldType `json:"type"`
+	Options []string        `json:"options"`
+	Order   int             `json:"order"`
+}
+
+// UpdateCustomFieldRequest represents the request to update a custom field; its type cannot change
+type UpdateCustomFieldRequest struct {
+	Name    *string  `json:"name"`
+	Options []string `json:"options"` // Replaces the options of a select field when not nil
+	Order   *int     `json:"order"`
+}
+
+// CustomFieldTotal represents the time tracked under one value of a custom field in a date range
+type CustomFieldTotal struct {
+	Value      string `json:"value"`    // Empty for blocks without a value
+	Duration   int    `json:"duration"` // Duration in seconds
+	BlockCount int    `json:"block_count"`
+}
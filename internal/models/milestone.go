@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// Milestone represents a delivery date inside a project; a project can have any number of them
+type Milestone struct {
+	ID             int       `json:"id" db:"id"`
+	ProjectID      int       `json:"project_id" db:"project_id"`
+	Title          string    `json:"title" db:"title"`
+	DueDate        time.Time `json:"due_date" db:"due_date"`
+	Done           bool      `json:"done" db:"done"`
+	EstimatedHours *float64  `json:"estimated_hours" db:"estimated_hours"` // Hour budget, nil when the milestone has none
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateMilestoneRequest represents the request to add a milestone to a project
+type CreateMilestoneRequest struct {
+	ProjectID      int       `json:"project_id"`
+	Title          string    `json:"title"`
+	DueDate        time.Time `json:"due_date"`
+	EstimatedHours *float64  `json:"estimated_hours"`
+}
+
+// UpdateMilestoneRequest represents the request to update a milestone
+type UpdateMilestoneRequest struct {
+	Title          *string    `json:"title"`
+	DueDate        *time.Time `json:"due_date"`
+	Done           *bool      `json:"done"`
+	EstimatedHours *float64   `json:"estimated_hours"` // 0 removes the budget
+}
+
+// Deadline represents a date a project is due, either the project's own deadline or one of its milestones
+type Deadline struct {
+	ProjectID   int       `json:"project_id"`
+	ProjectName string    `json:"project_name"`
+	MilestoneID *int      `json:"milestone_id"` // Nil for the project's own deadline
+	Title       string    `json:"title"`        // The milestone title, or the project name for its own deadline
+	DueDate     time.Time `json:"due_date"`
+	Done        bool      `json:"done"` // The milestone is done or the project completed
+}
+
+// DeadlineReminder is emitted once for each open deadline that comes within the reminder window
+type DeadlineReminder struct {
+	DaysLeft int      `json:"days_left"` // 0 when the deadline is today
+	Deadline Deadline `json:"deadline"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// PomodoroPhase represents the current interval of a Pomodoro session
+type PomodoroPhase string
+
+const (
+	PomodoroIdle       PomodoroPhase = "idle"
+	PomodoroWork       PomodoroPhase = "work"
+	PomodoroShortBreak PomodoroPhase = "short_break"
+	PomodoroLongBreak  PomodoroPhase = "long_break"
+)
+
+// PomodoroState represents the persisted state of the Pomodoro session
+type PomodoroState struct {
+	Phase           PomodoroPhase `json:"phase" db:"phase"`
+	ProjectID       *int          `json:"project_id" db:"project_id"`
+	TimeBlockID     *int          `json:"time_block_id" db:"time_block_id"` // Block of the current work interval
+	Description     *string       `json:"description" db:"description"`
+	PhaseStartedAt  *time.Time    `json:"phase_started_at" db:"phase_started_at"`
+	CompletedCycles int           `json:"completed_cycles" db:"completed_cycles"` // Work intervals finished in this session
+	Remaining       int           `json:"remaining"`                              // Seconds left in the current phase, computed on read
+	CompletedToday  int           `json:"completed_today"`                        // Work intervals finished today, computed on read
+	UpdatedAt       time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// PomodoroCycle represents a completed work interval
+type PomodoroCycle struct {
+	ID          int       `json:"id" db:"id"`
+	TimeBlockID int       `json:"time_block_id" db:"time_block_id"`
+	ProjectID   int       `json:"project_id" db:"project_id"`
+	StartedAt   time.Time `json:"started_at" db:"started_at"`
+	CompletedAt time.Time `json:"completed_at" db:"completed_at"`
+}
+
+// PomodoroDayCount represents the number of completed work intervals on a day
+type PomodoroDayCount struct {
+	Date   string `json:"date"` // YYYY-MM-DD in local time
+	Cycles int    `json:"cycles"`
+}
+
+// StartPomodoroRequest represents the request to start a Pomodoro session
+type StartPomodoroRequest struct {
+	ProjectID   int     `json:"project_id"`
+	Description *string `json:"description"`
+}
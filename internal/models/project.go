@@ -11,48 +11,55 @@ const (
 	StatusActive    ProjectStatus = "active"
 	StatusCompleted ProjectStatus = "completed"
 	StatusPaused    ProjectStatus = "paused"
+	StatusArchived  ProjectStatus = "archived" // Hidden from the project list; set through archiving only
 )
 
 // Project represents a work project
 type Project struct {
-	ID          int           `json:"id" db:"id"`
-	Name        string        `json:"name" db:"name"`
-	Description *string       `json:"description" db:"description"`
-	URL1        *string       `json:"url1" db:"url1"`
-	URL2        *string       `json:"url2" db:"url2"`
-	URL3        *string       `json:"url3" db:"url3"`
-	Discord     *string       `json:"discord" db:"discord"`
-	Directory   *string       `json:"directory" db:"directory"`
-	Deadline    *time.Time    `json:"deadline" db:"deadline"`
-	Status      ProjectStatus `json:"status" db:"status"`
-	Order       int           `json:"order" db:"order"`
-	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
+	ID             int            `json:"id" db:"id"`
+	Name           string         `json:"name" db:"name"`
+	Description    *string        `json:"description" db:"description"`
+	Directory      *string        `json:"directory" db:"directory"`
+	Deadline       *time.Time     `json:"deadline" db:"deadline"`
+	Status         ProjectStatus  `json:"status" db:"status"`
+	Order          int            `json:"order" db:"order"`
+	Billable       bool           `json:"billable" db:"billable"`
+	HourlyRate     float64        `json:"hourly_rate" db:"hourly_rate"` // 0 falls back to the client's default rate
+	ClientID       *int           `json:"client_id" db:"client_id"`
+	EstimatedHours *float64       `json:"estimated_hours" db:"estimated_hours"` // Hour budget, nil when the project has none
+	ArchivedAt     *time.Time     `json:"archived_at" db:"archived_at"`
+	DeletedAt      *time.Time     `json:"deleted_at" db:"deleted_at"` // Set while the project is in the trash
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+	Tags           []Tag          `json:"tags"`          // Tags applied to the project and all of its time blocks
+	Links          []ProjectLink  `json:"links"`         // Links in display order
+	CustomFields   map[int]string `json:"custom_fields"` // Custom field values in canonical text form, keyed by field ID
+	Milestones     []Milestone    `json:"milestones"`    // Milestones by due date
 }
 
 // CreateProjectRequest represents the request to create a new project
 type CreateProjectRequest struct {
-	Name        string     `json:"name"`
-	Description *string    `json:"description"`
-	URL1        *string    `json:"url1"`
-	URL2        *string    `json:"url2"`
-	URL3        *string    `json:"url3"`
-	Discord     *string    `json:"discord"`
-	Directory   *string    `json:"directory"`
-	Deadline    *time.Time `json:"deadline"`
-	Order       int        `json:"order"`
+	Name           string     `json:"name"`
+	Description    *string    `json:"description"`
+	Directory      *string    `json:"directory"`
+	Deadline       *time.Time `json:"deadline"`
+	Order          int        `json:"order"`
+	Billable       bool       `json:"billable"`
+	HourlyRate     float64    `json:"hourly_rate"`
+	ClientID       *int       `json:"client_id"`
+	EstimatedHours *float64   `json:"estimated_hours"`
 }
 
 // UpdateProjectRequest represents the request to update a project
 type UpdateProjectRequest struct {
-	Name        *string        `json:"name"`
-	Description *string        `json:"description"`
-	URL1        *string        `json:"url1"`
-	URL2        *string        `json:"url2"`
-	URL3        *string        `json:"url3"`
-	Discord     *string        `json:"discord"`
-	Directory   *string        `json:"directory"`
-	Deadline    *time.Time     `json:"deadline"`
-	Status      *ProjectStatus `json:"status"`
-	Order       *int           `json:"order"`
+	Name           *string        `json:"name"`
+	Description    *string        `json:"description"`
+	Directory      *string        `json:"directory"`
+	Deadline       *time.Time     `json:"deadline"`
+	Status         *ProjectStatus `json:"status"`
+	Order          *int           `json:"order"`
+	Billable       *bool          `json:"billable"`
+	HourlyRate     *float64       `json:"hourly_rate"`
+	ClientID       *int           `json:"client_id"`       // 0 removes the project from its client
+	EstimatedHours *float64       `json:"estimated_hours"` // 0 removes the budget
 }
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// LinkKind tells how a project link is opened
+type LinkKind string
+
+const (
+	LinkKindWeb     LinkKind = "web"     // Opened in the default browser
+	LinkKindDiscord LinkKind = "discord" // Opened in the Discord app when possible
+)
+
+// ProjectLink represents a labelled link of a project, such as a repository, board or chat channel
+type ProjectLink struct {
+	ID        int       `json:"id" db:"id"`
+	ProjectID int       `json:"project_id" db:"project_id"`
+	Label     string    `json:"label" db:"label"` // Empty shows the URL instead
+	URL       string    `json:"url" db:"url"`
+	Kind      LinkKind  `json:"kind" db:"kind"`
+	Order     int       `json:"order" db:"order"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateProjectLinkRequest represents the request to add a link to a project
+type CreateProjectLinkRequest struct {
+	ProjectID int      `json:"project_id"`
+	Label     string   `json:"label"`
+	URL       string   `json:"url"`
+	Kind      LinkKind `json:"kind"` // Empty defaults to web
+	Order     int      `json:"order"`
+}
+
+// UpdateProjectLinkRequest represents the request to update a project link
+type UpdateProjectLinkRequest struct {
+	Label *string   `json:"label"`
+	URL   *string   `json:"url"`
+	Kind  *LinkKind `json:"kind"`
+	Order *int      `json:"order"`
+}
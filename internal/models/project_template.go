@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// ProjectTemplate represents a saved starting point for new projects. Creating a project from it copies
+// everything it holds; the project's time blocks, deadline and status are never part of it.
+type ProjectTemplate struct {
+	ID             int            `json:"id" db:"id"`
+	Name           string         `json:"name" db:"name"`
+	Description    *string        `json:"description" db:"description"`
+	Directory      *string        `json:"directory" db:"directory"` // Pattern where {name}, {client}, {year} and {date} are filled in
+	Billable       bool           `json:"billable" db:"billable"`
+	HourlyRate     float64        `json:"hourly_rate" db:"hourly_rate"`
+	ClientID       *int           `json:"client_id" db:"client_id"`
+	EstimatedHours *float64       `json:"estimated_hours" db:"estimated_hours"`
+	Links          []TemplateLink `json:"links" db:"links"`
+	Tasks          []TemplateTask `json:"tasks" db:"tasks"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+	Tags           []Tag          `json:"tags"`
+	CustomFields   map[int]string `json:"custom_fields"`
+}
+
+// TemplateLink represents a project link kept in a template
+type TemplateLink struct {
+	Label string   `json:"label"`
+	URL   string   `json:"url"`
+	Kind  LinkKind `json:"kind"`
+}
+
+// TemplateTask represents a task kept in a template with its subtasks; tasks created from it start open
+type TemplateTask struct {
+	Name           string         `json:"name"`
+	Description    *string        `json:"description"`
+	EstimatedHours *float64       `json:"estimated_hours"`
+	Children       []TemplateTask `json:"children"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// ReportGrouping represents how a report groups the tracked time
+type ReportGrouping string
+
+const (
+	ReportByDay     ReportGrouping = "day"
+	ReportByWeek    ReportGrouping = "week" // ISO weeks, starting on Monday
+	ReportByMonth   ReportGrouping = "month"
+	ReportByProject ReportGrouping = "project"
+	ReportByTag     ReportGrouping = "tag" // A block counts towards its own tags and those of its project
+	ReportByClient  ReportGrouping = "client"
+)
+
+// ReportGroup represents the time tracked in one group of a report
+type ReportGroup struct {
+	Key        string `json:"key"`      // 2006-01-02 for days, 2006-W01 for weeks, 2006-01 for months, the ID otherwise
+	ID         int    `json:"id"`       // Project, tag or client ID; 0 for periods and for blocks without a tag or client
+	Label      string `json:"label"`    // Project, tag or client name; the key for periods
+	Color      string `json:"color"`    // Tag color, empty for the other groupings
+	Duration   int    `json:"duration"` // Duration in seconds
+	BlockCount int    `json:"block_count"`
+}
+
+// Report represents the time tracked in a date range, grouped one way
+type Report struct {
+	Grouping   ReportGrouping `json:"grouping"`
+	Duration   int            `json:"duration"` // Total of the range; below the sum of the groups when tags overlap
+	BlockCount int            `json:"block_count"`
+	Groups     []ReportGroup  `json:"groups"` // Periods in date order, other groups by duration
+}
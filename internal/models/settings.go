@@ -8,6 +8,22 @@ type Settings struct {
 	TimeFormat string `json:"timeFormat" db:"timeformat"` // "12" or "24"
 	CustomURL  string `json:"customUrl" db:"custom_url"`
 	TrelloURL  string `json:"trelloUrl" db:"trello_url"`
+
+	AllowParallelTimers  bool `json:"allowParallelTimers" db:"allow_parallel_timers"`   // Allow more than one running time block
+	IdleThresholdMinutes int  `json:"idleThresholdMinutes" db:"idle_threshold_minutes"` // 0 disables idle detection
+
+	PomodoroWorkMinutes       int `json:"pomodoroWorkMinutes" db:"pomodoro_work_minutes"`
+	PomodoroShortBreakMinutes int `json:"pomodoroShortBreakMinutes" db:"pomodoro_short_break_minutes"`
+	PomodoroLongBreakMinutes  int `json:"pomodoroLongBreakMinutes" db:"pomodoro_long_break_minutes"`
+	PomodoroLongBreakEvery    int `json:"pomodoroLongBreakEvery" db:"pomodoro_long_break_every"` // Work intervals before a long break
+
+	Currency string `json:"currency" db:"currency"` // ISO 4217 code used for hourly rates and billable amounts
+
+	BudgetAlertThreshold int `json:"budgetAlertThreshold" db:"budget_alert_threshold"` // Percentage of a budget that triggers an alert, 0 disables alerts
+
+	TrashRetentionDays int `json:"trashRetentionDays" db:"trash_retention_days"` // Days items stay in the trash before they are purged, 0 keeps them
+
+	DeadlineReminderDays int `json:"deadlineReminderDays" db:"deadline_reminder_days"` // Days ahead a deadline is announced, 0 disables reminders
 }
 
 // UpdateSettingsRequest represents the request to update settings
@@ -17,4 +33,20 @@ type UpdateSettingsRequest struct {
 	TimeFormat *string `json:"timeFormat"`
 	CustomURL  *string `json:"customUrl"`
 	TrelloURL  *string `json:"trelloUrl"`
+
+	AllowParallelTimers  *bool `json:"allowParallelTimers"`
+	IdleThresholdMinutes *int  `json:"idleThresholdMinutes"`
+
+	PomodoroWorkMinutes       *int `json:"pomodoroWorkMinutes"`
+	PomodoroShortBreakMinutes *int `json:"pomodoroShortBreakMinutes"`
+	PomodoroLongBreakMinutes  *int `json:"pomodoroLongBreakMinutes"`
+	PomodoroLongBreakEvery    *int `json:"pomodoroLongBreakEvery"`
+
+	Currency *string `json:"currency"`
+
+	BudgetAlertThreshold *int `json:"budgetAlertThreshold"`
+
+	TrashRetentionDays *int `json:"trashRetentionDays"`
+
+	DeadlineReminderDays *int `json:"deadlineReminderDays"`
 }
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+// Tag represents a label that can be attached to time blocks and projects
+type Tag struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Color     string    `json:"color" db:"color"` // Hex color such as #1098F7
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateTagRequest represents the request to create a new tag
+type CreateTagRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// UpdateTagRequest represents the request to rename or recolor a tag
+type UpdateTagRequest struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"`
+}
+
+// MergeTagsRequest represents the request to fold several tags into one
+type MergeTagsRequest struct {
+	SourceIDs []int `json:"source_ids"`
+	TargetID  int   `json:"target_id"`
+}
+
+// TagTotal represents the time tracked under a tag in a date range
+type TagTotal struct {
+	TagID      int    `json:"tag_id"`
+	TagName    string `json:"tag_name"`
+	Color      string `json:"color"`
+	Duration   int    `json:"duration"` // Duration in seconds
+	BlockCount int    `json:"block_count"`
+}
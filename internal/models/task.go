@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// Task represents a unit of work inside a project; tasks nest under other tasks of the same project
+type Task struct {
+	ID             int       `json:"id" db:"id"`
+	ProjectID      int       `json:"project_id" db:"project_id"`
+	ParentID       *int      `json:"parent_id" db:"parent_id"` // Nil for top-level tasks
+	Name           string    `json:"name" db:"name"`
+	Description    *string   `json:"description" db:"description"`
+	Done           bool      `json:"done" db:"done"`
+	Order          int       `json:"order" db:"order"`                     // Position among the tasks sharing the same parent
+	EstimatedHours *float64  `json:"estimated_hours" db:"estimated_hours"` // Hour budget, nil when the task has none
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+
+	Duration      int    `json:"duration"`       // Seconds tracked on the task itself
+	TotalDuration int    `json:"total_duration"` // Seconds tracked on the task and all of its subtasks
+	Children      []Task `json:"children"`
+}
+
+// CreateTaskRequest represents the request to create a new task
+type CreateTaskRequest struct {
+	ProjectID      int      `json:"project_id"`
+	ParentID       *int     `json:"parent_id"`
+	Name           string   `json:"name"`
+	Description    *string  `json:"description"`
+	Order          int      `json:"order"`
+	EstimatedHours *float64 `json:"estimated_hours"`
+}
+
+// UpdateTaskRequest represents the request to update a task
+type UpdateTaskRequest struct {
+	ParentID       *int     `json:"parent_id"` // 0 moves the task to the top level
+	Name           *string  `json:"name"`
+	Description    *string  `json:"description"`
+	Done           *bool    `json:"done"`
+	Order          *int     `json:"order"`
+	EstimatedHours *float64 `json:"estimated_hours"` // 0 removes the budget
+}
+
+// TaskTree represents the tasks of a project with durations rolled up at every level
+type TaskTree struct {
+	ProjectID          int    `json:"project_id"`
+	Duration           int    `json:"duration"`            // Seconds tracked on the project as a whole
+	UnassignedDuration int    `json:"unassigned_duration"` // Seconds tracked on the project outside any task
+	Tasks              []Task `json:"tasks"`
+}
@@ -6,32 +6,116 @@ import (
 
 // TimeBlock represents a time tracking block
 type TimeBlock struct {
+	ID                 int        `json:"id" db:"id"`
+	ProjectID          int        `json:"project_id" db:"project_id"`
+	ProjectName        string     `json:"project_name" db:"project_name"`
+	TaskID             *int       `json:"task_id" db:"task_id"`
+	TaskName           *string    `json:"task_name" db:"task_name"`
+	StartTime          time.Time  `json:"start_time" db:"start_time"`
+	EndTime            *time.Time `json:"end_time" db:"end_time"`
+	Duration           int        `json:"duration" db:"duration"`                     // Duration in seconds
+	DurationOverridden bool       `json:"duration_overridden" db:"duration_override"` // Duration was set explicitly instead of derived from the interval
+	IsManual           bool       `json:"is_manual" db:"is_manual"`
+	Description        *string    `json:"description" db:"description"`
+	HeartbeatAt        *time.Time `json:"heartbeat_at" db:"heartbeat_at"` // Last time a running timer reported activity
+	Billable           *bool      `json:"billable" db:"billable"`         // Overrides the project's billable flag when set
+	HourlyRate         *float64   `json:"hourly_rate" db:"hourly_rate"`   // Overrides the project's hourly rate when set
+	DeletedAt          *time.Time `json:"deleted_at" db:"deleted_at"`     // Set while the block is in the trash
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at" db:"updated_at"`
+
+	Segments       []TimeBlockSegment `json:"segments"`        // Active work intervals, empty for manual blocks
+	PausedDuration int                `json:"paused_duration"` // Seconds spent paused between segments
+	Tags           []Tag              `json:"tags"`            // Tags set on the block itself; project tags apply too
+	CustomFields   map[int]string     `json:"custom_fields"`   // Values set on the block itself; project values apply where it has none
+}
+
+// TimeBlockSegment represents an active work interval inside a time block
+type TimeBlockSegment struct {
 	ID          int        `json:"id" db:"id"`
-	ProjectID   int        `json:"project_id" db:"project_id"`
-	ProjectName string     `json:"project_name" db:"project_name"`
+	TimeBlockID int        `json:"time_block_id" db:"time_block_id"`
 	StartTime   time.Time  `json:"start_time" db:"start_time"`
 	EndTime     *time.Time `json:"end_time" db:"end_time"`
-	Duration    int        `json:"duration" db:"duration"` // Duration in seconds
-	IsManual    bool       `json:"is_manual" db:"is_manual"`
-	Description *string    `json:"description" db:"description"`
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // CreateTimeBlockRequest represents the request to create a new time block
 type CreateTimeBlockRequest struct {
 	ProjectID   int        `json:"project_id"`
+	TaskID      *int       `json:"task_id"`
 	StartTime   time.Time  `json:"start_time"`
 	EndTime     *time.Time `json:"end_time"`
 	Duration    int        `json:"duration"`
 	IsManual    bool       `json:"is_manual"`
 	Description *string    `json:"description"`
+	Billable    *bool      `json:"billable"`    // Inherits the project's flag when nil
+	HourlyRate  *float64   `json:"hourly_rate"` // Inherits the project's rate when nil
 }
 
 // UpdateTimeBlockRequest represents the request to update a time block
 type UpdateTimeBlockRequest struct {
-	StartTime   *time.Time `json:"start_time"`
-	EndTime     *time.Time `json:"end_time"`
-	Duration    *int       `json:"duration"`
-	Description *string    `json:"description"`
+	ProjectID     *int       `json:"project_id"`
+	TaskID        *int       `json:"task_id"` // 0 detaches the block from its task
+	StartTime     *time.Time `json:"start_time"`
+	EndTime       *time.Time `json:"end_time"`
+	Duration      *int       `json:"duration"`       // Explicit duration override; derived from the interval when nil
+	ResetDuration bool       `json:"reset_duration"` // Drop an existing override and derive the duration again
+	Description   *string    `json:"description"`
+	Billable      *bool      `json:"billable"`      // Billable override for this block
+	HourlyRate    *float64   `json:"hourly_rate"`   // Hourly rate override for this block
+	ResetBilling  bool       `json:"reset_billing"` // Drop both overrides so the block follows its project again
+}
+
+// TimeBlockFilter narrows time block range queries; empty fields do not filter
+type TimeBlockFilter struct {
+	TagIDs       []int          `json:"tag_ids"`        // Blocks tagged, directly or through their project, with these tags
+	MatchAllTags bool           `json:"match_all_tags"` // Require every tag in TagIDs instead of any of them
+	ClientIDs    []int          `json:"client_ids"`     // Blocks of projects belonging to any of these clients
+	CustomFields map[int]string `json:"custom_fields"`  // Blocks whose value of every field, their own or their project's, equals the given one
+}
+
+// MoveTimeBlocksRequest represents the request to reassign several time blocks to a project
+type MoveTimeBlocksRequest struct {
+	TimeBlockIDs []int `json:"time_block_ids"`
+	ProjectID    int   `json:"project_id"`
+}
+
+// OverlapStrategy represents how time blocks overlapping a kept block are resolved
+type OverlapStrategy string
+
+const (
+	OverlapTrim  OverlapStrategy = "trim"  // Shorten the other blocks so they end before or start after the kept one
+	OverlapSplit OverlapStrategy = "split" // Cut the overlapped stretch out of the other blocks, keeping both ends
+	OverlapMerge OverlapStrategy = "merge" // Fold the other blocks into the kept one
+)
+
+// ResolveOverlapRequest represents the request to save a time block and resolve its overlaps.
+// Create is saved as a new block when TimeBlockID is nil, otherwise Update is applied to the existing block.
+type ResolveOverlapRequest struct {
+	TimeBlockID *int                    `json:"time_block_id"`
+	Create      *CreateTimeBlockRequest `json:"create"`
+	Update      *UpdateTimeBlockRequest `json:"update"`
+	Strategy    OverlapStrategy         `json:"strategy"`
+}
+
+// TimeBlockAction is the kind of change recorded in a time block's history
+type TimeBlockAction string
+
+const (
+	TimeBlockCreated  TimeBlockAction = "create"
+	TimeBlockUpdated  TimeBlockAction = "update"
+	TimeBlockDeleted  TimeBlockAction = "delete"  // Moved to the trash
+	TimeBlockRestored TimeBlockAction = "restore" // Brought back from the trash
+	TimeBlockPurged   TimeBlockAction = "purge"   // Removed for good, by emptying the trash or merging blocks
+)
+
+// TimeBlockChange is an entry of a time block's append-only history
+type TimeBlockChange struct {
+	ID          int                    `json:"id" db:"id"`
+	TimeBlockID int                    `json:"time_block_id" db:"time_block_id"`
+	Action      TimeBlockAction        `json:"action" db:"action"`
+	Before      map[string]interface{} `json:"before" db:"before_values"` // Column values before the change, nil on create
+	After       map[string]interface{} `json:"after" db:"after_values"`   // Column values after the change, nil on purge
+	Changed     []string               `json:"changed"`                   // Columns that differ between before and after
+	ChangedAt   time.Time              `json:"changed_at" db:"changed_at"`
 }
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+)
+
+// TimerStatus represents the state of the backend timer
+type TimerStatus string
+
+const (
+	TimerIdle    TimerStatus = "idle"
+	TimerRunning TimerStatus = "running"
+	TimerPaused  TimerStatus = "paused"
+)
+
+// TimerState represents the persisted state of the running timer
+type TimerState struct {
+	Status        TimerStatus `json:"status" db:"status"`
+	TimeBlockID   *int        `json:"time_block_id" db:"time_block_id"`
+	ProjectID     *int        `json:"project_id" db:"project_id"`
+	StartTime     *time.Time  `json:"start_time" db:"start_time"`
+	PausedAt      *time.Time  `json:"paused_at" db:"paused_at"`
+	PausedSeconds int         `json:"paused_seconds" db:"paused_seconds"`
+	Elapsed       int         `json:"elapsed"` // Net elapsed seconds, computed on read
+	UpdatedAt     time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// StartTimerRequest represents the request to start the timer
+type StartTimerRequest struct {
+	ProjectID   int     `json:"project_id"`
+	TaskID      *int    `json:"task_id"`
+	Description *string `json:"description"`
+}
+
+// RecoveryAction represents how an orphaned time block is recovered
+type RecoveryAction string
+
+const (
+	RecoveryResume  RecoveryAction = "resume"
+	RecoveryStop    RecoveryAction = "stop"
+	RecoveryDiscard RecoveryAction = "discard"
+)
+
+// OrphanedTimeBlock represents a time block left running by a crash or sleep
+type OrphanedTimeBlock struct {
+	TimeBlock         TimeBlock  `json:"time_block"`
+	LastHeartbeat     *time.Time `json:"last_heartbeat"`
+	RecoveredEndTime  time.Time  `json:"recovered_end_time"` // End time used when stopping at the last heartbeat
+	RecoveredDuration int        `json:"recovered_duration"` // Duration in seconds when stopping at the last heartbeat
+	IsTimerBlock      bool       `json:"is_timer_block"`
+}
+
+// RecoverTimeBlockRequest represents the request to recover an orphaned time block
+type RecoverTimeBlockRequest struct {
+	TimeBlockID int            `json:"time_block_id"`
+	Action      RecoveryAction `json:"action"`
+}
+
+// IdleAction represents how an idle stretch is handled
+type IdleAction string
+
+const (
+	IdleKeep  IdleAction = "keep"
+	IdleTrim  IdleAction = "trim"
+	IdleSplit IdleAction = "split"
+)
+
+// IdlePeriod represents an idle stretch detected while a time block was running
+type IdlePeriod struct {
+	TimeBlockID int       `json:"time_block_id"`
+	IdleStart   time.Time `json:"idle_start"`
+	DetectedAt  time.Time `json:"detected_at"`
+}
+
+// ResolveIdleRequest represents the request to handle the pending idle stretch
+type ResolveIdleRequest struct {
+	Action IdleAction `json:"action"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+)
+
+// TimesheetFormat represents the file format of an exported timesheet
+type TimesheetFormat string
+
+const (
+	TimesheetHTML TimesheetFormat = "html" // A standalone page that prints cleanly from a browser
+	TimesheetPDF  TimesheetFormat = "pdf"
+)
+
+// TimesheetRequest represents the request to export a timesheet
+type TimesheetRequest struct {
+	StartDate  time.Time       `json:"start_date"`
+	EndDate    time.Time       `json:"end_date"`
+	ProjectIDs []int           `json:"project_ids"` // Every project when empty
+	Format     TimesheetFormat `json:"format"`
+}
+
+// TimesheetEntry represents one stopped time block on a timesheet
+type TimesheetEntry struct {
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	ProjectName string    `json:"project_name"`
+	TaskName    *string   `json:"task_name"`
+	Description *string   `json:"description"`
+	Duration    int       `json:"duration"` // Duration in seconds
+}
+
+// TimesheetDay represents the entries of one day with their subtotal
+type TimesheetDay struct {
+	Date     time.Time        `json:"date"`
+	Entries  []TimesheetEntry `json:"entries"`
+	Duration int              `json:"duration"`
+}
+
+// TimesheetProjectTotal represents the time of one project on a timesheet
+type TimesheetProjectTotal struct {
+	ProjectName string `json:"project_name"`
+	Duration    int    `json:"duration"`
+}
+
+// Timesheet represents the tracked time of a date range, day by day
+type Timesheet struct {
+	StartDate   time.Time               `json:"start_date"`
+	EndDate     time.Time               `json:"end_date"`
+	ClientName  string                  `json:"client_name"` // Set when every entry belongs to the same client
+	Days        []TimesheetDay          `json:"days"`        // Days with entries, earliest first
+	Projects    []TimesheetProjectTotal `json:"projects"`    // Project subtotals by duration
+	Duration    int                     `json:"duration"`    // Grand total in seconds
+	GeneratedAt time.Time               `json:"generated_at"`
+	TimeFormat  string                  `json:"time_format"` // "12" or "24", from the settings
+}
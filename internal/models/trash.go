@@ -0,0 +1,15 @@
+package models
+
+// TrashedProject is a deleted project with the time blocks that were deleted along with it
+type TrashedProject struct {
+	Project        Project `json:"project"`
+	TimeBlockCount int     `json:"time_block_count"`
+	Duration       int     `json:"duration"` // Seconds tracked in the blocks deleted with the project
+}
+
+// Trash lists the deleted items that can still be restored
+type Trash struct {
+	Projects      []TrashedProject `json:"projects"`
+	TimeBlocks    []TimeBlock      `json:"time_blocks"` // Blocks deleted on their own, not with their project
+	RetentionDays int              `json:"retention_days"`
+}
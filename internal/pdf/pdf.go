@@ -0,0 +1,283 @@
+// Package pdf writes simple text documents as PDF without external tools. It covers what printable reports
+// need: A4 pages with text in the standard Helvetica fonts, lines and shaded boxes. Text is encoded as
+// Windows-1252, so characters outside it print as question marks.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Font is one of the standard fonts every PDF reader provides
+type Font int
+
+const (
+	Regular Font = iota // Helvetica
+	Bold                // Helvetica-Bold
+)
+
+// Document is a PDF being built page by page. Positions are in points from the top left of the page.
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+}
+
+// New creates an empty document with a title shown by PDF readers
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// AddPage starts a new page; later drawing goes to it
+func (d *Document) AddPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+}
+
+// PageCount returns the number of pages added so far
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text draws a line of text with its baseline at y
+func (d *Document) Text(x, y float64, font Font, size float64, s string) {
+	d.ensurePage()
+	fmt.Fprintf(d.page, "BT /F%d %.2f Tf %.2f %.2f Td %s Tj ET\n", font+1, size, x, PageHeight-y, literal(encode(s)))
+}
+
+// TextRight draws a line of text ending at x
+func (d *Document) TextRight(x, y float64, font Font, size float64, s string) {
+	d.Text(x-TextWidth(font, size, s), y, font, size, s)
+}
+
+// Line draws a black line of the given width
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	d.ensurePage()
+	fmt.Fprintf(d.page, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// FillRect fills a box whose top left corner is at x, y with a gray level from 0 (black) to 1 (white)
+func (d *Document) FillRect(x, y, w, h, gray float64) {
+	d.ensurePage()
+	fmt.Fprintf(d.page, "q %.3f g %.2f %.2f %.2f %.2f re f Q\n", gray, x, PageHeight-y-h, w, h)
+}
+
+func (d *Document) ensurePage() {
+	if d.page == nil {
+		d.AddPage()
+	}
+}
+
+// TextWidth returns the width of a line of text in points
+func TextWidth(font Font, size float64, s string) float64 {
+	widths := &helveticaWidths
+	if font == Bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, c := range encode(s) {
+		if c >= 32 && c <= 126 {
+			total += widths[c-32]
+		} else {
+			total += otherWidth(c)
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// WrapText splits text into lines no wider than maxWidth, breaking between words where it can.
+// Line breaks in the text are kept.
+func WrapText(font Font, size float64, s string, maxWidth float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if TextWidth(font, size, candidate) <= maxWidth {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			// A word longer than the line is cut wherever it reaches the edge
+			for TextWidth(font, size, word) > maxWidth {
+				cut := nextRune(word, 0)
+				for cut < len(word) && TextWidth(font, size, word[:nextRune(word, cut)]) <= maxWidth {
+					cut = nextRune(word, cut)
+				}
+				lines = append(lines, word[:cut])
+				word = word[cut:]
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// nextRune returns the byte offset of the rune after the one starting at i
+func nextRune(s string, i int) int {
+	_, size := utf8.DecodeRuneInString(s[i:])
+	return i + size
+}
+
+// WriteTo writes the finished document
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	d.ensurePage()
+
+	out := &bytes.Buffer{}
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1 to 5 are fixed; each page then takes a page object followed by its content stream
+	pageIDs := make([]string, len(d.pages))
+	for i := range d.pages {
+		pageIDs[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(pageIDs, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title %s /CreationDate (D:%s) >>", literal(encode(d.title)), time.Now().Format("20060102150405")))
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, 7+2*i))
+
+		compressed := &bytes.Buffer{}
+		zw := zlib.NewWriter(compressed)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.WriteTo(w)
+}
+
+// Bytes returns the finished document
+func (d *Document) Bytes() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if _, err := d.WriteTo(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// literal quotes bytes as a PDF string, escaping everything outside printable ASCII
+func literal(b []byte) string {
+	var sb strings.Builder
+	sb.WriteByte('(')
+	for _, c := range b {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 32 || c > 126:
+			fmt.Fprintf(&sb, "\\%03o", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// encode converts text to Windows-1252; control characters become spaces
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			out = append(out, ' ')
+		case r < 32 || r == 127:
+			continue
+		case r < 128 || (r >= 0xA0 && r <= 0xFF):
+			out = append(out, byte(r))
+		default:
+			if c, ok := windows1252[r]; ok {
+				out = append(out, c)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
+
+// windows1252 maps the characters Windows-1252 places between 0x80 and 0x9F
+var windows1252 = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// Glyph widths of printable ASCII in thousandths of the font size, from the Adobe font metrics
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// otherWidth approximates the width of a character outside printable ASCII
+func otherWidth(c byte) int {
+	switch c {
+	case 0x85, 0x89, 0x97: // Ellipsis, per mille and em dash
+		return 1000
+	case 0x95, 0xB7: // Bullet and middle dot
+		return 350
+	case 0x91, 0x92, 0xA0:
+		return 278
+	case 0x80, 0x96:
+		return 556
+	default:
+		if c >= 0xC0 && c <= 0xDE {
+			return 722 // Accented capitals
+		}
+		return 556
+	}
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"database/sql"
+	"math"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+// BudgetVelocityDays is how many recent days the burn-down velocity is averaged over
+const BudgetVelocityDays = 14
+
+// BudgetService computes burn-down of project and task hour budgets
+type BudgetService struct {
+	db              *sql.DB
+	settingsService *SettingsService
+}
+
+// NewBudgetService creates a new budget service
+func NewBudgetService(db *sql.DB, settingsService *SettingsService) *BudgetService {
+	return &BudgetService{db: db, settingsService: settingsService}
+}
+
+// budgetScope selects the time blocks that count against one budget
+type budgetScope struct {
+	projectID int
+	taskID    *int
+	name      string
+	estimate  *float64
+	alerted   bool
+}
+
+// where returns the condition on the tb alias matching the blocks of the scope; a task counts its subtasks
+func (b budgetScope) where() (string, []interface{}) {
+	if b.taskID != nil {
+		return "tb.task_id IN (" + subtreeQuery + ")", []interface{}{*b.taskID}
+	}
+	return "tb.project_id = ?", []interface{}{b.projectID}
+}
+
+// GetProjectBurnDown returns the burn-down of a project's hour budget
+func (s *BudgetService) GetProjectBurnDown(projectID int) (*models.BurnDown, error) {
+	scope := budgetScope{projectID: projectID}
+	err := s.db.QueryRow("SELECT name, estimated_hours FROM projects WHERE id = ? AND deleted_at IS NULL", projectID).
+		Scan(&scope.name, &scope.estimate)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.burnDown(scope, time.Now())
+}
+
+// GetTaskBurnDown returns the burn-down of a task's hour budget, counting the time of its subtasks
+func (s *BudgetService) GetTaskBurnDown(taskID int) (*models.BurnDown, error) {
+	scope := budgetScope{taskID: &taskID}
+	err := s.db.QueryRow("SELECT project_id, name, estimated_hours FROM tasks WHERE id = ?", taskID).
+		Scan(&scope.projectID, &scope.name, &scope.estimate)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.burnDown(scope, time.Now())
+}
+
+// CheckBudgetAlerts returns the budgets that reached the alert threshold since the last check. Each budget
+// alerts once; falling back below the threshold, for example after raising the estimate, re-arms it.
+func (s *BudgetService) CheckBudgetAlerts() ([]models.BudgetAlert, error) {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	alerts := []models.BudgetAlert{}
+	if settings.BudgetAlertThreshold <= 0 {
+		return alerts, nil
+	}
+
+	scopes, err := s.budgetScopes()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, scope := range scopes {
+		burnDown, err := s.burnDown(scope, now)
+		if err != nil {
+			return nil, err
+		}
+
+		reached := burnDown.PercentBurned >= float64(settings.BudgetAlertThreshold)
+		if reached == scope.alerted {
+			continue
+		}
+
+		table, id := "projects", scope.projectID
+		if scope.taskID != nil {
+			table, id = "tasks", *scope.taskID
+		}
+		if _, err := s.db.Exec("UPDATE "+table+" SET budget_alerted = ? WHERE id = ?", reached, id); err != nil {
+			return nil, err
+		}
+
+		if reached {
+			alerts = append(alerts, models.BudgetAlert{Threshold: settings.BudgetAlertThreshold, BurnDown: *burnDown})
+		}
+	}
+
+	return alerts, nil
+}
+
+// budgetScopes returns every project and task that has an hour budget
+func (s *BudgetService) budgetScopes() ([]budgetScope, error) {
+	query := `
+		SELECT id, NULL, name, estimated_hours, COALESCE(budget_alerted, FALSE)
+		FROM projects WHERE estimated_hours > 0 AND deleted_at IS NULL
+		UNION ALL
+		SELECT project_id, id, name, estimated_hours, COALESCE(budget_alerted, FALSE)
+		FROM tasks WHERE estimated_hours > 0 AND project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scopes []budgetScope
+	for rows.Next() {
+		var scope budgetScope
+		if err := rows.Scan(&scope.projectID, &scope.taskID, &scope.name, &scope.estimate, &scope.alerted); err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, scope)
+	}
+
+	return scopes, rows.Err()
+}
+
+// burnDown compares the time tracked in a scope with its estimate and projects completion from recent velocity
+func (s *BudgetService) burnDown(scope budgetScope, now time.Time) (*models.BurnDown, error) {
+	burnDown := &models.BurnDown{
+		ProjectID:    scope.projectID,
+		TaskID:       scope.taskID,
+		Name:         scope.name,
+		VelocityDays: BudgetVelocityDays,
+	}
+	if scope.estimate != nil {
+		burnDown.EstimatedHours = *scope.estimate
+	}
+
+	consumed, err := s.trackedSeconds(scope, nil, now)
+	if err != nil {
+		return nil, err
+	}
+	since := now.AddDate(0, 0, -BudgetVelocityDays)
+	recent, err := s.trackedSeconds(scope, &since, now)
+	if err != nil {
+		return nil, err
+	}
+
+	burnDown.ConsumedHours = roundHours(float64(consumed) / 3600)
+	burnDown.DailyVelocity = roundHours(float64(recent) / 3600 / BudgetVelocityDays)
+
+	if burnDown.EstimatedHours <= 0 {
+		return burnDown, nil
+	}
+
+	remaining := burnDown.EstimatedHours - float64(consumed)/3600
+	burnDown.RemainingHours = roundHours(remaining)
+	burnDown.PercentBurned = math.Round(float64(consumed)/36/burnDown.EstimatedHours*10) / 10
+
+	if remaining > 0 && recent > 0 {
+		velocity := float64(recent) / 3600 / BudgetVelocityDays
+		projected := now.Add(time.Duration(remaining / velocity * 24 * float64(time.Hour))).In(time.Local)
+		burnDown.ProjectedCompletion = &projected
+	}
+
+	return burnDown, nil
+}
+
+// trackedSeconds sums the worked seconds of the blocks in a scope, optionally only those starting after since.
+// Running blocks count up to now.
+func (s *BudgetService) trackedSeconds(scope budgetScope, since *time.Time, now time.Time) (int, error) {
+	where, args := scope.where()
+	if since != nil {
+		where += " AND tb.start_time >= ?"
+		args = append(args, since.In(time.Local))
+	}
+
+	var stopped int
+	query := "SELECT COALESCE(SUM(tb.duration), 0) FROM time_blocks tb WHERE tb.end_time IS NOT NULL AND tb.deleted_at IS NULL AND " + where
+	if err := s.db.QueryRow(query, args...).Scan(&stopped); err != nil {
+		return 0, err
+	}
+
+	query = `
+		SELECT ` + timeBlockColumns + `
+		FROM time_blocks tb
+		JOIN projects p ON tb.project_id = p.id
+		WHERE tb.end_time IS NULL AND tb.deleted_at IS NULL AND ` + where
+
+	running, err := queryTimeBlocks(s.db, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	total := stopped
+	for _, timeBlock := range running {
+		if len(timeBlock.Segments) > 0 {
+			total += segmentsDuration(timeBlock.Segments, now)
+		} else if now.After(timeBlock.StartTime) {
+			total += int(now.Sub(timeBlock.StartTime).Seconds())
+		}
+	}
+
+	return total, nil
+}
+
+// normalizeEstimate validates an hour budget, turning 0 into no budget
+func normalizeEstimate(estimate *float64) (*float64, error) {
+	if estimate == nil || *estimate == 0 {
+		return nil, nil
+	}
+	if *estimate < 0 {
+		return nil, ErrNegativeEstimate
+	}
+	return estimate, nil
+}
+
+// roundHours rounds hours to two decimals
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
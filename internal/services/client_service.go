@@ -0,0 +1,227 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+// ErrClientNameRequired is returned when a client name is empty
+var ErrClientNameRequired = errors.New("client name is required")
+
+// clientColumns is the column list shared by every client query
+const clientColumns = `
+	id, name, contact, COALESCE(default_rate, 0), COALESCE(currency, ''), notes, created_at, updated_at
+`
+
+// ClientService handles client operations
+type ClientService struct {
+	db *sql.DB
+}
+
+// NewClientService creates a new client service
+func NewClientService(db *sql.DB) *ClientService {
+	return &ClientService{db: db}
+}
+
+// scanClient scans a row selected with clientColumns
+func scanClient(row rowScanner) (models.Client, error) {
+	var client models.Client
+	err := row.Scan(
+		&client.ID, &client.Name, &client.Contact, &client.DefaultRate, &client.Currency, &client.Notes,
+		&client.CreatedAt, &client.UpdatedAt,
+	)
+	return client, err
+}
+
+// CreateClient creates a new client
+func (s *ClientService) CreateClient(req models.CreateClientRequest) (*models.Client, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrClientNameRequired
+	}
+	if req.DefaultRate < 0 {
+		return nil, ErrNegativeHourlyRate
+	}
+	currency, err := normalizeClientCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO clients (name, contact, default_rate, currency, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING ` + clientColumns
+
+	now := time.Now().In(time.Local)
+
+	client, err := scanClient(s.db.QueryRow(query, name, req.Contact, req.DefaultRate, currency, req.Notes, now, now))
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
+
+// GetAllClients returns all clients sorted by name
+func (s *ClientService) GetAllClients() ([]models.Client, error) {
+	query := `
+		SELECT ` + clientColumns + `
+		FROM clients
+		ORDER BY name COLLATE NOCASE ASC
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clients := []models.Client{}
+	for rows.Next() {
+		client, err := scanClient(rows)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+
+	return clients, rows.Err()
+}
+
+// GetClientByID returns a client by ID
+func (s *ClientService) GetClientByID(id int) (*models.Client, error) {
+	query := `
+		SELECT ` + clientColumns + `
+		FROM clients
+		WHERE id = ?
+	`
+
+	client, err := scanClient(s.db.QueryRow(query, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
+
+// UpdateClient updates a client
+func (s *ClientService) UpdateClient(id int, req models.UpdateClientRequest) (*models.Client, error) {
+	setParts := []string{}
+	args := []interface{}{}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrClientNameRequired
+		}
+		setParts = append(setParts, "name = ?")
+		args = append(args, name)
+	}
+	if req.Contact != nil {
+		setParts = append(setParts, "contact = ?")
+		args = append(args, *req.Contact)
+	}
+	if req.DefaultRate != nil {
+		if *req.DefaultRate < 0 {
+			return nil, ErrNegativeHourlyRate
+		}
+		setParts = append(setParts, "default_rate = ?")
+		args = append(args, *req.DefaultRate)
+	}
+	if req.Currency != nil {
+		currency, err := normalizeClientCurrency(*req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		setParts = append(setParts, "currency = ?")
+		args = append(args, currency)
+	}
+	if req.Notes != nil {
+		setParts = append(setParts, "notes = ?")
+		args = append(args, *req.Notes)
+	}
+
+	setParts = append(setParts, "updated_at = ?")
+	args = append(args, time.Now().In(time.Local))
+	args = append(args, id)
+
+	query := "UPDATE clients SET " + strings.Join(setParts, ", ") + " WHERE id = ?"
+
+	_, err := s.db.Exec(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetClientByID(id)
+}
+
+// DeleteClient deletes a client; its projects and project templates are kept without a client
+func (s *ClientService) DeleteClient(id int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE projects SET client_id = NULL WHERE client_id = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE project_templates SET client_id = NULL WHERE client_id = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM clients WHERE id = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetClientTotals returns the time tracked per client for time blocks starting in the date range.
+// Projects without a client are grouped under client ID 0.
+func (s *ClientService) GetClientTotals(startDate, endDate time.Time) ([]models.ClientTotal, error) {
+	query := `
+		SELECT COALESCE(c.id, 0), COALESCE(c.name, ''), COALESCE(SUM(tb.duration), 0), COUNT(tb.id), COUNT(DISTINCT p.id)
+		FROM time_blocks tb
+		JOIN projects p ON tb.project_id = p.id
+		LEFT JOIN clients c ON p.client_id = c.id
+		WHERE tb.start_time >= ? AND tb.start_time <= ? AND tb.deleted_at IS NULL
+		GROUP BY c.id, c.name
+		ORDER BY 3 DESC, c.name COLLATE NOCASE ASC
+	`
+
+	rows, err := s.db.Query(query, startDate.In(time.Local), endDate.In(time.Local))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []models.ClientTotal{}
+	for rows.Next() {
+		var total models.ClientTotal
+		if err := rows.Scan(&total.ClientID, &total.ClientName, &total.Duration, &total.BlockCount, &total.ProjectCount); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+// checkClientExists returns sql.ErrNoRows when no client has the given ID
+func checkClientExists(q querier, id int) error {
+	var clientID int
+	return q.QueryRow("SELECT id FROM clients WHERE id = ?", id).Scan(&clientID)
+}
+
+// normalizeClientCurrency upper-cases a client currency; empty means the settings currency
+func normalizeClientCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency != "" && !currencyPattern.MatchString(currency) {
+		return "", ErrInvalidCurrency
+	}
+	return currency, nil
+}
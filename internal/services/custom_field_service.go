@@ -0,0 +1,493 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+var (
+	// ErrFieldNameRequired is returned when a custom field name is empty
+	ErrFieldNameRequired = errors.New("field name is required")
+	// ErrFieldNameTaken is returned when a custom field name is already used by another field
+	ErrFieldNameTaken = errors.New("a field with this name already exists")
+	// ErrInvalidFieldType is returned when a custom field is given an unknown type
+	ErrInvalidFieldType = errors.New("field type must be text, number, date or select")
+	// ErrFieldOptionsRequired is returned when a select field has no options
+	ErrFieldOptionsRequired = errors.New("a select field needs at least one option")
+	// ErrFieldValueNotNumber is returned when a number field is given something else
+	ErrFieldValueNotNumber = errors.New("field value must be a number")
+	// ErrFieldValueNotDate is returned when a date field is given something else
+	ErrFieldValueNotDate = errors.New("field value must be a date like 2024-01-31")
+	// ErrFieldValueNotOption is returned when a select field is given a value that is not one of its options
+	ErrFieldValueNotOption = errors.New("field value must be one of the field's options")
+)
+
+// fieldDateLayout is the canonical form of date field values
+const fieldDateLayout = "2006-01-02"
+
+// customFieldColumns is the column list shared by every custom field query
+const customFieldColumns = `
+	id, name, type, COALESCE(options, '[]'), COALESCE("order", 0), created_at, updated_at
+`
+
+// fieldValueExpr resolves a block's value of a custom field, its own before its project's; it needs the tb alias
+// and the field ID bound twice
+const fieldValueExpr = `COALESCE(
+	(SELECT tfv.value FROM time_block_field_values tfv WHERE tfv.time_block_id = tb.id AND tfv.field_id = ?),
+	(SELECT pfv.value FROM project_field_values pfv WHERE pfv.project_id = tb.project_id AND pfv.field_id = ?),
+	'')`
+
+// CustomFieldService handles custom field definitions and their values on projects and time blocks
+type CustomFieldService struct {
+	db *sql.DB
+}
+
+// NewCustomFieldService creates a new custom field service
+func NewCustomFieldService(db *sql.DB) *CustomFieldService {
+	return &CustomFieldService{db: db}
+}
+
+// scanCustomField scans a row selected with customFieldColumns
+func scanCustomField(row rowScanner) (models.CustomField, error) {
+	var field models.CustomField
+	var options string
+	err := row.Scan(&field.ID, &field.Name, &field.Type, &options, &field.Order, &field.CreatedAt, &field.UpdatedAt)
+	if err != nil {
+		return field, err
+	}
+	field.Options = []string{}
+	err = json.Unmarshal([]byte(options), &field.Options)
+	return field, err
+}
+
+// CreateCustomField defines a new custom field
+func (s *CustomFieldService) CreateCustomField(req models.CreateCustomFieldRequest) (*models.CustomField, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrFieldNameRequired
+	}
+	switch req.Type {
+	case models.FieldText, models.FieldNumber, models.FieldDate, models.FieldSelect:
+	default:
+		return nil, ErrInvalidFieldType
+	}
+	options, err := normalizeFieldOptions(req.Type, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNameFree(name, 0); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO custom_fields (name, type, options, "order", created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING ` + customFieldColumns
+
+	now := time.Now().In(time.Local)
+
+	field, err := scanCustomField(s.db.QueryRow(query, name, req.Type, options, req.Order, now, now))
+	if err != nil {
+		return nil, err
+	}
+
+	return &field, nil
+}
+
+// GetAllCustomFields returns every custom field in display order
+func (s *CustomFieldService) GetAllCustomFields() ([]models.CustomField, error) {
+	query := `
+		SELECT ` + customFieldColumns + `
+		FROM custom_fields
+		ORDER BY "order" ASC, name COLLATE NOCASE ASC
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields := []models.CustomField{}
+	for rows.Next() {
+		field, err := scanCustomField(rows)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, rows.Err()
+}
+
+// GetCustomFieldByID returns a custom field by ID
+func (s *CustomFieldService) GetCustomFieldByID(id int) (*models.CustomField, error) {
+	field, err := getCustomField(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// UpdateCustomField renames a custom field, replaces the options of a select field or moves it.
+// Values already stored keep their text, even when their option was removed.
+func (s *CustomFieldService) UpdateCustomField(id int, req models.UpdateCustomFieldRequest) (*models.CustomField, error) {
+	field, err := getCustomField(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	setParts := []string{}
+	args := []interface{}{}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrFieldNameRequired
+		}
+		if err := s.checkNameFree(name, id); err != nil {
+			return nil, err
+		}
+		setParts = append(setParts, "name = ?")
+		args = append(args, name)
+	}
+	if req.Options != nil {
+		options, err := normalizeFieldOptions(field.Type, req.Options)
+		if err != nil {
+			return nil, err
+		}
+		setParts = append(setParts, "options = ?")
+		args = append(args, options)
+	}
+	if req.Order != nil {
+		setParts = append(setParts, "\"order\" = ?")
+		args = append(args, *req.Order)
+	}
+
+	setParts = append(setParts, "updated_at = ?")
+	args = append(args, time.Now().In(time.Local))
+	args = append(args, id)
+
+	query := "UPDATE custom_fields SET " + strings.Join(setParts, ", ") + " WHERE id = ?"
+
+	if _, err := s.db.Exec(query, args...); err != nil {
+		return nil, err
+	}
+
+	return s.GetCustomFieldByID(id)
+}
+
+// DeleteCustomField deletes a custom field with its values on every project, time block and project template
+func (s *CustomFieldService) DeleteCustomField(id int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{
+		"DELETE FROM project_field_values WHERE field_id = ?",
+		"DELETE FROM time_block_field_values WHERE field_id = ?",
+		"DELETE FROM project_template_field_values WHERE field_id = ?",
+		"DELETE FROM custom_fields WHERE id = ?",
+	} {
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetProjectFieldValues replaces the custom field values of a project; empty values are left out
+func (s *CustomFieldService) SetProjectFieldValues(projectID int, values map[int]string) (map[int]string, error) {
+	var id int
+	if err := s.db.QueryRow("SELECT id FROM projects WHERE id = ? AND deleted_at IS NULL", projectID).Scan(&id); err != nil {
+		return nil, err
+	}
+	return s.setValues("projects", "project_field_values", "project_id", projectID, values)
+}
+
+// SetTimeBlockFieldValues replaces the custom field values set on a time block; empty values are left out
+func (s *CustomFieldService) SetTimeBlockFieldValues(timeBlockID int, values map[int]string) (map[int]string, error) {
+	var id int
+	if err := s.db.QueryRow("SELECT id FROM time_blocks WHERE id = ? AND deleted_at IS NULL", timeBlockID).Scan(&id); err != nil {
+		return nil, err
+	}
+	return s.setValues("time_blocks", "time_block_field_values", "time_block_id", timeBlockID, values)
+}
+
+// GetCustomFieldTotals returns the time tracked under each value of a custom field for time blocks starting in
+// the date range. A block counts under its own value, or its project's when it has none.
+func (s *CustomFieldService) GetCustomFieldTotals(fieldID int, startDate, endDate time.Time, filter *models.TimeBlockFilter) ([]models.CustomFieldTotal, error) {
+	if _, err := getCustomField(s.db, fieldID); err != nil {
+		return nil, err
+	}
+
+	filterSQL, filterArgs := filterClause(filter)
+
+	query := `
+		SELECT ` + fieldValueExpr + ` AS field_value, COALESCE(SUM(tb.duration), 0), COUNT(tb.id)
+		FROM time_blocks tb
+		JOIN projects p ON tb.project_id = p.id
+		WHERE tb.start_time >= ? AND tb.start_time <= ? AND tb.deleted_at IS NULL` + filterSQL + `
+		GROUP BY field_value
+		ORDER BY 2 DESC, field_value ASC
+	`
+
+	args := append([]interface{}{fieldID, fieldID, startDate.In(time.Local), endDate.In(time.Local)}, filterArgs...)
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []models.CustomFieldTotal{}
+	for rows.Next() {
+		var total models.CustomFieldTotal
+		if err := rows.Scan(&total.Value, &total.Duration, &total.BlockCount); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+// checkNameFree refuses a field name already used by another field, ignoring case
+func (s *CustomFieldService) checkNameFree(name string, exceptID int) error {
+	var id int
+	err := s.db.QueryRow("SELECT id FROM custom_fields WHERE name = ? COLLATE NOCASE AND id != ?", name, exceptID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return ErrFieldNameTaken
+}
+
+// setValues replaces the custom field values of one owner in a value table. A select value whose option
+// was removed since it was stored can be kept as it is.
+func (s *CustomFieldService) setValues(ownerTable, table, ownerColumn string, ownerID int, values map[int]string) (map[int]string, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Foreign keys are not enforced, so values for a missing or trashed owner are refused here
+	var owner int
+	if err := tx.QueryRow("SELECT id FROM "+ownerTable+" WHERE id = ? AND deleted_at IS NULL", ownerID).Scan(&owner); err != nil {
+		return nil, err
+	}
+
+	existing, err := loadFieldValues(tx, table, ownerColumn, []int{ownerID})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec("DELETE FROM "+table+" WHERE "+ownerColumn+" = ?", ownerID); err != nil {
+		return nil, err
+	}
+	for fieldID, raw := range values {
+		field, err := getCustomField(tx, fieldID)
+		if err != nil {
+			return nil, err
+		}
+		value, err := normalizeFieldValue(field, raw)
+		if err == ErrFieldValueNotOption && raw == existing[ownerID][fieldID] {
+			value, err = raw, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		query := "INSERT INTO " + table + " (" + ownerColumn + ", field_id, value) VALUES (?, ?, ?)"
+		if _, err := tx.Exec(query, ownerID, fieldID, value); err != nil {
+			return nil, err
+		}
+	}
+
+	stored, err := loadFieldValues(tx, table, ownerColumn, []int{ownerID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return stored[ownerID], nil
+}
+
+// getCustomField returns a custom field by ID
+func getCustomField(q querier, id int) (models.CustomField, error) {
+	return scanCustomField(q.QueryRow("SELECT "+customFieldColumns+" FROM custom_fields WHERE id = ?", id))
+}
+
+// normalizeFieldOptions trims and de-duplicates the options of a select field and encodes them for storage.
+// Other field types have no options.
+func normalizeFieldOptions(fieldType models.CustomFieldType, options []string) (string, error) {
+	normalized := []string{}
+	if fieldType == models.FieldSelect {
+		seen := map[string]bool{}
+		for _, option := range options {
+			option = strings.TrimSpace(option)
+			if option == "" || seen[option] {
+				continue
+			}
+			seen[option] = true
+			normalized = append(normalized, option)
+		}
+		if len(normalized) == 0 {
+			return "", ErrFieldOptionsRequired
+		}
+	}
+
+	encoded, err := json.Marshal(normalized)
+	return string(encoded), err
+}
+
+// normalizeFieldValue checks a value against the type of its field and returns its canonical text form.
+// An empty value means no value.
+func normalizeFieldValue(field models.CustomField, raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return "", nil
+	}
+
+	switch field.Type {
+	case models.FieldNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", ErrFieldValueNotNumber
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case models.FieldDate:
+		date, err := time.Parse(fieldDateLayout, value)
+		if err != nil {
+			return "", ErrFieldValueNotDate
+		}
+		return date.Format(fieldDateLayout), nil
+	case models.FieldSelect:
+		for _, option := range field.Options {
+			if option == value {
+				return value, nil
+			}
+		}
+		return "", ErrFieldValueNotOption
+	}
+	return value, nil
+}
+
+// fieldFilterClause narrows a range query on the tb alias to blocks whose resolved value of each field equals
+// the given one; an empty value matches blocks without a value
+func fieldFilterClause(values map[int]string) (string, []interface{}) {
+	fieldIDs := make([]int, 0, len(values))
+	for fieldID := range values {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	// A stable order keeps the generated SQL the same for the same filter
+	sort.Ints(fieldIDs)
+
+	clause := ""
+	args := []interface{}{}
+	for _, fieldID := range fieldIDs {
+		clause += " AND " + fieldValueExpr + " = ?"
+		args = append(args, fieldID, fieldID, strings.TrimSpace(values[fieldID]))
+	}
+	return clause, args
+}
+
+// attachProjectFields loads the custom field values of the given projects
+func attachProjectFields(q querier, projects []models.Project) error {
+	ids := make([]int, len(projects))
+	for i := range projects {
+		ids[i] = projects[i].ID
+	}
+
+	values, err := loadFieldValues(q, "project_field_values", "project_id", ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range projects {
+		projects[i].CustomFields = values[projects[i].ID]
+	}
+	return nil
+}
+
+// attachTimeBlockFields loads the custom field values set directly on the given time blocks
+func attachTimeBlockFields(q querier, timeBlocks []models.TimeBlock) error {
+	ids := make([]int, len(timeBlocks))
+	for i := range timeBlocks {
+		ids[i] = timeBlocks[i].ID
+	}
+
+	values, err := loadFieldValues(q, "time_block_field_values", "time_block_id", ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range timeBlocks {
+		timeBlocks[i].CustomFields = values[timeBlocks[i].ID]
+	}
+	return nil
+}
+
+// loadFieldValues returns the custom field values of each owner in a value table, every owner getting at least
+// an empty map
+func loadFieldValues(q querier, table, ownerColumn string, ownerIDs []int) (map[int]map[int]string, error) {
+	values := make(map[int]map[int]string, len(ownerIDs))
+	if len(ownerIDs) == 0 {
+		return values, nil
+	}
+
+	placeholders := make([]string, len(ownerIDs))
+	args := make([]interface{}, len(ownerIDs))
+	for i, id := range ownerIDs {
+		placeholders[i] = "?"
+		args[i] = id
+		values[id] = map[int]string{}
+	}
+
+	query := "SELECT " + ownerColumn + ", field_id, value FROM " + table +
+		" WHERE " + ownerColumn + " IN (" + strings.Join(placeholders, ", ") + ")"
+
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ownerID, fieldID int
+		var value string
+		if err := rows.Scan(&ownerID, &fieldID, &value); err != nil {
+			return nil, err
+		}
+		values[ownerID][fieldID] = value
+	}
+
+	return values, rows.Err()
+}
+
+// copyTimeBlockFields gives a time block the custom field values of another one, keeping values it already has
+func copyTimeBlockFields(q querier, fromID, toID int) error {
+	query := `
+		INSERT OR IGNORE INTO time_block_field_values (time_block_id, field_id, value)
+		SELECT ?, field_id, value FROM time_block_field_values WHERE time_block_id = ?
+	`
+	_, err := q.Exec(query, toID, fromID)
+	return err
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+var (
+	// ErrMilestoneTitleRequired is returned when a milestone title is empty
+	ErrMilestoneTitleRequired = errors.New("milestone title is required")
+	// ErrMilestoneDueDateRequired is returned when a milestone has no due date
+	ErrMilestoneDueDateRequired = errors.New("milestone due date is required")
+)
+
+// milestoneColumns is the column list shared by every milestone query
+const milestoneColumns = `
+	id, project_id, title, due_date, COALESCE(done, FALSE), estimated_hours, created_at, updated_at
+`
+
+// MilestoneService handles project milestones and the deadlines and reminders they share with projects
+type MilestoneService struct {
+	db              *sql.DB
+	settingsService *SettingsService
+}
+
+// NewMilestoneService creates a new milestone service
+func NewMilestoneService(db *sql.DB, settingsService *SettingsService) *MilestoneService {
+	return &MilestoneService{db: db, settingsService: settingsService}
+}
+
+// scanMilestone scans a row selected with milestoneColumns
+func scanMilestone(row rowScanner) (models.Milestone, error) {
+	var milestone models.Milestone
+	err := row.Scan(
+		&milestone.ID, &milestone.ProjectID, &milestone.Title, &milestone.DueDate, &milestone.Done,
+		&milestone.EstimatedHours, &milestone.CreatedAt, &milestone.UpdatedAt,
+	)
+	return milestone, err
+}
+
+// CreateMilestone adds a milestone to a project
+func (s *MilestoneService) CreateMilestone(req models.CreateMilestoneRequest) (*models.Milestone, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, ErrMilestoneTitleRequired
+	}
+	if req.DueDate.IsZero() {
+		return nil, ErrMilestoneDueDateRequired
+	}
+	estimatedHours, err := normalizeEstimate(req.EstimatedHours)
+	if err != nil {
+		return nil, err
+	}
+
+	var projectID int
+	if err := s.db.QueryRow("SELECT id FROM projects WHERE id = ? AND deleted_at IS NULL", req.ProjectID).Scan(&projectID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO milestones (project_id, title, due_date, done, estimated_hours, created_at, updated_at)
+		VALUES (?, ?, ?, FALSE, ?, ?, ?)
+		RETURNING ` + milestoneColumns
+
+	now := time.Now().In(time.Local)
+
+	milestone, err := scanMilestone(s.db.QueryRow(query, projectID, title, req.DueDate.In(time.Local), estimatedHours, now, now))
+	if err != nil {
+		return nil, err
+	}
+
+	return &milestone, nil
+}
+
+// GetProjectMilestones returns the milestones of a project by due date
+func (s *MilestoneService) GetProjectMilestones(projectID int) ([]models.Milestone, error) {
+	milestones, err := loadMilestones(s.db, []int{projectID})
+	if err != nil {
+		return nil, err
+	}
+	return milestones[projectID], nil
+}
+
+// UpdateMilestone updates a milestone; moving its due date sends its reminder again
+func (s *MilestoneService) UpdateMilestone(id int, req models.UpdateMilestoneRequest) (*models.Milestone, error) {
+	setParts := []string{}
+	args := []interface{}{}
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, ErrMilestoneTitleRequired
+		}
+		setParts = append(setParts, "title = ?")
+		args = append(args, title)
+	}
+	if req.DueDate != nil {
+		if req.DueDate.IsZero() {
+			return nil, ErrMilestoneDueDateRequired
+		}
+		setParts = append(setParts, "due_date = ?", "reminded = FALSE")
+		args = append(args, req.DueDate.In(time.Local))
+	}
+	if req.Done != nil {
+		setParts = append(setParts, "done = ?")
+		args = append(args, *req.Done)
+	}
+	if req.EstimatedHours != nil {
+		estimatedHours, err := normalizeEstimate(req.EstimatedHours)
+		if err != nil {
+			return nil, err
+		}
+		setParts = append(setParts, "estimated_hours = ?")
+		args = append(args, estimatedHours)
+	}
+
+	setParts = append(setParts, "updated_at = ?")
+	args = append(args, time.Now().In(time.Local))
+	args = append(args, id)
+
+	query := "UPDATE milestones SET " + strings.Join(setParts, ", ") + " WHERE id = ? RETURNING " + milestoneColumns
+
+	milestone, err := scanMilestone(s.db.QueryRow(query, args...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &milestone, nil
+}
+
+// DeleteMilestone deletes a milestone
+func (s *MilestoneService) DeleteMilestone(id int) error {
+	result, err := s.db.Exec("DELETE FROM milestones WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetDeadlinesByDateRange returns the project deadlines and milestones due in the date range, earliest first.
+// Projects that are archived or in the trash are left out.
+func (s *MilestoneService) GetDeadlinesByDateRange(startDate, endDate time.Time) ([]models.Deadline, error) {
+	deadlines, err := s.queryDeadlines(false)
+	if err != nil {
+		return nil, err
+	}
+
+	inRange := []models.Deadline{}
+	for _, deadline := range deadlines {
+		if !deadline.DueDate.Before(startDate) && !deadline.DueDate.After(endDate) {
+			inRange = append(inRange, deadline)
+		}
+	}
+	return inRange, nil
+}
+
+// CheckDeadlineReminders returns the open deadlines that came within the reminder window since the last check.
+// Each deadline reminds once; moving its date re-arms it.
+func (s *MilestoneService) CheckDeadlineReminders() ([]models.DeadlineReminder, error) {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	reminders := []models.DeadlineReminder{}
+	if settings.DeadlineReminderDays <= 0 {
+		return reminders, nil
+	}
+
+	deadlines, err := s.queryDeadlines(true)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	for _, deadline := range deadlines {
+		if deadline.Done {
+			continue
+		}
+		due := deadline.DueDate.In(time.Local)
+		dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.Local)
+		// Rounded, since a day across a daylight saving change is not 24 hours long
+		daysLeft := int(math.Round(dueDay.Sub(today).Hours() / 24))
+		if daysLeft < 0 || daysLeft > settings.DeadlineReminderDays {
+			continue
+		}
+
+		table, id, column := "projects", deadline.ProjectID, "deadline_reminded"
+		if deadline.MilestoneID != nil {
+			table, id, column = "milestones", *deadline.MilestoneID, "reminded"
+		}
+		if _, err := s.db.Exec("UPDATE "+table+" SET "+column+" = TRUE WHERE id = ?", id); err != nil {
+			return nil, err
+		}
+
+		reminders = append(reminders, models.DeadlineReminder{DaysLeft: daysLeft, Deadline: deadline})
+	}
+
+	return reminders, nil
+}
+
+// queryDeadlines returns the deadlines of the projects that are neither archived nor in the trash, earliest
+// first, optionally only those whose reminder was not sent yet
+func (s *MilestoneService) queryDeadlines(unremindedOnly bool) ([]models.Deadline, error) {
+	projectFilter, milestoneFilter := "", ""
+	if unremindedOnly {
+		projectFilter = " AND NOT COALESCE(p.deadline_reminded, FALSE)"
+		milestoneFilter = " AND NOT COALESCE(m.reminded, FALSE)"
+	}
+
+	query := `
+		SELECT p.id, p.name, NULL, p.name, p.deadline, COALESCE(p.status, 'active') = ?
+		FROM projects p
+		WHERE p.deadline IS NOT NULL AND p.deleted_at IS NULL AND COALESCE(p.status, 'active') != ?` + projectFilter + `
+		UNION ALL
+		SELECT p.id, p.name, m.id, m.title, m.due_date, COALESCE(m.done, FALSE) OR COALESCE(p.status, 'active') = ?
+		FROM milestones m
+		JOIN projects p ON p.id = m.project_id
+		WHERE p.deleted_at IS NULL AND COALESCE(p.status, 'active') != ?` + milestoneFilter
+
+	rows, err := s.db.Query(query, models.StatusCompleted, models.StatusArchived, models.StatusCompleted, models.StatusArchived)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deadlines := []models.Deadline{}
+	for rows.Next() {
+		var deadline models.Deadline
+		if err := rows.Scan(&deadline.ProjectID, &deadline.ProjectName, &deadline.MilestoneID, &deadline.Title, &deadline.DueDate, &deadline.Done); err != nil {
+			return nil, err
+		}
+		deadlines = append(deadlines, deadline)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Deadlines were stored with different time zones over time, so they are ordered as times rather than text
+	sort.SliceStable(deadlines, func(i, j int) bool {
+		return deadlines[i].DueDate.Before(deadlines[j].DueDate)
+	})
+	return deadlines, nil
+}
+
+// attachProjectMilestones fills in the milestones of each project
+func attachProjectMilestones(q querier, projects []models.Project) error {
+	ids := make([]int, len(projects))
+	for i := range projects {
+		ids[i] = projects[i].ID
+	}
+
+	milestones, err := loadMilestones(q, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range projects {
+		projects[i].Milestones = milestones[projects[i].ID]
+	}
+	return nil
+}
+
+// loadMilestones returns the milestones of each project by due date, every project getting at least an empty list
+func loadMilestones(q querier, projectIDs []int) (map[int][]models.Milestone, error) {
+	milestones := make(map[int][]models.Milestone, len(projectIDs))
+	if len(projectIDs) == 0 {
+		return milestones, nil
+	}
+
+	placeholders := make([]string, len(projectIDs))
+	args := make([]interface{}, len(projectIDs))
+	for i, id := range projectIDs {
+		placeholders[i] = "?"
+		args[i] = id
+		milestones[id] = []models.Milestone{}
+	}
+
+	query := `
+		SELECT ` + milestoneColumns + `
+		FROM milestones
+		WHERE project_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY due_date ASC, id ASC
+	`
+
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		milestone, err := scanMilestone(rows)
+		if err != nil {
+			return nil, err
+		}
+		milestones[milestone.ProjectID] = append(milestones[milestone.ProjectID], milestone)
+	}
+
+	return milestones, rows.Err()
+}
@@ -0,0 +1,333 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+// ErrPomodoroActive is returned when starting a Pomodoro session while one is running
+var ErrPomodoroActive = errors.New("a pomodoro session is already running")
+
+// PomodoroTickInterval is how often the Pomodoro schedule is checked
+const PomodoroTickInterval = time.Second
+
+// PomodoroService schedules work and break intervals on top of the timer
+type PomodoroService struct {
+	db              *sql.DB
+	timerService    *TimerService
+	settingsService *SettingsService
+	mu              sync.Mutex
+}
+
+// NewPomodoroService creates a new pomodoro service
+func NewPomodoroService(db *sql.DB, timerService *TimerService, settingsService *SettingsService) *PomodoroService {
+	return &PomodoroService{db: db, timerService: timerService, settingsService: settingsService}
+}
+
+// GetPomodoroState returns the current Pomodoro session state
+func (s *PomodoroService) GetPomodoroState() (*models.PomodoroState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.getState()
+}
+
+// StartPomodoro starts a Pomodoro session with a work interval on the given project
+func (s *PomodoroService) StartPomodoro(req models.StartPomodoroRequest) (*models.PomodoroState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.getState()
+	if err != nil {
+		return nil, err
+	}
+	if state.Phase != models.PomodoroIdle {
+		return nil, ErrPomodoroActive
+	}
+
+	if err := s.startWork(req.ProjectID, req.Description, 0); err != nil {
+		return nil, err
+	}
+
+	return s.getState()
+}
+
+// StopPomodoro ends the session, stopping the timer of an unfinished work interval
+func (s *PomodoroService) StopPomodoro() (*models.PomodoroState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.getState()
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Phase == models.PomodoroWork && s.ownsTimer(state) {
+		if _, err := s.timerService.StopTimer(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.setIdle(); err != nil {
+		return nil, err
+	}
+
+	return s.getState()
+}
+
+// Tick advances the schedule: it closes a finished work interval and starts the next one after a break.
+// It reports whether the phase changed so callers can notify the frontend.
+func (s *PomodoroService) Tick() (bool, *models.PomodoroState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.getState()
+	if err != nil {
+		return false, nil, err
+	}
+	if state.Phase == models.PomodoroIdle {
+		return false, state, nil
+	}
+
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return false, nil, err
+	}
+
+	now := time.Now().In(time.Local)
+
+	switch state.Phase {
+	case models.PomodoroWork:
+		timer, err := s.timerService.GetTimerState()
+		if err != nil {
+			return false, nil, err
+		}
+
+		// The timer was stopped or switched to another block by hand
+		if !s.ownsTimer(state) {
+			if err := s.setIdle(); err != nil {
+				return false, nil, err
+			}
+			break
+		}
+
+		workSeconds := settings.PomodoroWorkMinutes * 60
+		if timer.Elapsed < workSeconds {
+			return false, state, nil
+		}
+
+		// Close the block exactly when the interval was reached
+		endTime := now.Add(-time.Duration(timer.Elapsed-workSeconds) * time.Second)
+		timeBlock, err := s.timerService.StopTimerAt(endTime)
+		if err != nil {
+			return false, nil, err
+		}
+
+		query := "INSERT INTO pomodoro_cycles (time_block_id, project_id, started_at, completed_at) VALUES (?, ?, ?, ?)"
+		_, err = s.db.Exec(query, timeBlock.ID, timeBlock.ProjectID, timeBlock.StartTime, endTime)
+		if err != nil {
+			return false, nil, err
+		}
+
+		completed := state.CompletedCycles + 1
+		phase := models.PomodoroShortBreak
+		if every := settings.PomodoroLongBreakEvery; every > 0 && completed%every == 0 {
+			phase = models.PomodoroLongBreak
+		}
+
+		query = "UPDATE pomodoro_state SET phase = ?, time_block_id = NULL, phase_started_at = ?, completed_cycles = ?, updated_at = ? WHERE id = 1"
+		_, err = s.db.Exec(query, phase, endTime, completed, now)
+		if err != nil {
+			return false, nil, err
+		}
+
+	case models.PomodoroShortBreak, models.PomodoroLongBreak:
+		if state.PhaseStartedAt == nil || now.Before(state.PhaseStartedAt.Add(breakLength(state.Phase, settings))) {
+			return false, state, nil
+		}
+
+		timer, err := s.timerService.GetTimerState()
+		if err != nil {
+			return false, nil, err
+		}
+
+		// Don't take over a timer started by hand during the break
+		if timer.Status != models.TimerIdle || state.ProjectID == nil {
+			if err := s.setIdle(); err != nil {
+				return false, nil, err
+			}
+			break
+		}
+
+		if err := s.startWork(*state.ProjectID, state.Description, state.CompletedCycles); err != nil {
+			return false, nil, err
+		}
+	}
+
+	state, err = s.getState()
+	if err != nil {
+		return false, nil, err
+	}
+	return true, state, nil
+}
+
+// GetPomodoroCyclesByDate returns the work intervals completed on a specific date
+func (s *PomodoroService) GetPomodoroCyclesByDate(date time.Time) ([]models.PomodoroCycle, error) {
+	localDate := date.In(time.Local)
+	startOfDay := time.Date(localDate.Year(), localDate.Month(), localDate.Day(), 0, 0, 0, 0, time.Local)
+	endOfDay := startOfDay.Add(24 * time.Hour)
+
+	return s.getCycles(startOfDay, endOfDay)
+}
+
+// GetPomodoroCountsByDateRange returns the number of completed work intervals per day in a date range
+func (s *PomodoroService) GetPomodoroCountsByDateRange(startDate, endDate time.Time) ([]models.PomodoroDayCount, error) {
+	cycles, err := s.getCycles(startDate.In(time.Local), endDate.In(time.Local))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := []models.PomodoroDayCount{}
+	index := map[string]int{}
+	for _, cycle := range cycles {
+		day := cycle.CompletedAt.In(time.Local).Format("2006-01-02")
+		if i, ok := index[day]; ok {
+			counts[i].Cycles++
+			continue
+		}
+		index[day] = len(counts)
+		counts = append(counts, models.PomodoroDayCount{Date: day, Cycles: 1})
+	}
+
+	return counts, nil
+}
+
+// getCycles returns the cycles completed in [start, end)
+func (s *PomodoroService) getCycles(start, end time.Time) ([]models.PomodoroCycle, error) {
+	query := `
+		SELECT id, time_block_id, project_id, started_at, completed_at
+		FROM pomodoro_cycles
+		WHERE completed_at >= ? AND completed_at < ?
+		ORDER BY completed_at ASC
+	`
+
+	rows, err := s.db.Query(query, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cycles := []models.PomodoroCycle{}
+	for rows.Next() {
+		var cycle models.PomodoroCycle
+		err := rows.Scan(&cycle.ID, &cycle.TimeBlockID, &cycle.ProjectID, &cycle.StartedAt, &cycle.CompletedAt)
+		if err != nil {
+			return nil, err
+		}
+		cycles = append(cycles, cycle)
+	}
+
+	return cycles, rows.Err()
+}
+
+// startWork starts the timer for a new work interval
+func (s *PomodoroService) startWork(projectID int, description *string, completedCycles int) error {
+	timer, err := s.timerService.StartTimer(models.StartTimerRequest{ProjectID: projectID, Description: description})
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE pomodoro_state
+		SET phase = ?, project_id = ?, time_block_id = ?, description = ?, phase_started_at = ?, completed_cycles = ?, updated_at = ?
+		WHERE id = 1
+	`
+	now := time.Now().In(time.Local)
+	_, err = s.db.Exec(query, models.PomodoroWork, projectID, timer.TimeBlockID, description, now, completedCycles, now)
+	return err
+}
+
+// setIdle ends the Pomodoro session
+func (s *PomodoroService) setIdle() error {
+	query := `
+		UPDATE pomodoro_state
+		SET phase = ?, project_id = NULL, time_block_id = NULL, description = NULL, phase_started_at = NULL, completed_cycles = 0, updated_at = ?
+		WHERE id = 1
+	`
+	_, err := s.db.Exec(query, models.PomodoroIdle, time.Now().In(time.Local))
+	return err
+}
+
+// ownsTimer reports whether the timer is still tracking the current work interval
+func (s *PomodoroService) ownsTimer(state *models.PomodoroState) bool {
+	timer, err := s.timerService.GetTimerState()
+	if err != nil || timer.Status == models.TimerIdle || timer.TimeBlockID == nil || state.TimeBlockID == nil {
+		return false
+	}
+	return *timer.TimeBlockID == *state.TimeBlockID
+}
+
+// getState reads the Pomodoro state and computes the remaining time and today's count
+func (s *PomodoroService) getState() (*models.PomodoroState, error) {
+	query := `
+		SELECT COALESCE(phase, 'idle'), project_id, time_block_id, description, phase_started_at,
+		       COALESCE(completed_cycles, 0), updated_at
+		FROM pomodoro_state
+		WHERE id = 1
+	`
+
+	var state models.PomodoroState
+	err := s.db.QueryRow(query).Scan(
+		&state.Phase, &state.ProjectID, &state.TimeBlockID, &state.Description, &state.PhaseStartedAt,
+		&state.CompletedCycles, &state.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	today, err := s.GetPomodoroCyclesByDate(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	state.CompletedToday = len(today)
+
+	if state.Phase == models.PomodoroIdle {
+		return &state, nil
+	}
+
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	switch state.Phase {
+	case models.PomodoroWork:
+		timer, err := s.timerService.GetTimerState()
+		if err != nil {
+			return nil, err
+		}
+		state.Remaining = settings.PomodoroWorkMinutes*60 - timer.Elapsed
+	case models.PomodoroShortBreak, models.PomodoroLongBreak:
+		if state.PhaseStartedAt != nil {
+			ends := state.PhaseStartedAt.Add(breakLength(state.Phase, settings))
+			state.Remaining = int(time.Until(ends).Seconds())
+		}
+	}
+	if state.Remaining < 0 {
+		state.Remaining = 0
+	}
+
+	return &state, nil
+}
+
+// breakLength returns the configured length of a break phase
+func breakLength(phase models.PomodoroPhase, settings *models.Settings) time.Duration {
+	if phase == models.PomodoroLongBreak {
+		return time.Duration(settings.PomodoroLongBreakMinutes) * time.Minute
+	}
+	return time.Duration(settings.PomodoroShortBreakMinutes) * time.Minute
+}
@@ -2,11 +2,47 @@ package services
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
 	"time"
 
 	"ThinkTimerV2/internal/models"
 )
 
+var (
+	// ErrNegativeHourlyRate is returned when an hourly rate is below zero
+	ErrNegativeHourlyRate = errors.New("hourly rate cannot be negative")
+	// ErrNegativeEstimate is returned when an hour budget is below zero
+	ErrNegativeEstimate = errors.New("estimated hours cannot be negative")
+	// ErrInvalidProjectStatus is returned when a project is given an unknown status, or archived through an update
+	ErrInvalidProjectStatus = errors.New("project status must be active, paused or completed; use archiving to archive a project")
+	// ErrLinkURLRequired is returned when a project link has no URL
+	ErrLinkURLRequired = errors.New("link URL is required")
+	// ErrInvalidLinkKind is returned when a project link is given an unknown kind
+	ErrInvalidLinkKind = errors.New("link kind must be web or discord")
+	// ErrTemplateNameRequired is returned when a project template name is empty
+	ErrTemplateNameRequired = errors.New("template name is required")
+	// ErrTemplateNameTaken is returned when a project template name is already used by another template
+	ErrTemplateNameTaken = errors.New("a template with this name already exists")
+)
+
+// projectColumns is the column list shared by every project query
+const projectColumns = `
+	id, name, description, directory, deadline, status, "order",
+	COALESCE(billable, FALSE), COALESCE(hourly_rate, 0), client_id, estimated_hours, archived_at, deleted_at, created_at, updated_at
+`
+
+// scanProject scans a row selected with projectColumns
+func scanProject(row rowScanner) (models.Project, error) {
+	var project models.Project
+	err := row.Scan(
+		&project.ID, &project.Name, &project.Description, &project.Directory,
+		&project.Deadline, &project.Status, &project.Order, &project.Billable, &project.HourlyRate, &project.ClientID, &project.EstimatedHours, &project.ArchivedAt, &project.DeletedAt, &project.CreatedAt, &project.UpdatedAt,
+	)
+	return project, err
+}
+
 // ProjectService handles project operations
 type ProjectService struct {
 	db *sql.DB
@@ -19,36 +55,72 @@ func NewProjectService(db *sql.DB) *ProjectService {
 
 // CreateProject creates a new project
 func (s *ProjectService) CreateProject(req models.CreateProjectRequest) (*models.Project, error) {
+	if req.HourlyRate < 0 {
+		return nil, ErrNegativeHourlyRate
+	}
+	estimatedHours, err := normalizeEstimate(req.EstimatedHours)
+	if err != nil {
+		return nil, err
+	}
+	clientID := req.ClientID
+	if clientID != nil && *clientID == 0 {
+		clientID = nil
+	}
+	if clientID != nil {
+		if err := checkClientExists(s.db, *clientID); err != nil {
+			return nil, err
+		}
+	}
+
 	query := `
-		INSERT INTO projects (name, description, url1, url2, url3, discord, directory, deadline, "order", created_at, updated_at)
+		INSERT INTO projects (name, description, directory, deadline, "order", billable, hourly_rate, client_id, estimated_hours, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		RETURNING id, name, description, url1, url2, url3, discord, directory, deadline, status, "order", created_at, updated_at
-	`
+		RETURNING ` + projectColumns
 
 	now := time.Now()
 
-	var project models.Project
-	err := s.db.QueryRow(query, req.Name, req.Description, req.URL1, req.URL2, req.URL3, req.Discord, req.Directory, req.Deadline, req.Order, now, now).Scan(
-		&project.ID, &project.Name, &project.Description, &project.URL1, &project.URL2, &project.URL3, &project.Discord, &project.Directory,
-		&project.Deadline, &project.Status, &project.Order, &project.CreatedAt, &project.UpdatedAt,
-	)
+	project, err := scanProject(s.db.QueryRow(query, req.Name, req.Description, req.Directory,
+		req.Deadline, req.Order, req.Billable, req.HourlyRate, clientID, estimatedHours, now, now))
 
 	if err != nil {
 		return nil, err
 	}
+	project.Tags = []models.Tag{}
+	project.Links = []models.ProjectLink{}
+	project.CustomFields = map[int]string{}
+	project.Milestones = []models.Milestone{}
 
 	return &project, nil
 }
 
-// GetAllProjects returns all projects
+// GetAllProjects returns all projects that are not archived
 func (s *ProjectService) GetAllProjects() ([]models.Project, error) {
 	query := `
-		SELECT id, name, description, url1, url2, url3, discord, directory, deadline, status, "order", created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
+		WHERE COALESCE(status, 'active') != ? AND deleted_at IS NULL
 		ORDER BY "order" ASC, created_at DESC
 	`
 
-	rows, err := s.db.Query(query)
+	return s.queryProjects(query, models.StatusArchived)
+}
+
+// GetArchivedProjects returns the archived projects, most recently archived first
+func (s *ProjectService) GetArchivedProjects() ([]models.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		WHERE status = ? AND deleted_at IS NULL
+		ORDER BY archived_at DESC, name ASC
+	`
+
+	return s.queryProjects(query, models.StatusArchived)
+}
+
+// queryProjects runs a query selecting projectColumns and attaches the tags, links, custom field values and milestones
+// of the result
+func (s *ProjectService) queryProjects(query string, args ...interface{}) ([]models.Project, error) {
+	rows, err := s.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -56,16 +128,28 @@ func (s *ProjectService) GetAllProjects() ([]models.Project, error) {
 
 	var projects []models.Project
 	for rows.Next() {
-		var project models.Project
-		err := rows.Scan(
-			&project.ID, &project.Name, &project.Description, &project.URL1, &project.URL2, &project.URL3, &project.Discord, &project.Directory,
-			&project.Deadline, &project.Status, &project.Order, &project.CreatedAt, &project.UpdatedAt,
-		)
+		project, err := scanProject(rows)
 		if err != nil {
 			return nil, err
 		}
 		projects = append(projects, project)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := attachProjectTags(s.db, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectLinks(s.db, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectFields(s.db, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectMilestones(s.db, projects); err != nil {
+		return nil, err
+	}
 
 	return projects, nil
 }
@@ -73,22 +157,32 @@ func (s *ProjectService) GetAllProjects() ([]models.Project, error) {
 // GetProjectByID returns a project by ID
 func (s *ProjectService) GetProjectByID(id int) (*models.Project, error) {
 	query := `
-		SELECT id, name, description, url1, url2, url3, discord, directory, deadline, status, "order", created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
-	var project models.Project
-	err := s.db.QueryRow(query, id).Scan(
-		&project.ID, &project.Name, &project.Description, &project.URL1, &project.URL2, &project.URL3, &project.Discord, &project.Directory,
-		&project.Deadline, &project.Status, &project.Order, &project.CreatedAt, &project.UpdatedAt,
-	)
+	project, err := scanProject(s.db.QueryRow(query, id))
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &project, nil
+	projects := []models.Project{project}
+	if err := attachProjectTags(s.db, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectLinks(s.db, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectFields(s.db, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectMilestones(s.db, projects); err != nil {
+		return nil, err
+	}
+
+	return &projects[0], nil
 }
 
 // UpdateProject updates a project
@@ -105,38 +199,59 @@ func (s *ProjectService) UpdateProject(id int, req models.UpdateProjectRequest)
 		setParts = append(setParts, "description = ?")
 		args = append(args, *req.Description)
 	}
-	if req.URL1 != nil {
-		setParts = append(setParts, "url1 = ?")
-		args = append(args, *req.URL1)
-	}
-	if req.URL2 != nil {
-		setParts = append(setParts, "url2 = ?")
-		args = append(args, *req.URL2)
-	}
-	if req.URL3 != nil {
-		setParts = append(setParts, "url3 = ?")
-		args = append(args, *req.URL3)
-	}
-	if req.Discord != nil {
-		setParts = append(setParts, "discord = ?")
-		args = append(args, *req.Discord)
-	}
 	if req.Directory != nil {
 		setParts = append(setParts, "directory = ?")
 		args = append(args, *req.Directory)
 	}
 	if req.Deadline != nil {
-		setParts = append(setParts, "deadline = ?")
+		// A moved deadline is announced again
+		setParts = append(setParts, "deadline = ?", "deadline_reminded = FALSE")
 		args = append(args, *req.Deadline)
 	}
 	if req.Status != nil {
-		setParts = append(setParts, "status = ?")
+		switch *req.Status {
+		case models.StatusActive, models.StatusPaused, models.StatusCompleted:
+		default:
+			return nil, ErrInvalidProjectStatus
+		}
+		// Setting a status by hand also takes the project out of the archive
+		setParts = append(setParts, "status = ?", "archived_at = NULL", "status_before_archive = NULL")
 		args = append(args, *req.Status)
 	}
 	if req.Order != nil {
 		setParts = append(setParts, "\"order\" = ?")
 		args = append(args, *req.Order)
 	}
+	if req.Billable != nil {
+		setParts = append(setParts, "billable = ?")
+		args = append(args, *req.Billable)
+	}
+	if req.HourlyRate != nil {
+		if *req.HourlyRate < 0 {
+			return nil, ErrNegativeHourlyRate
+		}
+		setParts = append(setParts, "hourly_rate = ?")
+		args = append(args, *req.HourlyRate)
+	}
+	if req.ClientID != nil {
+		if *req.ClientID == 0 {
+			setParts = append(setParts, "client_id = NULL")
+		} else {
+			if err := checkClientExists(s.db, *req.ClientID); err != nil {
+				return nil, err
+			}
+			setParts = append(setParts, "client_id = ?")
+			args = append(args, *req.ClientID)
+		}
+	}
+	if req.EstimatedHours != nil {
+		estimatedHours, err := normalizeEstimate(req.EstimatedHours)
+		if err != nil {
+			return nil, err
+		}
+		setParts = append(setParts, "estimated_hours = ?")
+		args = append(args, estimatedHours)
+	}
 
 	setParts = append(setParts, "updated_at = ?")
 	args = append(args, time.Now())
@@ -146,21 +261,78 @@ func (s *ProjectService) UpdateProject(id int, req models.UpdateProjectRequest)
 	for i := 1; i < len(setParts); i++ {
 		query += ", " + setParts[i]
 	}
-	query += " WHERE id = ?"
+	// Projects in the trash are restored before they can be edited
+	query += " WHERE id = ? AND deleted_at IS NULL"
 
-	_, err := s.db.Exec(query, args...)
+	result, err := s.db.Exec(query, args...)
 	if err != nil {
 		return nil, err
 	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return s.GetProjectByID(id)
+}
+
+// ArchiveProject hides a project from the project list while keeping its time blocks in reports
+func (s *ProjectService) ArchiveProject(id int) (*models.Project, error) {
+	query := `
+		UPDATE projects
+		SET status_before_archive = status, status = ?, archived_at = ?, updated_at = ?
+		WHERE id = ? AND COALESCE(status, 'active') != ? AND deleted_at IS NULL
+	`
+
+	now := time.Now().In(time.Local)
+	if _, err := s.db.Exec(query, models.StatusArchived, now, now, id, models.StatusArchived); err != nil {
+		return nil, err
+	}
 
 	return s.GetProjectByID(id)
 }
 
-// DeleteProject deletes a project
+// RestoreProject brings an archived project back with the status it had before archiving
+func (s *ProjectService) RestoreProject(id int) (*models.Project, error) {
+	query := `
+		UPDATE projects
+		SET status = COALESCE(status_before_archive, ?), status_before_archive = NULL, archived_at = NULL, updated_at = ?
+		WHERE id = ? AND status = ? AND deleted_at IS NULL
+	`
+
+	if _, err := s.db.Exec(query, models.StatusActive, time.Now().In(time.Local), id, models.StatusArchived); err != nil {
+		return nil, err
+	}
+
+	return s.GetProjectByID(id)
+}
+
+// DeleteProject moves a project and its time blocks to the trash. The blocks get the project's deletion time,
+// which is how restoring the project tells them apart from blocks that were trashed on their own.
 func (s *ProjectService) DeleteProject(id int) error {
-	query := "DELETE FROM projects WHERE id = ?"
-	_, err := s.db.Exec(query, id)
-	return err
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().In(time.Local)
+	result, err := tx.Exec("UPDATE projects SET deleted_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL", now, now, id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.Exec("UPDATE time_blocks SET deleted_at = ? WHERE project_id = ? AND deleted_at IS NULL", now, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // UpdateProjectsOrder updates the order of multiple projects
@@ -180,3 +352,645 @@ func (s *ProjectService) UpdateProjectsOrder(projectOrders map[int]int) error {
 
 	return tx.Commit()
 }
+
+// projectLinkColumns is the column list shared by every project link query
+const projectLinkColumns = `
+	id, project_id, label, url, kind, "order", created_at, updated_at
+`
+
+// scanProjectLink scans a row selected with projectLinkColumns
+func scanProjectLink(row rowScanner) (models.ProjectLink, error) {
+	var link models.ProjectLink
+	err := row.Scan(&link.ID, &link.ProjectID, &link.Label, &link.URL, &link.Kind, &link.Order, &link.CreatedAt, &link.UpdatedAt)
+	return link, err
+}
+
+// CreateProjectLink adds a link to a project
+func (s *ProjectService) CreateProjectLink(req models.CreateProjectLinkRequest) (*models.ProjectLink, error) {
+	url := strings.TrimSpace(req.URL)
+	if url == "" {
+		return nil, ErrLinkURLRequired
+	}
+	kind, err := normalizeLinkKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var projectID int
+	if err := s.db.QueryRow("SELECT id FROM projects WHERE id = ? AND deleted_at IS NULL", req.ProjectID).Scan(&projectID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO project_links (project_id, label, url, kind, "order", created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING ` + projectLinkColumns
+
+	now := time.Now().In(time.Local)
+
+	link, err := scanProjectLink(s.db.QueryRow(query, projectID, strings.TrimSpace(req.Label), url, kind, req.Order, now, now))
+	if err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// GetProjectLinks returns the links of a project in display order
+func (s *ProjectService) GetProjectLinks(projectID int) ([]models.ProjectLink, error) {
+	links, err := loadProjectLinks(s.db, []int{projectID})
+	if err != nil {
+		return nil, err
+	}
+	return links[projectID], nil
+}
+
+// UpdateProjectLink updates a project link
+func (s *ProjectService) UpdateProjectLink(id int, req models.UpdateProjectLinkRequest) (*models.ProjectLink, error) {
+	setParts := []string{}
+	args := []interface{}{}
+
+	if req.Label != nil {
+		setParts = append(setParts, "label = ?")
+		args = append(args, strings.TrimSpace(*req.Label))
+	}
+	if req.URL != nil {
+		url := strings.TrimSpace(*req.URL)
+		if url == "" {
+			return nil, ErrLinkURLRequired
+		}
+		setParts = append(setParts, "url = ?")
+		args = append(args, url)
+	}
+	if req.Kind != nil {
+		kind, err := normalizeLinkKind(*req.Kind)
+		if err != nil {
+			return nil, err
+		}
+		setParts = append(setParts, "kind = ?")
+		args = append(args, kind)
+	}
+	if req.Order != nil {
+		setParts = append(setParts, "\"order\" = ?")
+		args = append(args, *req.Order)
+	}
+
+	setParts = append(setParts, "updated_at = ?")
+	args = append(args, time.Now().In(time.Local))
+	args = append(args, id)
+
+	query := "UPDATE project_links SET " + strings.Join(setParts, ", ") + " WHERE id = ? RETURNING " + projectLinkColumns
+
+	link, err := scanProjectLink(s.db.QueryRow(query, args...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// DeleteProjectLink removes a link from its project
+func (s *ProjectService) DeleteProjectLink(id int) error {
+	result, err := s.db.Exec("DELETE FROM project_links WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UpdateProjectLinksOrder updates the order of multiple project links
+func (s *ProjectService) UpdateProjectLinksOrder(linkOrders map[int]int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for id, order := range linkOrders {
+		_, err := tx.Exec(`UPDATE project_links SET "order" = ?, updated_at = ? WHERE id = ?`, order, time.Now().In(time.Local), id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// normalizeLinkKind validates a link kind, defaulting an empty one to web
+func normalizeLinkKind(kind models.LinkKind) (models.LinkKind, error) {
+	switch kind {
+	case "":
+		return models.LinkKindWeb, nil
+	case models.LinkKindWeb, models.LinkKindDiscord:
+		return kind, nil
+	}
+	return "", ErrInvalidLinkKind
+}
+
+// attachProjectLinks loads the links of the given projects
+func attachProjectLinks(q querier, projects []models.Project) error {
+	ids := make([]int, len(projects))
+	for i := range projects {
+		ids[i] = projects[i].ID
+	}
+
+	links, err := loadProjectLinks(q, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range projects {
+		projects[i].Links = links[projects[i].ID]
+	}
+	return nil
+}
+
+// loadProjectLinks returns the links of each project in display order, every project getting at least an empty list
+func loadProjectLinks(q querier, projectIDs []int) (map[int][]models.ProjectLink, error) {
+	links := make(map[int][]models.ProjectLink, len(projectIDs))
+	if len(projectIDs) == 0 {
+		return links, nil
+	}
+
+	placeholders := make([]string, len(projectIDs))
+	args := make([]interface{}, len(projectIDs))
+	for i, id := range projectIDs {
+		placeholders[i] = "?"
+		args[i] = id
+		links[id] = []models.ProjectLink{}
+	}
+
+	query := `
+		SELECT ` + projectLinkColumns + `
+		FROM project_links
+		WHERE project_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY "order" ASC, id ASC
+	`
+
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		link, err := scanProjectLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links[link.ProjectID] = append(links[link.ProjectID], link)
+	}
+
+	return links, rows.Err()
+}
+
+// projectTemplateColumns is the column list shared by every project template query
+const projectTemplateColumns = `
+	id, name, description, directory, COALESCE(billable, FALSE), COALESCE(hourly_rate, 0), client_id, estimated_hours,
+	COALESCE(links, '[]'), COALESCE(tasks, '[]'), created_at, updated_at
+`
+
+// scanProjectTemplate scans a row selected with projectTemplateColumns
+func scanProjectTemplate(row rowScanner) (models.ProjectTemplate, error) {
+	var template models.ProjectTemplate
+	var links, tasks string
+	err := row.Scan(
+		&template.ID, &template.Name, &template.Description, &template.Directory, &template.Billable, &template.HourlyRate,
+		&template.ClientID, &template.EstimatedHours, &links, &tasks, &template.CreatedAt, &template.UpdatedAt,
+	)
+	if err != nil {
+		return template, err
+	}
+	template.Links = []models.TemplateLink{}
+	template.Tasks = []models.TemplateTask{}
+	if err := json.Unmarshal([]byte(links), &template.Links); err != nil {
+		return template, err
+	}
+	err = json.Unmarshal([]byte(tasks), &template.Tasks)
+	return template, err
+}
+
+// DuplicateProject creates a copy of a project with its links, tags, tasks and custom field values but none of
+// its time blocks. The copy is named after the project and its directory follows the new name.
+func (s *ProjectService) DuplicateProject(id int) (*models.Project, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	template, err := projectBlueprint(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	newID, err := createProjectFromTemplate(tx, template, template.Name+" (copy)")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetProjectByID(newID)
+}
+
+// SaveProjectAsTemplate saves what a duplicate of a project would copy as a named template
+func (s *ProjectService) SaveProjectAsTemplate(projectID int, name string) (*models.ProjectTemplate, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrTemplateNameRequired
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var existingID int
+	err = tx.QueryRow("SELECT id FROM project_templates WHERE name = ? COLLATE NOCASE", name).Scan(&existingID)
+	if err == nil {
+		return nil, ErrTemplateNameTaken
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	template, err := projectBlueprint(tx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := json.Marshal(template.Links)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := json.Marshal(template.Tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO project_templates (name, description, directory, billable, hourly_rate, client_id, estimated_hours, links, tasks, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	now := time.Now().In(time.Local)
+
+	var id int
+	err = tx.QueryRow(query, name, template.Description, template.Directory, template.Billable, template.HourlyRate,
+		template.ClientID, template.EstimatedHours, string(links), string(tasks), now, now).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range template.Tags {
+		if _, err := tx.Exec("INSERT INTO project_template_tags (template_id, tag_id) VALUES (?, ?)", id, tag.ID); err != nil {
+			return nil, err
+		}
+	}
+	for fieldID, value := range template.CustomFields {
+		_, err := tx.Exec("INSERT INTO project_template_field_values (template_id, field_id, value) VALUES (?, ?, ?)", id, fieldID, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetProjectTemplateByID(id)
+}
+
+// GetAllProjectTemplates returns the saved project templates by name
+func (s *ProjectService) GetAllProjectTemplates() ([]models.ProjectTemplate, error) {
+	query := `
+		SELECT ` + projectTemplateColumns + `
+		FROM project_templates
+		ORDER BY name COLLATE NOCASE ASC
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []models.ProjectTemplate{}
+	for rows.Next() {
+		template, err := scanProjectTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := attachTemplateTagsAndFields(s.db, templates); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// GetProjectTemplateByID returns a project template
+func (s *ProjectService) GetProjectTemplateByID(id int) (*models.ProjectTemplate, error) {
+	template, err := getProjectTemplate(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// DeleteProjectTemplate deletes a project template; projects created from it are kept
+func (s *ProjectService) DeleteProjectTemplate(id int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{
+		"DELETE FROM project_template_tags WHERE template_id = ?",
+		"DELETE FROM project_template_field_values WHERE template_id = ?",
+	} {
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.Exec("DELETE FROM project_templates WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
+
+// CreateProjectFromTemplate creates a project from a template; an empty name takes the template's name
+func (s *ProjectService) CreateProjectFromTemplate(templateID int, name string) (*models.Project, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	template, err := getProjectTemplate(tx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = template.Name
+	}
+
+	id, err := createProjectFromTemplate(tx, &template, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetProjectByID(id)
+}
+
+// getProjectTemplate returns a project template with its tags and custom field values
+func getProjectTemplate(q querier, id int) (models.ProjectTemplate, error) {
+	query := `
+		SELECT ` + projectTemplateColumns + `
+		FROM project_templates
+		WHERE id = ?
+	`
+
+	template, err := scanProjectTemplate(q.QueryRow(query, id))
+	if err != nil {
+		return template, err
+	}
+
+	templates := []models.ProjectTemplate{template}
+	if err := attachTemplateTagsAndFields(q, templates); err != nil {
+		return template, err
+	}
+	return templates[0], nil
+}
+
+// attachTemplateTagsAndFields fills in the tags and custom field values of each template
+func attachTemplateTagsAndFields(q querier, templates []models.ProjectTemplate) error {
+	ids := make([]int, len(templates))
+	for i := range templates {
+		ids[i] = templates[i].ID
+	}
+
+	tags, err := loadTags(q, "project_template_tags", "template_id", ids)
+	if err != nil {
+		return err
+	}
+	values, err := loadFieldValues(q, "project_template_field_values", "template_id", ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range templates {
+		templates[i].Tags = tags[templates[i].ID]
+		templates[i].CustomFields = values[templates[i].ID]
+	}
+	return nil
+}
+
+// projectBlueprint returns what a copy of a project starts from, as an unsaved template named after the project.
+// A last directory element equal to the project's name becomes the {name} placeholder.
+func projectBlueprint(q querier, projectID int) (*models.ProjectTemplate, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	project, err := scanProject(q.QueryRow(query, projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	projects := []models.Project{project}
+	if err := attachProjectTags(q, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectLinks(q, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectFields(q, projects); err != nil {
+		return nil, err
+	}
+	project = projects[0]
+
+	template := &models.ProjectTemplate{
+		Name:           project.Name,
+		Description:    project.Description,
+		Directory:      project.Directory,
+		Billable:       project.Billable,
+		HourlyRate:     project.HourlyRate,
+		ClientID:       project.ClientID,
+		EstimatedHours: project.EstimatedHours,
+		Links:          []models.TemplateLink{},
+		Tags:           project.Tags,
+		CustomFields:   project.CustomFields,
+	}
+	if project.Directory != nil && project.Name != "" {
+		pattern := directoryPattern(*project.Directory, project.Name)
+		template.Directory = &pattern
+	}
+	for _, link := range project.Links {
+		template.Links = append(template.Links, models.TemplateLink{Label: link.Label, URL: link.URL, Kind: link.Kind})
+	}
+
+	rows, err := q.Query(`SELECT `+taskColumns+` FROM tasks WHERE project_id = ? ORDER BY "order" ASC, id ASC`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	template.Tasks = templateTasks(buildTaskTree(tasks, nil, nil))
+
+	return template, nil
+}
+
+// directoryPattern turns a project directory into a template pattern. Only a final path element equal to the
+// project name becomes {name}; both slashes separate elements, since the directory may come from any platform.
+func directoryPattern(directory, name string) string {
+	trimmed := strings.TrimRight(directory, `/\`)
+	base := trimmed[strings.LastIndexAny(trimmed, `/\`)+1:]
+	if base != name {
+		return directory
+	}
+	return trimmed[:len(trimmed)-len(base)] + "{name}" + directory[len(trimmed):]
+}
+
+// templateTasks converts a task tree to the tasks a template keeps
+func templateTasks(tasks []models.Task) []models.TemplateTask {
+	result := make([]models.TemplateTask, len(tasks))
+	for i, task := range tasks {
+		result[i] = models.TemplateTask{
+			Name:           task.Name,
+			Description:    task.Description,
+			EstimatedHours: task.EstimatedHours,
+			Children:       templateTasks(task.Children),
+		}
+	}
+	return result
+}
+
+// createProjectFromTemplate creates a project named name from a template and returns its ID. Tags, custom
+// fields and a client that were deleted since the template was made are left out.
+func createProjectFromTemplate(tx *sql.Tx, template *models.ProjectTemplate, name string) (int, error) {
+	clientID := template.ClientID
+	clientName := ""
+	if clientID != nil {
+		err := tx.QueryRow("SELECT name FROM clients WHERE id = ?", *clientID).Scan(&clientName)
+		if err == sql.ErrNoRows {
+			clientID = nil
+		} else if err != nil {
+			return 0, err
+		}
+	}
+
+	now := time.Now().In(time.Local)
+
+	directory := template.Directory
+	if directory != nil {
+		expanded := strings.NewReplacer(
+			"{name}", name,
+			"{client}", clientName,
+			"{year}", now.Format("2006"),
+			"{date}", now.Format("2006-01-02"),
+		).Replace(*directory)
+		directory = &expanded
+	}
+
+	query := `
+		INSERT INTO projects (name, description, directory, "order", billable, hourly_rate, client_id, estimated_hours, created_at, updated_at)
+		VALUES (?, ?, ?, (SELECT COALESCE(MAX("order"), -1) + 1 FROM projects), ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	var id int
+	err := tx.QueryRow(query, name, template.Description, directory, template.Billable, template.HourlyRate,
+		clientID, template.EstimatedHours, now, now).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	for order, link := range template.Links {
+		_, err := tx.Exec(`
+			INSERT INTO project_links (project_id, label, url, kind, "order", created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, id, link.Label, link.URL, link.Kind, order, now, now)
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, tag := range template.Tags {
+		if _, err := tx.Exec("INSERT OR IGNORE INTO project_tags (project_id, tag_id) SELECT ?, id FROM tags WHERE id = ?", id, tag.ID); err != nil {
+			return 0, err
+		}
+	}
+	for fieldID, value := range template.CustomFields {
+		_, err := tx.Exec("INSERT INTO project_field_values (project_id, field_id, value) SELECT ?, id, ? FROM custom_fields WHERE id = ?", id, value, fieldID)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := insertTemplateTasks(tx, id, nil, template.Tasks, now); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// insertTemplateTasks creates the tasks of a template under parentID, open and in template order
+func insertTemplateTasks(tx *sql.Tx, projectID int, parentID *int, tasks []models.TemplateTask, now time.Time) error {
+	query := `
+		INSERT INTO tasks (project_id, parent_id, name, description, done, "order", estimated_hours, created_at, updated_at)
+		VALUES (?, ?, ?, ?, FALSE, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	for order, task := range tasks {
+		var id int
+		if err := tx.QueryRow(query, projectID, parentID, task.Name, task.Description, order, task.EstimatedHours, now, now).Scan(&id); err != nil {
+			return err
+		}
+		if err := insertTemplateTasks(tx, projectID, &id, task.Children, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
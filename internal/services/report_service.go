@@ -0,0 +1,150 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+// ErrInvalidReportGrouping is returned when a report is requested with an unknown grouping
+var ErrInvalidReportGrouping = errors.New("report grouping must be day, week, month, project, tag or client")
+
+// localDayExpr is the local calendar date a block starts on. Start times are stored in local time, so the
+// date is cut from the stored text; SQLite's date functions would convert it to UTC first.
+const localDayExpr = `substr(tb.start_time, 1, 10)`
+
+// isoWeekExpr is the ISO week a block starts in, such as 2024-W07. The week belongs to the year of its
+// Thursday, which is three days after its Monday.
+const isoWeekExpr = `(
+	SELECT strftime('%Y', thursday) || '-W' || printf('%02d', (CAST(strftime('%j', thursday) AS INTEGER) - 1) / 7 + 1)
+	FROM (SELECT date(` + localDayExpr + `, '-' || ((CAST(strftime('%w', ` + localDayExpr + `) AS INTEGER) + 6) % 7) || ' days', '+3 days') AS thursday)
+)`
+
+// reportGroup holds the SQL of one report grouping. Its select list yields the group ID, key, label and
+// color, in that order, and it may need the tb, p, c, t and tagged aliases.
+type reportGroup struct {
+	columns    string
+	joins      string
+	joinsRange bool // The joins bind the start and end of the range before the rest of the query
+	groupBy    string
+	orderBy    string
+}
+
+// periodOrder and totalOrder sort report rows; the duration is the fifth column
+const (
+	periodOrder = `2 ASC`
+	totalOrder  = `5 DESC, 3 COLLATE NOCASE ASC`
+)
+
+var reportGroups = map[models.ReportGrouping]reportGroup{
+	models.ReportByDay: {
+		columns: `0, ` + localDayExpr + ` AS period, ` + localDayExpr + `, ''`,
+		groupBy: `period`,
+		orderBy: periodOrder,
+	},
+	models.ReportByWeek: {
+		columns: `0, ` + isoWeekExpr + ` AS period, ` + isoWeekExpr + `, ''`,
+		groupBy: `period`,
+		orderBy: periodOrder,
+	},
+	models.ReportByMonth: {
+		columns: `0, substr(tb.start_time, 1, 7) AS period, substr(tb.start_time, 1, 7), ''`,
+		groupBy: `period`,
+		orderBy: periodOrder,
+	},
+	models.ReportByProject: {
+		columns: `p.id, CAST(p.id AS TEXT), p.name, ''`,
+		groupBy: `p.id, p.name`,
+		orderBy: totalOrder,
+	},
+	models.ReportByClient: {
+		columns: `COALESCE(c.id, 0), CAST(COALESCE(c.id, 0) AS TEXT), COALESCE(c.name, ''), ''`,
+		joins:   `LEFT JOIN clients c ON p.client_id = c.id`,
+		groupBy: `c.id, c.name`,
+		orderBy: totalOrder,
+	},
+	// The project half of the tag links is limited to the range so it does not pair every tagged project
+	// with its whole history
+	models.ReportByTag: {
+		columns: `COALESCE(t.id, 0), CAST(COALESCE(t.id, 0) AS TEXT), COALESCE(t.name, ''), COALESCE(t.color, '')`,
+		joins: `LEFT JOIN (
+			SELECT tag_id, time_block_id FROM time_block_tags
+			UNION
+			SELECT pt.tag_id, ptb.id FROM project_tags pt JOIN time_blocks ptb ON ptb.project_id = pt.project_id
+			WHERE ptb.start_time >= ? AND ptb.start_time <= ?
+		) tagged ON tagged.time_block_id = tb.id
+		LEFT JOIN tags t ON t.id = tagged.tag_id`,
+		joinsRange: true,
+		groupBy:    `t.id, t.name, t.color`,
+		orderBy:    totalOrder,
+	},
+}
+
+// ReportService computes tracked time totals grouped by period, project, tag or client
+type ReportService struct {
+	db *sql.DB
+}
+
+// NewReportService creates a new report service
+func NewReportService(db *sql.DB) *ReportService {
+	return &ReportService{db: db}
+}
+
+// GetReport returns the time tracked by time blocks starting in the date range, grouped one way and narrowed
+// by an optional filter. Totals are summed by the database, and groups without tracked time are left out.
+func (s *ReportService) GetReport(grouping models.ReportGrouping, startDate, endDate time.Time, filter *models.TimeBlockFilter) (*models.Report, error) {
+	spec, ok := reportGroups[grouping]
+	if !ok {
+		return nil, ErrInvalidReportGrouping
+	}
+
+	start, end := startDate.In(time.Local), endDate.In(time.Local)
+	filterSQL, filterArgs := filterClause(filter)
+	rangeSQL := `tb.start_time >= ? AND tb.start_time <= ? AND tb.deleted_at IS NULL` + filterSQL
+	rangeArgs := append([]interface{}{start, end}, filterArgs...)
+
+	report := &models.Report{Grouping: grouping, Groups: []models.ReportGroup{}}
+
+	// The range total is taken separately, since a block can count in several tag groups
+	totalQuery := `
+		SELECT COALESCE(SUM(tb.duration), 0), COUNT(tb.id)
+		FROM time_blocks tb
+		JOIN projects p ON tb.project_id = p.id
+		WHERE ` + rangeSQL
+
+	if err := s.db.QueryRow(totalQuery, rangeArgs...).Scan(&report.Duration, &report.BlockCount); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + spec.columns + `, COALESCE(SUM(tb.duration), 0), COUNT(tb.id)
+		FROM time_blocks tb
+		JOIN projects p ON tb.project_id = p.id
+		` + spec.joins + `
+		WHERE ` + rangeSQL + `
+		GROUP BY ` + spec.groupBy + `
+		ORDER BY ` + spec.orderBy
+
+	args := rangeArgs
+	if spec.joinsRange {
+		args = append([]interface{}{start, end}, rangeArgs...)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var group models.ReportGroup
+		if err := rows.Scan(&group.ID, &group.Key, &group.Label, &group.Color, &group.Duration, &group.BlockCount); err != nil {
+			return nil, err
+		}
+		report.Groups = append(report.Groups, group)
+	}
+
+	return report, rows.Err()
+}
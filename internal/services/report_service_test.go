@@ -0,0 +1,92 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+func TestGetReportGrouping(t *testing.T) {
+	f := newServiceFixture(t)
+
+	client, err := NewClientService(f.db).CreateClient(models.CreateClientRequest{Name: "Acme", Currency: "USD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mobile, err := NewProjectService(f.db).CreateProject(models.CreateProjectRequest{Name: "Mobile", ClientID: &client.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, err := NewTagService(f.db).CreateTag(models.CreateTagRequest{Name: "urgent", Color: "#ff0000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewTagService(f.db).SetProjectTags(mobile.ID, []int{tag.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sunday 29 December 2024 ends ISO week 2024-W52 and the Monday after starts 2025-W01
+	sunday := time.Date(2024, 12, 29, 0, 0, 0, 0, time.Local)
+	monday := sunday.AddDate(0, 0, 1)
+	for _, block := range []struct {
+		projectID  int
+		start      time.Time
+		hours, min int
+	}{
+		{f.projectID, sunday.Add(9 * time.Hour), 1, 0},
+		{f.projectID, monday.Add(9 * time.Hour), 2, 0},
+		{mobile.ID, monday.Add(13 * time.Hour), 0, 30},
+	} {
+		end := block.start.Add(time.Duration(block.hours)*time.Hour + time.Duration(block.min)*time.Minute)
+		if _, err := f.timeBlock.CreateTimeBlock(models.CreateTimeBlockRequest{ProjectID: block.projectID, StartTime: block.start, EndTime: &end}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type group struct {
+		label    string
+		duration int
+		blocks   int
+	}
+	tests := []struct {
+		grouping models.ReportGrouping
+		want     []group
+	}{
+		{models.ReportByDay, []group{{"2024-12-29", 3600, 1}, {"2024-12-30", 9000, 2}}},
+		{models.ReportByWeek, []group{{"2024-W52", 3600, 1}, {"2025-W01", 9000, 2}}},
+		{models.ReportByMonth, []group{{"2024-12", 12600, 3}}},
+		{models.ReportByProject, []group{{"Website", 10800, 2}, {"Mobile", 1800, 1}}},
+		{models.ReportByClient, []group{{"", 10800, 2}, {"Acme", 1800, 1}}},
+		{models.ReportByTag, []group{{"", 10800, 2}, {"urgent", 1800, 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.grouping), func(t *testing.T) {
+			report, err := NewReportService(f.db).GetReport(tt.grouping, sunday, monday.Add(24*time.Hour-time.Second), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.Duration != 12600 || report.BlockCount != 3 {
+				t.Errorf("total = %d s in %d blocks, want 12600 s in 3 blocks", report.Duration, report.BlockCount)
+			}
+
+			got := make([]group, len(report.Groups))
+			for i, g := range report.Groups {
+				got[i] = group{g.Label, g.Duration, g.BlockCount}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("groups = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("group %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := NewReportService(f.db).GetReport("year", sunday, monday, nil); err != ErrInvalidReportGrouping {
+		t.Errorf("unknown grouping = %v, want %v", err, ErrInvalidReportGrouping)
+	}
+}
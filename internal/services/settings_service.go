@@ -2,10 +2,34 @@ package services
 
 import (
 	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
 
 	"ThinkTimerV2/internal/models"
 )
 
+var (
+	// ErrParallelTimersInUse is returned when disabling parallel timers while several time blocks are running
+	ErrParallelTimersInUse = errors.New("cannot disable parallel timers while more than one time block is running")
+	// ErrInvalidIdleThreshold is returned when the idle threshold is outside 0 to 1440 minutes
+	ErrInvalidIdleThreshold = errors.New("idle threshold must be between 0 and 1440 minutes")
+	// ErrInvalidPomodoroInterval is returned when a pomodoro interval is outside 1 to 240 minutes
+	ErrInvalidPomodoroInterval = errors.New("pomodoro intervals must be between 1 and 240 minutes")
+	// ErrInvalidLongBreakEvery is returned when the long break frequency is outside 1 to 12 cycles
+	ErrInvalidLongBreakEvery = errors.New("long break frequency must be between 1 and 12 cycles")
+	// ErrInvalidCurrency is returned when the currency is not a three-letter ISO 4217 code
+	ErrInvalidCurrency = errors.New("currency must be a three-letter code like USD")
+	// ErrInvalidBudgetAlertThreshold is returned when the budget alert threshold is outside 0 to 100 percent
+	ErrInvalidBudgetAlertThreshold = errors.New("budget alert threshold must be between 0 and 100 percent")
+	// ErrInvalidTrashRetention is returned when the trash retention is outside 0 to 3650 days
+	ErrInvalidTrashRetention = errors.New("trash retention must be between 0 and 3650 days")
+	// ErrInvalidDeadlineReminder is returned when the deadline reminder window is outside 0 to 365 days
+	ErrInvalidDeadlineReminder = errors.New("deadline reminders must be between 0 and 365 days ahead")
+)
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
 // SettingsService handles settings operations
 type SettingsService struct {
 	db *sql.DB
@@ -18,10 +42,24 @@ func NewSettingsService(db *sql.DB) *SettingsService {
 
 // GetSettings returns the current settings
 func (s *SettingsService) GetSettings() (*models.Settings, error) {
-	query := "SELECT id, theme, language, COALESCE(timeformat, '24'), COALESCE(custom_url, ''), COALESCE(trello_url, '') FROM settings WHERE id = 1"
+	query := `
+		SELECT id, theme, language, COALESCE(timeformat, '24'), COALESCE(custom_url, ''), COALESCE(trello_url, ''),
+		       COALESCE(allow_parallel_timers, FALSE), COALESCE(idle_threshold_minutes, 15),
+		       COALESCE(pomodoro_work_minutes, 25), COALESCE(pomodoro_short_break_minutes, 5),
+		       COALESCE(pomodoro_long_break_minutes, 15), COALESCE(pomodoro_long_break_every, 4),
+		       COALESCE(currency, 'USD'), COALESCE(budget_alert_threshold, 80),
+		       COALESCE(trash_retention_days, 30), COALESCE(deadline_reminder_days, 1)
+		FROM settings WHERE id = 1
+	`
 
 	var settings models.Settings
-	err := s.db.QueryRow(query).Scan(&settings.ID, &settings.Theme, &settings.Language, &settings.TimeFormat, &settings.CustomURL, &settings.TrelloURL)
+	err := s.db.QueryRow(query).Scan(
+		&settings.ID, &settings.Theme, &settings.Language, &settings.TimeFormat, &settings.CustomURL, &settings.TrelloURL,
+		&settings.AllowParallelTimers, &settings.IdleThresholdMinutes,
+		&settings.PomodoroWorkMinutes, &settings.PomodoroShortBreakMinutes,
+		&settings.PomodoroLongBreakMinutes, &settings.PomodoroLongBreakEvery,
+		&settings.Currency, &settings.BudgetAlertThreshold, &settings.TrashRetentionDays, &settings.DeadlineReminderDays,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -54,6 +92,81 @@ func (s *SettingsService) UpdateSettings(req models.UpdateSettingsRequest) (*mod
 		setParts = append(setParts, "trello_url = ?")
 		args = append(args, *req.TrelloURL)
 	}
+	if req.AllowParallelTimers != nil {
+		if !*req.AllowParallelTimers {
+			var running int
+			err := s.db.QueryRow("SELECT COUNT(*) FROM time_blocks WHERE end_time IS NULL AND deleted_at IS NULL").Scan(&running)
+			if err != nil {
+				return nil, err
+			}
+			if running > 1 {
+				return nil, ErrParallelTimersInUse
+			}
+		}
+		setParts = append(setParts, "allow_parallel_timers = ?")
+		args = append(args, *req.AllowParallelTimers)
+	}
+	if req.IdleThresholdMinutes != nil {
+		if *req.IdleThresholdMinutes < 0 || *req.IdleThresholdMinutes > 24*60 {
+			return nil, ErrInvalidIdleThreshold
+		}
+		setParts = append(setParts, "idle_threshold_minutes = ?")
+		args = append(args, *req.IdleThresholdMinutes)
+	}
+	intervals := []struct {
+		column string
+		value  *int
+	}{
+		{"pomodoro_work_minutes", req.PomodoroWorkMinutes},
+		{"pomodoro_short_break_minutes", req.PomodoroShortBreakMinutes},
+		{"pomodoro_long_break_minutes", req.PomodoroLongBreakMinutes},
+	}
+	for _, interval := range intervals {
+		if interval.value == nil {
+			continue
+		}
+		if *interval.value < 1 || *interval.value > 240 {
+			return nil, ErrInvalidPomodoroInterval
+		}
+		setParts = append(setParts, interval.column+" = ?")
+		args = append(args, *interval.value)
+	}
+	if req.PomodoroLongBreakEvery != nil {
+		if *req.PomodoroLongBreakEvery < 1 || *req.PomodoroLongBreakEvery > 12 {
+			return nil, ErrInvalidLongBreakEvery
+		}
+		setParts = append(setParts, "pomodoro_long_break_every = ?")
+		args = append(args, *req.PomodoroLongBreakEvery)
+	}
+	if req.Currency != nil {
+		currency := strings.ToUpper(strings.TrimSpace(*req.Currency))
+		if !currencyPattern.MatchString(currency) {
+			return nil, ErrInvalidCurrency
+		}
+		setParts = append(setParts, "currency = ?")
+		args = append(args, currency)
+	}
+	if req.BudgetAlertThreshold != nil {
+		if *req.BudgetAlertThreshold < 0 || *req.BudgetAlertThreshold > 100 {
+			return nil, ErrInvalidBudgetAlertThreshold
+		}
+		setParts = append(setParts, "budget_alert_threshold = ?")
+		args = append(args, *req.BudgetAlertThreshold)
+	}
+	if req.TrashRetentionDays != nil {
+		if *req.TrashRetentionDays < 0 || *req.TrashRetentionDays > 3650 {
+			return nil, ErrInvalidTrashRetention
+		}
+		setParts = append(setParts, "trash_retention_days = ?")
+		args = append(args, *req.TrashRetentionDays)
+	}
+	if req.DeadlineReminderDays != nil {
+		if *req.DeadlineReminderDays < 0 || *req.DeadlineReminderDays > 365 {
+			return nil, ErrInvalidDeadlineReminder
+		}
+		setParts = append(setParts, "deadline_reminder_days = ?")
+		args = append(args, *req.DeadlineReminderDays)
+	}
 
 	if len(setParts) > 0 {
 		args = append(args, 1) // settings ID is always 1
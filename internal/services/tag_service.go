@@ -0,0 +1,395 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+// DefaultTagColor is used for tags created without a color
+const DefaultTagColor = "#1098F7"
+
+var (
+	// ErrTagNameRequired is returned when a tag name is empty
+	ErrTagNameRequired = errors.New("tag name is required")
+	// ErrTagNameTaken is returned when a tag name is already used by another tag
+	ErrTagNameTaken = errors.New("a tag with this name already exists")
+	// ErrInvalidTagColor is returned when a tag color is not a #RRGGBB hex color
+	ErrInvalidTagColor = errors.New("tag color must be a hex color like #1098F7")
+	// ErrMergeTagIntoItself is returned when a tag merge names the target as a source
+	ErrMergeTagIntoItself = errors.New("a tag cannot be merged into itself")
+)
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// TagService handles tag operations
+type TagService struct {
+	db *sql.DB
+}
+
+// NewTagService creates a new tag service
+func NewTagService(db *sql.DB) *TagService {
+	return &TagService{db: db}
+}
+
+// CreateTag creates a new tag
+func (s *TagService) CreateTag(req models.CreateTagRequest) (*models.Tag, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrTagNameRequired
+	}
+	color := req.Color
+	if color == "" {
+		color = DefaultTagColor
+	}
+	if !tagColorPattern.MatchString(color) {
+		return nil, ErrInvalidTagColor
+	}
+	if err := s.checkNameFree(name, 0); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO tags (name, color, created_at, updated_at)
+		VALUES (?, ?, ?, ?)
+		RETURNING id, name, color, created_at, updated_at
+	`
+
+	now := time.Now().In(time.Local)
+
+	var tag models.Tag
+	err := s.db.QueryRow(query, name, color, now, now).Scan(&tag.ID, &tag.Name, &tag.Color, &tag.CreatedAt, &tag.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// GetAllTags returns all tags sorted by name
+func (s *TagService) GetAllTags() ([]models.Tag, error) {
+	query := `
+		SELECT id, name, color, created_at, updated_at
+		FROM tags
+		ORDER BY name COLLATE NOCASE ASC
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []models.Tag{}
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Color, &tag.CreatedAt, &tag.UpdatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// GetTagByID returns a tag by ID
+func (s *TagService) GetTagByID(id int) (*models.Tag, error) {
+	query := `
+		SELECT id, name, color, created_at, updated_at
+		FROM tags
+		WHERE id = ?
+	`
+
+	var tag models.Tag
+	err := s.db.QueryRow(query, id).Scan(&tag.ID, &tag.Name, &tag.Color, &tag.CreatedAt, &tag.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// UpdateTag renames or recolors a tag
+func (s *TagService) UpdateTag(id int, req models.UpdateTagRequest) (*models.Tag, error) {
+	setParts := []string{}
+	args := []interface{}{}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrTagNameRequired
+		}
+		if err := s.checkNameFree(name, id); err != nil {
+			return nil, err
+		}
+		setParts = append(setParts, "name = ?")
+		args = append(args, name)
+	}
+	if req.Color != nil {
+		if !tagColorPattern.MatchString(*req.Color) {
+			return nil, ErrInvalidTagColor
+		}
+		setParts = append(setParts, "color = ?")
+		args = append(args, *req.Color)
+	}
+
+	setParts = append(setParts, "updated_at = ?")
+	args = append(args, time.Now().In(time.Local))
+	args = append(args, id)
+
+	query := "UPDATE tags SET " + strings.Join(setParts, ", ") + " WHERE id = ?"
+
+	_, err := s.db.Exec(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTagByID(id)
+}
+
+// DeleteTag deletes a tag and removes it from every time block, project and project template
+func (s *TagService) DeleteTag(id int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{
+		"DELETE FROM time_block_tags WHERE tag_id = ?",
+		"DELETE FROM project_tags WHERE tag_id = ?",
+		"DELETE FROM project_template_tags WHERE tag_id = ?",
+		"DELETE FROM tags WHERE id = ?",
+	} {
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// MergeTags moves every use of the source tags to the target tag and deletes the sources
+func (s *TagService) MergeTags(req models.MergeTagsRequest) (*models.Tag, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var targetID int
+	if err := tx.QueryRow("SELECT id FROM tags WHERE id = ?", req.TargetID).Scan(&targetID); err != nil {
+		return nil, err
+	}
+
+	for _, sourceID := range req.SourceIDs {
+		if sourceID == targetID {
+			return nil, ErrMergeTagIntoItself
+		}
+
+		for _, query := range []string{
+			"INSERT OR IGNORE INTO time_block_tags (time_block_id, tag_id) SELECT time_block_id, ? FROM time_block_tags WHERE tag_id = ?",
+			"INSERT OR IGNORE INTO project_tags (project_id, tag_id) SELECT project_id, ? FROM project_tags WHERE tag_id = ?",
+			"INSERT OR IGNORE INTO project_template_tags (template_id, tag_id) SELECT template_id, ? FROM project_template_tags WHERE tag_id = ?",
+		} {
+			if _, err := tx.Exec(query, targetID, sourceID); err != nil {
+				return nil, err
+			}
+		}
+		for _, query := range []string{
+			"DELETE FROM time_block_tags WHERE tag_id = ?",
+			"DELETE FROM project_tags WHERE tag_id = ?",
+			"DELETE FROM project_template_tags WHERE tag_id = ?",
+			"DELETE FROM tags WHERE id = ?",
+		} {
+			if _, err := tx.Exec(query, sourceID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetTagByID(targetID)
+}
+
+// SetTimeBlockTags replaces the tags of a time block
+func (s *TagService) SetTimeBlockTags(timeBlockID int, tagIDs []int) ([]models.Tag, error) {
+	return s.setTags("time_blocks", "time_block_tags", "time_block_id", timeBlockID, tagIDs)
+}
+
+// SetProjectTags replaces the tags of a project
+func (s *TagService) SetProjectTags(projectID int, tagIDs []int) ([]models.Tag, error) {
+	return s.setTags("projects", "project_tags", "project_id", projectID, tagIDs)
+}
+
+// GetTagTotals returns the time tracked under each tag for time blocks starting in the date range.
+// A block counts towards its own tags and those of its project, so totals of different tags can overlap.
+func (s *TagService) GetTagTotals(startDate, endDate time.Time) ([]models.TagTotal, error) {
+	query := `
+		SELECT t.id, t.name, t.color, COALESCE(SUM(tb.duration), 0), COUNT(tb.id)
+		FROM tags t
+		JOIN (
+			SELECT tag_id, time_block_id FROM time_block_tags
+			UNION
+			SELECT pt.tag_id, ptb.id FROM project_tags pt JOIN time_blocks ptb ON ptb.project_id = pt.project_id
+		) tagged ON tagged.tag_id = t.id
+		JOIN time_blocks tb ON tb.id = tagged.time_block_id
+		WHERE tb.start_time >= ? AND tb.start_time <= ? AND tb.deleted_at IS NULL
+		GROUP BY t.id, t.name, t.color
+		ORDER BY 4 DESC, t.name COLLATE NOCASE ASC
+	`
+
+	rows, err := s.db.Query(query, startDate.In(time.Local), endDate.In(time.Local))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []models.TagTotal{}
+	for rows.Next() {
+		var total models.TagTotal
+		if err := rows.Scan(&total.TagID, &total.TagName, &total.Color, &total.Duration, &total.BlockCount); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+// checkNameFree refuses a tag name already used by another tag, ignoring case
+func (s *TagService) checkNameFree(name string, exceptID int) error {
+	var id int
+	err := s.db.QueryRow("SELECT id FROM tags WHERE name = ? COLLATE NOCASE AND id != ?", name, exceptID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return ErrTagNameTaken
+}
+
+// setTags replaces the tag links of one owner in a link table. Foreign keys are not enforced, so the owner
+// is checked here to keep links to missing or trashed rows out.
+func (s *TagService) setTags(ownerTable, table, ownerColumn string, ownerID int, tagIDs []int) ([]models.Tag, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var owner int
+	if err := tx.QueryRow("SELECT id FROM "+ownerTable+" WHERE id = ? AND deleted_at IS NULL", ownerID).Scan(&owner); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec("DELETE FROM "+table+" WHERE "+ownerColumn+" = ?", ownerID); err != nil {
+		return nil, err
+	}
+	for _, tagID := range tagIDs {
+		var id int
+		if err := tx.QueryRow("SELECT id FROM tags WHERE id = ?", tagID).Scan(&id); err != nil {
+			return nil, err
+		}
+		query := "INSERT OR IGNORE INTO " + table + " (" + ownerColumn + ", tag_id) VALUES (?, ?)"
+		if _, err := tx.Exec(query, ownerID, id); err != nil {
+			return nil, err
+		}
+	}
+
+	tags, err := loadTags(tx, table, ownerColumn, []int{ownerID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return tags[ownerID], nil
+}
+
+// attachTimeBlockTags loads the tags set directly on the given time blocks
+func attachTimeBlockTags(q querier, timeBlocks []models.TimeBlock) error {
+	ids := make([]int, len(timeBlocks))
+	for i := range timeBlocks {
+		ids[i] = timeBlocks[i].ID
+	}
+
+	tags, err := loadTags(q, "time_block_tags", "time_block_id", ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range timeBlocks {
+		timeBlocks[i].Tags = tags[timeBlocks[i].ID]
+	}
+	return nil
+}
+
+// attachProjectTags loads the tags of the given projects
+func attachProjectTags(q querier, projects []models.Project) error {
+	ids := make([]int, len(projects))
+	for i := range projects {
+		ids[i] = projects[i].ID
+	}
+
+	tags, err := loadTags(q, "project_tags", "project_id", ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range projects {
+		projects[i].Tags = tags[projects[i].ID]
+	}
+	return nil
+}
+
+// loadTags returns the tags linked to each owner in a link table, every owner getting at least an empty list
+func loadTags(q querier, table, ownerColumn string, ownerIDs []int) (map[int][]models.Tag, error) {
+	tags := make(map[int][]models.Tag, len(ownerIDs))
+	if len(ownerIDs) == 0 {
+		return tags, nil
+	}
+
+	placeholders := make([]string, len(ownerIDs))
+	args := make([]interface{}, len(ownerIDs))
+	for i, id := range ownerIDs {
+		placeholders[i] = "?"
+		args[i] = id
+		tags[id] = []models.Tag{}
+	}
+
+	query := `
+		SELECT l.` + ownerColumn + `, t.id, t.name, t.color, t.created_at, t.updated_at
+		FROM ` + table + ` l
+		JOIN tags t ON t.id = l.tag_id
+		WHERE l.` + ownerColumn + ` IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY t.name COLLATE NOCASE ASC
+	`
+
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ownerID int
+		var tag models.Tag
+		if err := rows.Scan(&ownerID, &tag.ID, &tag.Name, &tag.Color, &tag.CreatedAt, &tag.UpdatedAt); err != nil {
+			return nil, err
+		}
+		tags[ownerID] = append(tags[ownerID], tag)
+	}
+
+	return tags, rows.Err()
+}
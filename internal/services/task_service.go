@@ -0,0 +1,340 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+var (
+	// ErrTaskNameRequired is returned when a task name is empty
+	ErrTaskNameRequired = errors.New("task name is required")
+	// ErrTaskProjectMismatch is returned when a task is used with a project it does not belong to
+	ErrTaskProjectMismatch = errors.New("task belongs to another project")
+	// ErrTaskCycle is returned when a task would be moved under itself or one of its subtasks
+	ErrTaskCycle = errors.New("a task cannot be moved under itself or one of its subtasks")
+)
+
+// taskColumns is the column list shared by every task query
+const taskColumns = `
+	id, project_id, parent_id, name, description, COALESCE(done, FALSE), COALESCE("order", 0), estimated_hours, created_at, updated_at
+`
+
+// subtreeQuery selects the ID of a task and of every task nested under it
+const subtreeQuery = `
+	WITH RECURSIVE subtree(id) AS (
+		SELECT ?
+		UNION ALL
+		SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id
+	)
+	SELECT id FROM subtree
+`
+
+// TaskService handles task operations
+type TaskService struct {
+	db *sql.DB
+}
+
+// NewTaskService creates a new task service
+func NewTaskService(db *sql.DB) *TaskService {
+	return &TaskService{db: db}
+}
+
+// scanTask scans a row selected with taskColumns
+func scanTask(row rowScanner) (models.Task, error) {
+	var task models.Task
+	err := row.Scan(
+		&task.ID, &task.ProjectID, &task.ParentID, &task.Name, &task.Description, &task.Done, &task.Order,
+		&task.EstimatedHours, &task.CreatedAt, &task.UpdatedAt,
+	)
+	task.Children = []models.Task{}
+	return task, err
+}
+
+// CreateTask creates a new task in a project, optionally under a parent task of the same project
+func (s *TaskService) CreateTask(req models.CreateTaskRequest) (*models.Task, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrTaskNameRequired
+	}
+
+	estimatedHours, err := normalizeEstimate(req.EstimatedHours)
+	if err != nil {
+		return nil, err
+	}
+
+	var projectID int
+	if err := s.db.QueryRow("SELECT id FROM projects WHERE id = ? AND deleted_at IS NULL", req.ProjectID).Scan(&projectID); err != nil {
+		return nil, err
+	}
+
+	parentID := req.ParentID
+	if parentID != nil && *parentID == 0 {
+		parentID = nil
+	}
+	if parentID != nil {
+		if err := checkTaskInProject(s.db, *parentID, projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		INSERT INTO tasks (project_id, parent_id, name, description, done, "order", estimated_hours, created_at, updated_at)
+		VALUES (?, ?, ?, ?, FALSE, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	now := time.Now().In(time.Local)
+
+	var id int
+	if err := s.db.QueryRow(query, projectID, parentID, name, req.Description, req.Order, estimatedHours, now, now).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	return s.GetTaskByID(id)
+}
+
+// GetTaskByID returns a task with its subtasks and rolled-up durations
+func (s *TaskService) GetTaskByID(id int) (*models.Task, error) {
+	var projectID int
+	if err := s.db.QueryRow("SELECT project_id FROM tasks WHERE id = ?", id).Scan(&projectID); err != nil {
+		return nil, err
+	}
+
+	tree, err := s.GetTaskTree(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task := findTask(tree.Tasks, id); task != nil {
+		return task, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+// GetTaskTree returns the tasks of a project as a tree, each task's total including all of its subtasks
+func (s *TaskService) GetTaskTree(projectID int) (*models.TaskTree, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE project_id = ?
+		ORDER BY "order" ASC, id ASC
+	`
+
+	rows, err := s.db.Query(query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []models.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tree := &models.TaskTree{ProjectID: projectID, Tasks: []models.Task{}}
+
+	query = "SELECT task_id, COALESCE(SUM(duration), 0) FROM time_blocks WHERE project_id = ? AND deleted_at IS NULL GROUP BY task_id"
+	durations, err := s.db.Query(query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer durations.Close()
+
+	own := map[int]int{}
+	for durations.Next() {
+		var taskID sql.NullInt64
+		var duration int
+		if err := durations.Scan(&taskID, &duration); err != nil {
+			return nil, err
+		}
+		tree.Duration += duration
+		if taskID.Valid {
+			own[int(taskID.Int64)] = duration
+		}
+	}
+	if err := durations.Err(); err != nil {
+		return nil, err
+	}
+
+	tree.Tasks = buildTaskTree(tasks, own, nil)
+
+	// Time on tasks that no longer exist counts as unassigned
+	assigned := 0
+	for _, task := range tree.Tasks {
+		assigned += task.TotalDuration
+	}
+	tree.UnassignedDuration = tree.Duration - assigned
+
+	return tree, nil
+}
+
+// buildTaskTree nests the tasks under parentID and rolls their durations up
+func buildTaskTree(tasks []models.Task, own map[int]int, parentID *int) []models.Task {
+	children := []models.Task{}
+	for _, task := range tasks {
+		if (parentID == nil) != (task.ParentID == nil) || (parentID != nil && *parentID != *task.ParentID) {
+			continue
+		}
+
+		id := task.ID
+		task.Children = buildTaskTree(tasks, own, &id)
+		task.Duration = own[task.ID]
+		task.TotalDuration = task.Duration
+		for _, child := range task.Children {
+			task.TotalDuration += child.TotalDuration
+		}
+		children = append(children, task)
+	}
+	return children
+}
+
+// findTask looks a task up anywhere in a tree
+func findTask(tasks []models.Task, id int) *models.Task {
+	for i := range tasks {
+		if tasks[i].ID == id {
+			return &tasks[i]
+		}
+		if task := findTask(tasks[i].Children, id); task != nil {
+			return task
+		}
+	}
+	return nil
+}
+
+// UpdateTask updates a task; moving it under another parent keeps it in its project
+func (s *TaskService) UpdateTask(id int, req models.UpdateTaskRequest) (*models.Task, error) {
+	var projectID int
+	if err := s.db.QueryRow("SELECT project_id FROM tasks WHERE id = ?", id).Scan(&projectID); err != nil {
+		return nil, err
+	}
+
+	setParts := []string{}
+	args := []interface{}{}
+
+	if req.ParentID != nil {
+		if *req.ParentID == 0 {
+			setParts = append(setParts, "parent_id = NULL")
+		} else {
+			if err := checkTaskInProject(s.db, *req.ParentID, projectID); err != nil {
+				return nil, err
+			}
+			if err := s.checkNotInSubtree(id, *req.ParentID); err != nil {
+				return nil, err
+			}
+			setParts = append(setParts, "parent_id = ?")
+			args = append(args, *req.ParentID)
+		}
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrTaskNameRequired
+		}
+		setParts = append(setParts, "name = ?")
+		args = append(args, name)
+	}
+	if req.Description != nil {
+		setParts = append(setParts, "description = ?")
+		args = append(args, *req.Description)
+	}
+	if req.Done != nil {
+		setParts = append(setParts, "done = ?")
+		args = append(args, *req.Done)
+	}
+	if req.Order != nil {
+		setParts = append(setParts, "\"order\" = ?")
+		args = append(args, *req.Order)
+	}
+	if req.EstimatedHours != nil {
+		estimatedHours, err := normalizeEstimate(req.EstimatedHours)
+		if err != nil {
+			return nil, err
+		}
+		setParts = append(setParts, "estimated_hours = ?")
+		args = append(args, estimatedHours)
+	}
+
+	setParts = append(setParts, "updated_at = ?")
+	args = append(args, time.Now().In(time.Local))
+	args = append(args, id)
+
+	query := "UPDATE tasks SET " + strings.Join(setParts, ", ") + " WHERE id = ?"
+
+	if _, err := s.db.Exec(query, args...); err != nil {
+		return nil, err
+	}
+
+	return s.GetTaskByID(id)
+}
+
+// DeleteTask deletes a task and its subtasks; their time blocks stay on the project without a task
+func (s *TaskService) DeleteTask(id int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE time_blocks SET task_id = NULL WHERE task_id IN ("+subtreeQuery+")", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM tasks WHERE id IN ("+subtreeQuery+")", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateTasksOrder updates the order of multiple tasks
+func (s *TaskService) UpdateTasksOrder(taskOrders map[int]int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for id, order := range taskOrders {
+		_, err := tx.Exec(`UPDATE tasks SET "order" = ?, updated_at = ? WHERE id = ?`, order, time.Now().In(time.Local), id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// checkNotInSubtree refuses a new parent that is the task itself or one of its subtasks
+func (s *TaskService) checkNotInSubtree(id, parentID int) error {
+	var found int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM ("+subtreeQuery+") WHERE id = ?", id, parentID).Scan(&found)
+	if err != nil {
+		return err
+	}
+	if found > 0 {
+		return ErrTaskCycle
+	}
+	return nil
+}
+
+// checkTaskInProject returns ErrTaskProjectMismatch when a task is not part of the given project
+func checkTaskInProject(q querier, taskID, projectID int) error {
+	var taskProjectID int
+	if err := q.QueryRow("SELECT project_id FROM tasks WHERE id = ?", taskID).Scan(&taskProjectID); err != nil {
+		return err
+	}
+	if taskProjectID != projectID {
+		return ErrTaskProjectMismatch
+	}
+	return nil
+}
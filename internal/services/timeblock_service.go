@@ -2,32 +2,144 @@ package services
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"ThinkTimerV2/internal/models"
 )
 
+var (
+	// ErrInvalidOverlapStrategy is returned for an unknown overlap strategy
+	ErrInvalidOverlapStrategy = errors.New("invalid overlap strategy")
+	// ErrInvalidOverlapRequest is returned when an overlap resolution names no time block to save
+	ErrInvalidOverlapRequest = errors.New("overlap resolution needs a time block to create or update")
+	// ErrNegativeDuration is returned when a time block duration is below zero
+	ErrNegativeDuration = errors.New("duration cannot be negative")
+	// ErrEndBeforeStart is returned when a time block ends before it starts
+	ErrEndBeforeStart = errors.New("end time cannot be before start time")
+	// ErrDurationExceedsInterval is returned when a duration is longer than the time between start and end
+	ErrDurationExceedsInterval = errors.New("duration cannot exceed the time between start and end")
+	// ErrDurationOnRunning is returned when setting a duration on a time block that is still running
+	ErrDurationOnRunning = errors.New("running time blocks have no duration until they are stopped")
+	// ErrTimeBlockRunning is returned when splitting, cutting or merging a running time block
+	ErrTimeBlockRunning = errors.New("running time blocks cannot be split, cut or merged; stop the timer first")
+	// ErrSplitOutOfRange is returned when the split time does not fall strictly inside the time block
+	ErrSplitOutOfRange = errors.New("split time must fall inside the time block")
+	// ErrMergeTooFew is returned when merging fewer than two time blocks
+	ErrMergeTooFew = errors.New("at least two time blocks are needed to merge")
+	// ErrMergeProjectMismatch is returned when merging time blocks of different projects
+	ErrMergeProjectMismatch = errors.New("only time blocks of the same project can be merged")
+)
+
+// OverlapError is returned when a time block would overlap existing time blocks
+type OverlapError struct {
+	TimeBlockIDs []int // IDs of the conflicting time blocks
+}
+
+func (e *OverlapError) Error() string {
+	ids := make([]string, len(e.TimeBlockIDs))
+	for i, id := range e.TimeBlockIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+	return "time block overlaps time blocks " + strings.Join(ids, ", ")
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
 // TimeBlockService handles time block operations
 type TimeBlockService struct {
-	db *sql.DB
+	db              *sql.DB
+	settingsService *SettingsService
 }
 
 // NewTimeBlockService creates a new time block service
-func NewTimeBlockService(db *sql.DB) *TimeBlockService {
-	return &TimeBlockService{db: db}
+func NewTimeBlockService(db *sql.DB, settingsService *SettingsService) *TimeBlockService {
+	return &TimeBlockService{db: db, settingsService: settingsService}
 }
 
-// CreateTimeBlock creates a new time block
+// CreateTimeBlock creates a new time block; unless parallel timers are allowed,
+// creating a running block stops every other running block first.
+// A block overlapping existing ones is refused with an *OverlapError.
 func (s *TimeBlockService) CreateTimeBlock(req models.CreateTimeBlockRequest) (*models.TimeBlock, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	id, err := s.createTimeBlock(tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetTimeBlockByID(id)
+}
+
+// createTimeBlock checks and inserts a time block as CreateTimeBlock does and returns its ID
+func (s *TimeBlockService) createTimeBlock(q querier, req models.CreateTimeBlockRequest) (int, error) {
+	// Convert start_time to local timezone if it's not already
+	startTime := req.StartTime.In(time.Local)
+
+	var endTime *time.Time
+	if req.EndTime != nil {
+		localEndTime := req.EndTime.In(time.Local)
+		endTime = &localEndTime
+	}
+
+	if _, _, err := deriveDuration(startTime, endTime, nil, explicitDuration(req.Duration)); err != nil {
+		return 0, err
+	}
+
+	if err := s.checkOverlaps(q, startTime, endTime); err != nil {
+		return 0, err
+	}
+
+	if endTime == nil {
+		if err := s.stopOtherRunningBlocks(q, startTime); err != nil {
+			return 0, err
+		}
+	}
+
+	return insertTimeBlock(q, req)
+}
+
+// insertTimeBlock inserts a time block and returns its ID; a zero duration is derived from the interval
+func insertTimeBlock(q querier, req models.CreateTimeBlockRequest) (int, error) {
 	query := `
-		INSERT INTO time_blocks (project_id, start_time, end_time, duration, is_manual, description, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO time_blocks (project_id, task_id, start_time, end_time, duration, duration_override, is_manual, description, billable, hourly_rate, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		RETURNING id
 	`
 
-	now := time.Now().In(time.Local) // Ensure we use local timezone
+	taskID := req.TaskID
+	if taskID != nil && *taskID == 0 {
+		taskID = nil
+	}
+	if taskID != nil {
+		if err := checkTaskInProject(q, *taskID, req.ProjectID); err != nil {
+			return 0, err
+		}
+	}
 
-	// Convert start_time to local timezone if it's not already
+	if req.HourlyRate != nil && *req.HourlyRate < 0 {
+		return 0, ErrNegativeHourlyRate
+	}
+
+	now := time.Now().In(time.Local) // Ensure we use local timezone
 	startTime := req.StartTime.In(time.Local)
 
 	var endTime *time.Time
@@ -36,37 +148,145 @@ func (s *TimeBlockService) CreateTimeBlock(req models.CreateTimeBlockRequest) (*
 		endTime = &localEndTime
 	}
 
+	duration, override, err := deriveDuration(startTime, endTime, nil, explicitDuration(req.Duration))
+	if err != nil {
+		return 0, err
+	}
+
 	var id int
-	err := s.db.QueryRow(query, req.ProjectID, startTime, endTime, req.Duration, req.IsManual, req.Description, now, now).Scan(&id)
+	err = q.QueryRow(query, req.ProjectID, taskID, startTime, endTime, duration, override, req.IsManual, req.Description,
+		req.Billable, req.HourlyRate, now, now).Scan(&id)
+	return id, err
+}
+
+// explicitDuration treats a zero duration in a create request as "derive it from the interval"
+func explicitDuration(duration int) *int {
+	if duration == 0 {
+		return nil
+	}
+	return &duration
+}
+
+// deriveDuration applies the duration rule shared by every write: a stopped block lasts from start to end
+// minus its pauses, unless an explicit duration overrides it. Running blocks have no duration until stopped.
+// It returns the duration to store and whether it is an override.
+func deriveDuration(start time.Time, end *time.Time, worked []interval, explicit *int) (int, bool, error) {
+	if explicit != nil && *explicit < 0 {
+		return 0, false, ErrNegativeDuration
+	}
+	if end == nil {
+		if explicit != nil && *explicit != 0 {
+			return 0, false, ErrDurationOnRunning
+		}
+		return 0, false, nil
+	}
+	if end.Before(start) {
+		return 0, false, ErrEndBeforeStart
+	}
+
+	span := int(end.Sub(start).Seconds())
+	derived := span
+	if worked != nil {
+		derived = intervalsDuration(worked)
+	}
+
+	if explicit == nil || *explicit == derived {
+		return derived, false, nil
+	}
+	if *explicit > span {
+		return 0, false, ErrDurationExceedsInterval
+	}
+	return *explicit, true, nil
+}
+
+// timeBlockColumns is the column list shared by every time block query
+const timeBlockColumns = `
+	tb.id, tb.project_id, p.name as project_name, tb.task_id, (SELECT name FROM tasks WHERE id = tb.task_id),
+	tb.start_time, tb.end_time,
+	tb.duration, COALESCE(tb.duration_override, FALSE), tb.is_manual, tb.description, tb.heartbeat_at,
+	tb.billable, tb.hourly_rate, tb.deleted_at, tb.created_at, tb.updated_at
+`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTimeBlock scans a row selected with timeBlockColumns
+func scanTimeBlock(row rowScanner) (models.TimeBlock, error) {
+	var timeBlock models.TimeBlock
+	err := row.Scan(
+		&timeBlock.ID, &timeBlock.ProjectID, &timeBlock.ProjectName, &timeBlock.TaskID, &timeBlock.TaskName, &timeBlock.StartTime,
+		&timeBlock.EndTime, &timeBlock.Duration, &timeBlock.DurationOverridden, &timeBlock.IsManual, &timeBlock.Description,
+		&timeBlock.HeartbeatAt, &timeBlock.Billable, &timeBlock.HourlyRate, &timeBlock.DeletedAt, &timeBlock.CreatedAt, &timeBlock.UpdatedAt,
+	)
+	return timeBlock, err
+}
+
+// queryTimeBlocks runs a query selecting timeBlockColumns and attaches the segments of the result
+func queryTimeBlocks(q querier, query string, args ...interface{}) ([]models.TimeBlock, error) {
+	rows, err := q.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	return s.GetTimeBlockByID(id)
+	var timeBlocks []models.TimeBlock
+	for rows.Next() {
+		timeBlock, err := scanTimeBlock(rows)
+		if err != nil {
+			return nil, err
+		}
+		timeBlocks = append(timeBlocks, timeBlock)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := attachSegments(q, timeBlocks); err != nil {
+		return nil, err
+	}
+	if err := attachTimeBlockTags(q, timeBlocks); err != nil {
+		return nil, err
+	}
+	if err := attachTimeBlockFields(q, timeBlocks); err != nil {
+		return nil, err
+	}
+
+	return timeBlocks, nil
 }
 
 // GetTimeBlockByID returns a time block by ID
 func (s *TimeBlockService) GetTimeBlockByID(id int) (*models.TimeBlock, error) {
+	return getTimeBlock(s.db, id)
+}
+
+// getTimeBlock returns a time block by ID with its segments
+func getTimeBlock(q querier, id int) (*models.TimeBlock, error) {
 	query := `
-		SELECT tb.id, tb.project_id, p.name as project_name, tb.start_time, tb.end_time, 
-		       tb.duration, tb.is_manual, tb.description, tb.created_at, tb.updated_at
+		SELECT ` + timeBlockColumns + `
 		FROM time_blocks tb
 		JOIN projects p ON tb.project_id = p.id
-		WHERE tb.id = ?
+		WHERE tb.id = ? AND tb.deleted_at IS NULL
 	`
 
-	var timeBlock models.TimeBlock
-	err := s.db.QueryRow(query, id).Scan(
-		&timeBlock.ID, &timeBlock.ProjectID, &timeBlock.ProjectName, &timeBlock.StartTime,
-		&timeBlock.EndTime, &timeBlock.Duration, &timeBlock.IsManual, &timeBlock.Description,
-		&timeBlock.CreatedAt, &timeBlock.UpdatedAt,
-	)
-
+	timeBlock, err := scanTimeBlock(q.QueryRow(query, id))
 	if err != nil {
 		return nil, err
 	}
 
-	return &timeBlock, nil
+	timeBlocks := []models.TimeBlock{timeBlock}
+	if err := attachSegments(q, timeBlocks); err != nil {
+		return nil, err
+	}
+	if err := attachTimeBlockTags(q, timeBlocks); err != nil {
+		return nil, err
+	}
+	if err := attachTimeBlockFields(q, timeBlocks); err != nil {
+		return nil, err
+	}
+
+	return &timeBlocks[0], nil
 }
 
 // GetTimeBlocksByDate returns time blocks for a specific date
@@ -77,122 +297,339 @@ func (s *TimeBlockService) GetTimeBlocksByDate(date time.Time) ([]models.TimeBlo
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	query := `
-		SELECT tb.id, tb.project_id, p.name as project_name, tb.start_time, tb.end_time, 
-		       tb.duration, tb.is_manual, tb.description, tb.created_at, tb.updated_at
+		SELECT ` + timeBlockColumns + `
 		FROM time_blocks tb
 		JOIN projects p ON tb.project_id = p.id
-		WHERE tb.start_time >= ? AND tb.start_time < ?
+		WHERE tb.start_time >= ? AND tb.start_time < ? AND tb.deleted_at IS NULL
 		ORDER BY tb.start_time DESC
 	`
 
-	rows, err := s.db.Query(query, startOfDay, endOfDay)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var timeBlocks []models.TimeBlock
-	for rows.Next() {
-		var timeBlock models.TimeBlock
-		err := rows.Scan(
-			&timeBlock.ID, &timeBlock.ProjectID, &timeBlock.ProjectName, &timeBlock.StartTime,
-			&timeBlock.EndTime, &timeBlock.Duration, &timeBlock.IsManual, &timeBlock.Description,
-			&timeBlock.CreatedAt, &timeBlock.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		timeBlocks = append(timeBlocks, timeBlock)
-	}
-
-	return timeBlocks, nil
+	return queryTimeBlocks(s.db, query, startOfDay, endOfDay)
 }
 
-// GetTimeBlocksByDateRange returns time blocks for a date range
-func (s *TimeBlockService) GetTimeBlocksByDateRange(startDate, endDate time.Time) ([]models.TimeBlock, error) {
+// GetTimeBlocksByDateRange returns time blocks for a date range, narrowed by an optional filter
+func (s *TimeBlockService) GetTimeBlocksByDateRange(startDate, endDate time.Time, filter *models.TimeBlockFilter) ([]models.TimeBlock, error) {
 	// Convert dates to local timezone
 	localStartDate := startDate.In(time.Local)
 	localEndDate := endDate.In(time.Local)
 
+	filterSQL, filterArgs := filterClause(filter)
+
 	query := `
-		SELECT tb.id, tb.project_id, p.name as project_name, tb.start_time, tb.end_time, 
-		       tb.duration, tb.is_manual, tb.description, tb.created_at, tb.updated_at
+		SELECT ` + timeBlockColumns + `
 		FROM time_blocks tb
 		JOIN projects p ON tb.project_id = p.id
-		WHERE tb.start_time >= ? AND tb.start_time <= ?
+		WHERE tb.start_time >= ? AND tb.start_time <= ? AND tb.deleted_at IS NULL` + filterSQL + `
 		ORDER BY tb.start_time DESC
 	`
 
-	rows, err := s.db.Query(query, localStartDate, localEndDate)
+	args := append([]interface{}{localStartDate, localEndDate}, filterArgs...)
+	return queryTimeBlocks(s.db, query, args...)
+}
+
+// filterClause turns a time block filter into SQL conditions on the tb and p aliases
+func filterClause(filter *models.TimeBlockFilter) (string, []interface{}) {
+	if filter == nil {
+		return "", nil
+	}
+
+	clause := ""
+	args := []interface{}{}
+
+	// A block carries a tag when it is set on the block itself or on its project
+	hasTags := func(tagIDs []int) string {
+		placeholders := make([]string, len(tagIDs))
+		for i, tagID := range tagIDs {
+			placeholders[i] = "?"
+			args = append(args, tagID)
+		}
+		in := strings.Join(placeholders, ", ")
+		for _, tagID := range tagIDs {
+			args = append(args, tagID)
+		}
+		return `(EXISTS (SELECT 1 FROM time_block_tags tbt WHERE tbt.time_block_id = tb.id AND tbt.tag_id IN (` + in + `))
+			OR EXISTS (SELECT 1 FROM project_tags pt WHERE pt.project_id = tb.project_id AND pt.tag_id IN (` + in + `)))`
+	}
+
+	if len(filter.TagIDs) > 0 {
+		if filter.MatchAllTags {
+			for _, tagID := range filter.TagIDs {
+				clause += " AND " + hasTags([]int{tagID})
+			}
+		} else {
+			clause += " AND " + hasTags(filter.TagIDs)
+		}
+	}
+
+	if len(filter.ClientIDs) > 0 {
+		placeholders := make([]string, len(filter.ClientIDs))
+		for i, clientID := range filter.ClientIDs {
+			placeholders[i] = "?"
+			args = append(args, clientID)
+		}
+		clause += " AND p.client_id IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	if len(filter.CustomFields) > 0 {
+		fieldsSQL, fieldsArgs := fieldFilterClause(filter.CustomFields)
+		clause += fieldsSQL
+		args = append(args, fieldsArgs...)
+	}
+
+	return clause, args
+}
+
+// GetOpenTimeBlocks returns every time block that has no end time yet
+func (s *TimeBlockService) GetOpenTimeBlocks() ([]models.TimeBlock, error) {
+	return queryTimeBlocks(s.db, openTimeBlocksQuery)
+}
+
+// openTimeBlocksQuery selects the running time blocks, oldest first
+const openTimeBlocksQuery = `
+	SELECT ` + timeBlockColumns + `
+	FROM time_blocks tb
+	JOIN projects p ON tb.project_id = p.id
+	WHERE tb.end_time IS NULL AND tb.deleted_at IS NULL
+	ORDER BY tb.start_time ASC
+`
+
+// UpdateTimeBlock updates a time block, refusing changes that make it overlap other blocks
+func (s *TimeBlockService) UpdateTimeBlock(id int, req models.UpdateTimeBlockRequest) (*models.TimeBlock, error) {
+	// The check and the write share a transaction, so no block can move into the gap between them
+	tx, err := s.db.Begin()
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
-	var timeBlocks []models.TimeBlock
-	for rows.Next() {
-		var timeBlock models.TimeBlock
-		err := rows.Scan(
-			&timeBlock.ID, &timeBlock.ProjectID, &timeBlock.ProjectName, &timeBlock.StartTime,
-			&timeBlock.EndTime, &timeBlock.Duration, &timeBlock.IsManual, &timeBlock.Description,
-			&timeBlock.CreatedAt, &timeBlock.UpdatedAt,
-		)
+	current, err := getTimeBlock(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.StartTime != nil || req.EndTime != nil {
+		startTime := current.StartTime
+		if req.StartTime != nil {
+			startTime = req.StartTime.In(time.Local)
+		}
+		endTime := current.EndTime
+		if req.EndTime != nil {
+			localEndTime := req.EndTime.In(time.Local)
+			endTime = &localEndTime
+		}
+
+		if err := s.checkOverlaps(tx, startTime, endTime, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := updateTimeBlock(tx, id, req); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetTimeBlockByID(id)
+}
+
+// MoveTimeBlocks reassigns several time blocks to a project in one transaction
+func (s *TimeBlockService) MoveTimeBlocks(req models.MoveTimeBlocksRequest) ([]models.TimeBlock, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for _, id := range req.TimeBlockIDs {
+		if err := updateTimeBlock(tx, id, models.UpdateTimeBlockRequest{ProjectID: &req.ProjectID}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	timeBlocks := make([]models.TimeBlock, 0, len(req.TimeBlockIDs))
+	for _, id := range req.TimeBlockIDs {
+		timeBlock, err := s.GetTimeBlockByID(id)
 		if err != nil {
 			return nil, err
 		}
-		timeBlocks = append(timeBlocks, timeBlock)
+		timeBlocks = append(timeBlocks, *timeBlock)
 	}
 
 	return timeBlocks, nil
 }
 
-// UpdateTimeBlock updates a time block
-func (s *TimeBlockService) UpdateTimeBlock(id int, req models.UpdateTimeBlockRequest) (*models.TimeBlock, error) {
-	setParts := []string{}
-	args := []interface{}{}
+// updateTimeBlock applies the set fields of an update request to a time block and re-derives its duration.
+// Moving the bounds stretches or clips the segments, and drops a previous override unless a new one is given.
+func updateTimeBlock(q querier, id int, req models.UpdateTimeBlockRequest) error {
+	current, err := getTimeBlock(q, id)
+	if err != nil {
+		return err
+	}
 
+	startTime := current.StartTime
 	if req.StartTime != nil {
-		setParts = append(setParts, "start_time = ?")
-		localStartTime := req.StartTime.In(time.Local)
-		args = append(args, localStartTime)
+		startTime = req.StartTime.In(time.Local)
 	}
+	endTime := current.EndTime
 	if req.EndTime != nil {
-		setParts = append(setParts, "end_time = ?")
 		localEndTime := req.EndTime.In(time.Local)
-		args = append(args, localEndTime)
+		endTime = &localEndTime
+	}
+	boundsChanged := !startTime.Equal(current.StartTime) || !sameEnd(endTime, current.EndTime)
+
+	explicit := req.Duration
+	if explicit == nil && !boundsChanged && current.DurationOverridden && !req.ResetDuration {
+		explicit = &current.Duration
+	}
+
+	var worked []interval
+	if len(current.Segments) > 0 && endTime != nil {
+		worked = fitIntervals(workedIntervals(*current), startTime, *endTime)
+	}
+
+	duration, override, err := deriveDuration(startTime, endTime, worked, explicit)
+	if err != nil {
+		return err
+	}
+
+	if req.ProjectID != nil {
+		if err := moveTimeBlock(q, id, *req.ProjectID); err != nil {
+			return err
+		}
+	}
+
+	if boundsChanged && len(current.Segments) > 0 {
+		if endTime != nil {
+			if _, err := q.Exec("DELETE FROM time_block_segments WHERE time_block_id = ?", id); err != nil {
+				return err
+			}
+			if _, err := insertSegments(q, id, worked, true); err != nil {
+				return err
+			}
+		} else if err := fitRunningSegments(q, current.Segments, startTime); err != nil {
+			return err
+		}
+	}
+
+	setParts := []string{"start_time = ?", "end_time = ?", "duration = ?", "duration_override = ?"}
+	args := []interface{}{startTime, endTime, duration, override}
+
+	if req.ProjectID != nil {
+		setParts = append(setParts, "project_id = ?")
+		args = append(args, *req.ProjectID)
 	}
-	if req.Duration != nil {
-		setParts = append(setParts, "duration = ?")
-		args = append(args, *req.Duration)
+	if req.TaskID != nil {
+		if *req.TaskID == 0 {
+			setParts = append(setParts, "task_id = NULL")
+		} else {
+			projectID := current.ProjectID
+			if req.ProjectID != nil {
+				projectID = *req.ProjectID
+			}
+			if err := checkTaskInProject(q, *req.TaskID, projectID); err != nil {
+				return err
+			}
+			setParts = append(setParts, "task_id = ?")
+			args = append(args, *req.TaskID)
+		}
 	}
 	if req.Description != nil {
 		setParts = append(setParts, "description = ?")
 		args = append(args, *req.Description)
 	}
+	if req.ResetBilling {
+		setParts = append(setParts, "billable = NULL", "hourly_rate = NULL")
+	}
+	if req.Billable != nil {
+		setParts = append(setParts, "billable = ?")
+		args = append(args, *req.Billable)
+	}
+	if req.HourlyRate != nil {
+		if *req.HourlyRate < 0 {
+			return ErrNegativeHourlyRate
+		}
+		setParts = append(setParts, "hourly_rate = ?")
+		args = append(args, *req.HourlyRate)
+	}
 
 	setParts = append(setParts, "updated_at = ?")
 	args = append(args, time.Now().In(time.Local))
 	args = append(args, id)
 
-	query := "UPDATE time_blocks SET " + setParts[0]
-	for i := 1; i < len(setParts); i++ {
-		query += ", " + setParts[i]
+	query := "UPDATE time_blocks SET " + strings.Join(setParts, ", ") + " WHERE id = ?"
+
+	_, err = q.Exec(query, args...)
+	return err
+}
+
+// sameEnd reports whether two optional end times are equal
+func sameEnd(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
 	}
-	query += " WHERE id = ?"
+	return a.Equal(*b)
+}
 
-	_, err := s.db.Exec(query, args...)
-	if err != nil {
-		return nil, err
+// fitRunningSegments moves the start of a running block's first segment to a new block start,
+// dropping the closed segments that now end before it
+func fitRunningSegments(q querier, segments []models.TimeBlockSegment, start time.Time) error {
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if !last && segment.EndTime != nil && !segment.EndTime.After(start) {
+			if _, err := q.Exec("DELETE FROM time_block_segments WHERE id = ?", segment.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		_, err := q.Exec("UPDATE time_block_segments SET start_time = ? WHERE id = ?", start, segment.ID)
+		return err
 	}
+	return nil
+}
 
-	return s.GetTimeBlockByID(id)
+// moveTimeBlock checks that the target project exists and moves the timer,
+// pomodoro and cycle records that point at the time block along with it
+func moveTimeBlock(q querier, id, projectID int) error {
+	var existing int
+	if err := q.QueryRow("SELECT id FROM projects WHERE id = ? AND deleted_at IS NULL", projectID).Scan(&existing); err != nil {
+		return err
+	}
+
+	for _, table := range []string{"timer_state", "pomodoro_state", "pomodoro_cycles"} {
+		query := "UPDATE " + table + " SET project_id = ? WHERE time_block_id = ?"
+		if _, err := q.Exec(query, projectID, id); err != nil {
+			return err
+		}
+	}
+
+	// A task belongs to one project, so the block leaves it when moving elsewhere
+	query := "UPDATE time_blocks SET task_id = NULL WHERE id = ? AND task_id IN (SELECT id FROM tasks WHERE project_id != ?)"
+	if _, err := q.Exec(query, id, projectID); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-// DeleteTimeBlock deletes a time block
+// DeleteTimeBlock moves a time block to the trash
 func (s *TimeBlockService) DeleteTimeBlock(id int) error {
-	query := "DELETE FROM time_blocks WHERE id = ?"
-	_, err := s.db.Exec(query, id)
-	return err
+	now := time.Now().In(time.Local)
+	result, err := s.db.Exec("UPDATE time_blocks SET deleted_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL", now, now, id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
 // GetTimeBlocksByDateString returns time blocks for a specific date string
@@ -226,57 +663,1009 @@ func (s *TimeBlockService) GetTimeBlocksByDateRangeString(startDateStr, endDateS
 		}
 	}
 
-	return s.GetTimeBlocksByDateRange(startDate, endDate)
+	return s.GetTimeBlocksByDateRange(startDate, endDate, nil)
 }
 
 // StopRunningTimeBlock stops a running time block by setting end time and calculating duration
 func (s *TimeBlockService) StopRunningTimeBlock(id int) (*models.TimeBlock, error) {
-	endTime := time.Now().In(time.Local)
+	return s.StopTimeBlockAt(id, time.Now().In(time.Local))
+}
 
-	// Get the current time block to calculate duration
-	timeBlock, err := s.GetTimeBlockByID(id)
+// StopTimeBlockAt stops a running time block at the given end time, closing its open segment
+func (s *TimeBlockService) StopTimeBlockAt(id int, endTime time.Time) (*models.TimeBlock, error) {
+	tx, err := s.db.Begin()
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback()
 
-	duration := int(endTime.Sub(timeBlock.StartTime).Seconds())
+	if err := stopTimeBlockAt(tx, id, endTime); err != nil {
+		return nil, err
+	}
 
-	query := "UPDATE time_blocks SET end_time = ?, duration = ?, updated_at = ? WHERE id = ?"
-	_, err = s.db.Exec(query, endTime, duration, endTime, id)
-	if err != nil {
+	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
 	return s.GetTimeBlockByID(id)
 }
 
-// StopTimeBlockWithDuration stops a time block with a specific duration (used for paused timers)
-func (s *TimeBlockService) StopTimeBlockWithDuration(id int, duration int) (*models.TimeBlock, error) {
-	endTime := time.Now().In(time.Local) // Use local timezone
+// stopTimeBlockAt closes a time block and its segments at the given end time, never before its start
+func stopTimeBlockAt(q querier, id int, endTime time.Time) error {
+	endTime = endTime.In(time.Local)
 
-	query := "UPDATE time_blocks SET end_time = ?, duration = ?, updated_at = ? WHERE id = ?"
-	_, err := s.db.Exec(query, endTime, duration, endTime, id)
+	// Get the current time block to calculate duration
+	timeBlock, err := getTimeBlock(q, id)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return s.GetTimeBlockByID(id)
-}
+	if err := trimSegments(q, timeBlock.Segments, endTime); err != nil {
+		return err
+	}
 
-// GetTotalDurationByProject returns the total duration in seconds for a given project
-func (s *TimeBlockService) GetTotalDurationByProject(projectID int) (int, error) {
-	query := `
-		SELECT COALESCE(SUM(duration), 0) FROM time_blocks WHERE project_id = ?
-	`
+	if endTime.Before(timeBlock.StartTime) {
+		endTime = timeBlock.StartTime
+	}
 
-	var total sql.NullInt64
-	err := s.db.QueryRow(query, projectID).Scan(&total)
-	if err != nil {
-		return 0, err
+	var worked []interval
+	if len(timeBlock.Segments) > 0 {
+		// Blocks tracked by the timer only count their active segments
+		worked = clipIntervals(workedIntervals(*timeBlock), timeBlock.StartTime, endTime)
 	}
 
-	if !total.Valid {
-		return 0, nil
+	duration, _, err := deriveDuration(timeBlock.StartTime, &endTime, worked, nil)
+	if err != nil {
+		return err
+	}
+
+	query := "UPDATE time_blocks SET end_time = ?, duration = ?, duration_override = FALSE, updated_at = ? WHERE id = ?"
+	_, err = q.Exec(query, endTime, duration, time.Now().In(time.Local), id)
+	return err
+}
+
+// stopOtherRunningBlocks enforces a single running time block unless parallel timers are enabled
+func (s *TimeBlockService) stopOtherRunningBlocks(q querier, newStart time.Time) error {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return err
+	}
+	if settings.AllowParallelTimers {
+		return nil
+	}
+
+	running, err := queryTimeBlocks(q, openTimeBlocksQuery)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().In(time.Local)
+	for _, timeBlock := range running {
+		// Hand over at the start of the new block, never in the future nor before the old block began
+		end := newStart
+		if end.After(now) {
+			end = now
+		}
+		if end.Before(timeBlock.StartTime) {
+			end = timeBlock.StartTime
+		}
+
+		if err := stopTimeBlockAt(q, timeBlock.ID, end); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordHeartbeat stores the last moment a running time block was known to be tracked
+func (s *TimeBlockService) RecordHeartbeat(id int, at time.Time) error {
+	query := "UPDATE time_blocks SET heartbeat_at = ? WHERE id = ? AND end_time IS NULL"
+	_, err := s.db.Exec(query, at.In(time.Local), id)
+	return err
+}
+
+// StopTimeBlockWithDuration stops a time block now with an explicit duration (used for paused timers)
+func (s *TimeBlockService) StopTimeBlockWithDuration(id int, duration int) (*models.TimeBlock, error) {
+	endTime := time.Now().In(time.Local) // Use local timezone
+
+	timeBlock, err := s.GetTimeBlockByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var worked []interval
+	if len(timeBlock.Segments) > 0 {
+		worked = workedIntervals(*timeBlock)
+	}
+
+	duration, override, err := deriveDuration(timeBlock.StartTime, &endTime, worked, &duration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.EndSegment(id, endTime); err != nil {
+		return nil, err
+	}
+
+	query := "UPDATE time_blocks SET end_time = ?, duration = ?, duration_override = ?, updated_at = ? WHERE id = ?"
+	_, err = s.db.Exec(query, endTime, duration, override, endTime, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTimeBlockByID(id)
+}
+
+// StartSegment opens a new active segment for a time block
+func (s *TimeBlockService) StartSegment(timeBlockID int, at time.Time) error {
+	return startSegment(s.db, timeBlockID, at)
+}
+
+// startSegment opens a segment through q, so it can be part of a larger transaction
+func startSegment(q querier, timeBlockID int, at time.Time) error {
+	query := "INSERT INTO time_block_segments (time_block_id, start_time, created_at) VALUES (?, ?, ?)"
+	_, err := q.Exec(query, timeBlockID, at.In(time.Local), time.Now().In(time.Local))
+	return err
+}
+
+// EndSegment closes the open segment of a time block, if any
+func (s *TimeBlockService) EndSegment(timeBlockID int, at time.Time) error {
+	query := "UPDATE time_block_segments SET end_time = ? WHERE time_block_id = ? AND end_time IS NULL"
+	_, err := s.db.Exec(query, at.In(time.Local), timeBlockID)
+	return err
+}
+
+// trimSegments closes the segments of a time block at the given time, dropping those that start after it
+func trimSegments(q querier, segments []models.TimeBlockSegment, at time.Time) error {
+	at = at.In(time.Local)
+
+	for _, segment := range segments {
+		if !segment.StartTime.Before(at) {
+			if _, err := q.Exec("DELETE FROM time_block_segments WHERE id = ?", segment.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		if segment.EndTime == nil || segment.EndTime.After(at) {
+			if _, err := q.Exec("UPDATE time_block_segments SET end_time = ? WHERE id = ?", at, segment.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetSegmentsByTimeBlock returns the segments of a time block in chronological order
+func (s *TimeBlockService) GetSegmentsByTimeBlock(timeBlockID int) ([]models.TimeBlockSegment, error) {
+	query := `
+		SELECT id, time_block_id, start_time, end_time, created_at
+		FROM time_block_segments
+		WHERE time_block_id = ?
+		ORDER BY start_time ASC
+	`
+
+	rows, err := s.db.Query(query, timeBlockID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	segments := []models.TimeBlockSegment{}
+	for rows.Next() {
+		var segment models.TimeBlockSegment
+		err := rows.Scan(&segment.ID, &segment.TimeBlockID, &segment.StartTime, &segment.EndTime, &segment.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+
+	return segments, rows.Err()
+}
+
+// GetSegmentedDuration returns the worked seconds of a time block, counting an open segment up to until
+func (s *TimeBlockService) GetSegmentedDuration(timeBlockID int, until time.Time) (int, error) {
+	segments, err := s.GetSegmentsByTimeBlock(timeBlockID)
+	if err != nil {
+		return 0, err
+	}
+
+	return segmentsDuration(segments, until), nil
+}
+
+// attachSegments loads the segments of the given time blocks and derives their paused duration
+func attachSegments(q querier, timeBlocks []models.TimeBlock) error {
+	if len(timeBlocks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(timeBlocks))
+	args := make([]interface{}, len(timeBlocks))
+	index := make(map[int]int, len(timeBlocks))
+	for i := range timeBlocks {
+		placeholders[i] = "?"
+		args[i] = timeBlocks[i].ID
+		index[timeBlocks[i].ID] = i
+		timeBlocks[i].Segments = []models.TimeBlockSegment{}
+	}
+
+	query := `
+		SELECT id, time_block_id, start_time, end_time, created_at
+		FROM time_block_segments
+		WHERE time_block_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY start_time ASC
+	`
+
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var segment models.TimeBlockSegment
+		err := rows.Scan(&segment.ID, &segment.TimeBlockID, &segment.StartTime, &segment.EndTime, &segment.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if i, ok := index[segment.TimeBlockID]; ok {
+			timeBlocks[i].Segments = append(timeBlocks[i].Segments, segment)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range timeBlocks {
+		timeBlock := &timeBlocks[i]
+		if timeBlock.EndTime == nil || len(timeBlock.Segments) == 0 {
+			continue
+		}
+		span := int(timeBlock.EndTime.Sub(timeBlock.StartTime).Seconds())
+		paused := span - segmentsDuration(timeBlock.Segments, *timeBlock.EndTime)
+		if paused > 0 {
+			timeBlock.PausedDuration = paused
+		}
+	}
+
+	return nil
+}
+
+// segmentsDuration sums the length of the segments, counting an open segment up to until
+func segmentsDuration(segments []models.TimeBlockSegment, until time.Time) int {
+	total := 0
+	for _, segment := range segments {
+		end := until
+		if segment.EndTime != nil {
+			end = *segment.EndTime
+		}
+		if end.After(segment.StartTime) {
+			total += int(end.Sub(segment.StartTime).Seconds())
+		}
+	}
+	return total
+}
+
+// RepairDurations brings stored durations of stopped time blocks back in line with the duration rule
+// and returns how many blocks were changed. Blocks ending before they start are closed at their start
+// plus their stored duration. A duration shorter than the interval of a block without segments predates
+// pause tracking, so it is kept as an override instead of being inflated.
+func (s *TimeBlockService) RepairDurations() (int, error) {
+	query := `
+		SELECT ` + timeBlockColumns + `
+		FROM time_blocks tb
+		JOIN projects p ON tb.project_id = p.id
+		WHERE tb.end_time IS NOT NULL AND tb.deleted_at IS NULL
+	`
+
+	timeBlocks, err := queryTimeBlocks(s.db, query)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	repaired := 0
+	for _, timeBlock := range timeBlocks {
+		endTime := *timeBlock.EndTime
+		if endTime.Before(timeBlock.StartTime) {
+			endTime = timeBlock.StartTime
+			if timeBlock.Duration > 0 {
+				endTime = endTime.Add(time.Duration(timeBlock.Duration) * time.Second)
+			}
+		}
+
+		var worked []interval
+		if len(timeBlock.Segments) > 0 {
+			worked = clipIntervals(workedIntervals(timeBlock), timeBlock.StartTime, endTime)
+		}
+
+		span := int(endTime.Sub(timeBlock.StartTime).Seconds())
+		explicit := &timeBlock.Duration
+		if timeBlock.Duration < 0 || timeBlock.Duration > span {
+			explicit = nil
+		} else if !timeBlock.DurationOverridden && (worked != nil || timeBlock.Duration == 0) {
+			explicit = nil
+		}
+
+		duration, override, err := deriveDuration(timeBlock.StartTime, &endTime, worked, explicit)
+		if err != nil {
+			return 0, err
+		}
+		if duration == timeBlock.Duration && override == timeBlock.DurationOverridden && endTime.Equal(*timeBlock.EndTime) {
+			continue
+		}
+
+		query := "UPDATE time_blocks SET end_time = ?, duration = ?, duration_override = ? WHERE id = ?"
+		if _, err := tx.Exec(query, endTime, duration, override, timeBlock.ID); err != nil {
+			return 0, err
+		}
+		repaired++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return repaired, nil
+}
+
+// GetTimeBlockHistory returns every recorded change of a time block, oldest first, including after it was
+// deleted. Each change lists the columns it altered, not counting the updated_at timestamp.
+func (s *TimeBlockService) GetTimeBlockHistory(id int) ([]models.TimeBlockChange, error) {
+	query := `
+		SELECT id, time_block_id, action, before_values, after_values, changed_at
+		FROM time_block_history
+		WHERE time_block_id = ?
+		ORDER BY id ASC
+	`
+
+	rows, err := s.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []models.TimeBlockChange{}
+	for rows.Next() {
+		var change models.TimeBlockChange
+		var before, after sql.NullString
+		if err := rows.Scan(&change.ID, &change.TimeBlockID, &change.Action, &before, &after, &change.ChangedAt); err != nil {
+			return nil, err
+		}
+		if before.Valid {
+			if err := json.Unmarshal([]byte(before.String), &change.Before); err != nil {
+				return nil, err
+			}
+		}
+		if after.Valid {
+			if err := json.Unmarshal([]byte(after.String), &change.After); err != nil {
+				return nil, err
+			}
+		}
+
+		change.Changed = []string{}
+		if change.Before != nil && change.After != nil {
+			for column, value := range change.After {
+				if column != "updated_at" && change.Before[column] != value {
+					change.Changed = append(change.Changed, column)
+				}
+			}
+			sort.Strings(change.Changed)
+		}
+
+		history = append(history, change)
+	}
+
+	return history, rows.Err()
+}
+
+// GetTotalDurationByProject returns the total duration in seconds for a given project
+func (s *TimeBlockService) GetTotalDurationByProject(projectID int) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(duration), 0) FROM time_blocks WHERE project_id = ? AND deleted_at IS NULL
+	`
+
+	var total sql.NullInt64
+	err := s.db.QueryRow(query, projectID).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	if !total.Valid {
+		return 0, nil
 	}
 	return int(total.Int64), nil
 }
+
+// interval is a stretch of time between two instants
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// blockEnd returns the end of a time block, treating a running block as ending now
+func blockEnd(timeBlock models.TimeBlock, now time.Time) time.Time {
+	if timeBlock.EndTime != nil {
+		return *timeBlock.EndTime
+	}
+	return now
+}
+
+// checkOverlaps refuses an interval that overlaps other time blocks. A running interval ignores the other
+// running blocks, which are stopped when it starts; with parallel timers no interval conflicts with them.
+func (s *TimeBlockService) checkOverlaps(q querier, start time.Time, end *time.Time, excludeIDs ...int) error {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return err
+	}
+
+	conflicts, err := findOverlaps(q, 0, start, end, end != nil && !settings.AllowParallelTimers)
+	if err != nil {
+		return err
+	}
+
+	excluded := map[int]bool{}
+	for _, id := range excludeIDs {
+		excluded[id] = true
+	}
+
+	overlapErr := &OverlapError{}
+	for _, conflict := range conflicts {
+		if !excluded[conflict.ID] {
+			overlapErr.TimeBlockIDs = append(overlapErr.TimeBlockIDs, conflict.ID)
+		}
+	}
+	if len(overlapErr.TimeBlockIDs) == 0 {
+		return nil
+	}
+	return overlapErr
+}
+
+// findOverlaps returns the time blocks sharing time with the given interval, in chronological order.
+// Touching blocks do not overlap, and a nil end means the interval is still running.
+func findOverlaps(q querier, excludeID int, start time.Time, end *time.Time, includeOpen bool) ([]models.TimeBlock, error) {
+	now := time.Now().In(time.Local)
+	rangeEnd := now
+	if end != nil {
+		rangeEnd = *end
+	}
+
+	// Times are stored with their offset, so the SQL filter is padded by a day and refined below
+	query := `
+		SELECT ` + timeBlockColumns + `
+		FROM time_blocks tb
+		JOIN projects p ON tb.project_id = p.id
+		WHERE tb.id != ? AND tb.deleted_at IS NULL AND tb.start_time < ? AND (tb.end_time IS NULL OR tb.end_time > ?)
+		ORDER BY tb.start_time ASC
+	`
+
+	candidates, err := queryTimeBlocks(q, query, excludeID, rangeEnd.Add(24*time.Hour), start.Add(-24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	var overlaps []models.TimeBlock
+	for _, candidate := range candidates {
+		if candidate.EndTime == nil && !includeOpen {
+			continue
+		}
+		if candidate.StartTime.Before(rangeEnd) && blockEnd(candidate, now).After(start) {
+			overlaps = append(overlaps, candidate)
+		}
+	}
+
+	return overlaps, nil
+}
+
+// ResolveOverlaps saves a time block and, in the same transaction, trims, splits or merges
+// every block it overlaps so that no time is counted twice
+func (s *TimeBlockService) ResolveOverlaps(req models.ResolveOverlapRequest) (*models.TimeBlock, error) {
+	switch req.Strategy {
+	case models.OverlapTrim, models.OverlapSplit, models.OverlapMerge:
+	default:
+		return nil, ErrInvalidOverlapStrategy
+	}
+	if req.TimeBlockID == nil && req.Create == nil {
+		return nil, ErrInvalidOverlapRequest
+	}
+
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if req.TimeBlockID == nil && req.Create.EndTime == nil {
+		if err := s.stopOtherRunningBlocks(tx, req.Create.StartTime.In(time.Local)); err != nil {
+			return nil, err
+		}
+	}
+
+	var id int
+	if req.TimeBlockID == nil {
+		id, err = insertTimeBlock(tx, *req.Create)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		id = *req.TimeBlockID
+		if req.Update != nil {
+			if err := updateTimeBlock(tx, id, *req.Update); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	kept, err := getTimeBlock(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Running blocks of parallel timers are left alone, as they are by checkOverlaps
+	conflicts, err := findOverlaps(tx, id, kept.StartTime, kept.EndTime, !settings.AllowParallelTimers)
+	if err != nil {
+		return nil, err
+	}
+	for _, conflict := range conflicts {
+		if conflict.EndTime == nil {
+			return nil, ErrTimeBlockRunning
+		}
+	}
+
+	if req.Strategy == models.OverlapMerge {
+		err = mergeTimeBlocks(tx, *kept, conflicts)
+	} else {
+		err = cutTimeBlocks(tx, *kept, conflicts, req.Strategy == models.OverlapSplit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetTimeBlockByID(id)
+}
+
+// SplitTimeBlock splits a stopped time block in two at the given time, both parts keeping the description.
+// The second part is moved to newProjectID, or stays on the same project when it is zero.
+func (s *TimeBlockService) SplitTimeBlock(id int, at time.Time, newProjectID int) ([]models.TimeBlock, error) {
+	at = at.In(time.Local)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	timeBlock, err := getTimeBlock(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if timeBlock.EndTime == nil {
+		return nil, ErrTimeBlockRunning
+	}
+	if !at.After(timeBlock.StartTime) || !at.Before(*timeBlock.EndTime) {
+		return nil, ErrSplitOutOfRange
+	}
+
+	second := *timeBlock
+	if newProjectID > 0 {
+		var projectID int
+		if err := tx.QueryRow("SELECT id FROM projects WHERE id = ? AND deleted_at IS NULL", newProjectID).Scan(&projectID); err != nil {
+			return nil, err
+		}
+		if projectID != second.ProjectID {
+			second.ProjectID = projectID
+			second.TaskID = nil
+		}
+	}
+
+	if err := reshapeTimeBlock(tx, *timeBlock, timeBlock.StartTime, at); err != nil {
+		return nil, err
+	}
+	secondID, err := insertTimeBlockPart(tx, second, at, *timeBlock.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	parts := make([]models.TimeBlock, 0, 2)
+	for _, partID := range []int{id, secondID} {
+		part, err := s.GetTimeBlockByID(partID)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, *part)
+	}
+
+	return parts, nil
+}
+
+// MergeTimeBlocks merges stopped time blocks of one project into the earliest of them.
+// Gaps between the blocks are kept as pauses, and the merged span may not overlap other blocks.
+func (s *TimeBlockService) MergeTimeBlocks(ids []int) (*models.TimeBlock, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	seen := map[int]bool{}
+	timeBlocks := []models.TimeBlock{}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		timeBlock, err := getTimeBlock(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if timeBlock.EndTime == nil {
+			return nil, ErrTimeBlockRunning
+		}
+		if len(timeBlocks) > 0 && timeBlock.ProjectID != timeBlocks[0].ProjectID {
+			return nil, ErrMergeProjectMismatch
+		}
+		timeBlocks = append(timeBlocks, *timeBlock)
+	}
+	if len(timeBlocks) < 2 {
+		return nil, ErrMergeTooFew
+	}
+
+	sort.Slice(timeBlocks, func(i, j int) bool {
+		return timeBlocks[i].StartTime.Before(timeBlocks[j].StartTime)
+	})
+
+	start, end := timeBlocks[0].StartTime, *timeBlocks[0].EndTime
+	for _, timeBlock := range timeBlocks[1:] {
+		if timeBlock.EndTime.After(end) {
+			end = *timeBlock.EndTime
+		}
+	}
+	if err := s.checkOverlaps(tx, start, &end, ids...); err != nil {
+		return nil, err
+	}
+
+	if err := mergeTimeBlocks(tx, timeBlocks[0], timeBlocks[1:]); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetTimeBlockByID(timeBlocks[0].ID)
+}
+
+// cutTimeBlocks removes the stretch covered by the kept block from the conflicting blocks.
+// Blocks spanning the whole kept block lose their tail, or are split in two when split is set.
+func cutTimeBlocks(q querier, kept models.TimeBlock, conflicts []models.TimeBlock, split bool) error {
+	keptEnd := blockEnd(kept, time.Now().In(time.Local))
+
+	for _, conflict := range conflicts {
+		conflictEnd := *conflict.EndTime
+		before := conflict.StartTime.Before(kept.StartTime)
+		after := conflictEnd.After(keptEnd)
+
+		var err error
+		switch {
+		case before && after && split:
+			if err = reshapeTimeBlock(q, conflict, conflict.StartTime, kept.StartTime); err != nil {
+				return err
+			}
+			_, err = insertTimeBlockPart(q, conflict, keptEnd, conflictEnd)
+		case before:
+			err = reshapeTimeBlock(q, conflict, conflict.StartTime, kept.StartTime)
+		case after:
+			err = reshapeTimeBlock(q, conflict, keptEnd, conflictEnd)
+		default:
+			err = deleteTimeBlock(q, conflict.ID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeTimeBlocks folds the other blocks into the kept one, which grows to cover all of them.
+// Gaps between the worked stretches are kept as pauses so the duration never counts time twice.
+func mergeTimeBlocks(q querier, kept models.TimeBlock, others []models.TimeBlock) error {
+	if kept.EndTime == nil {
+		return ErrTimeBlockRunning
+	}
+
+	start, end := kept.StartTime, *kept.EndTime
+	worked := workedIntervals(kept)
+	descriptions := []string{}
+	seen := map[string]bool{}
+	for _, timeBlock := range append([]models.TimeBlock{kept}, others...) {
+		if timeBlock.Description == nil {
+			continue
+		}
+		description := strings.TrimSpace(*timeBlock.Description)
+		if description != "" && !seen[description] {
+			seen[description] = true
+			descriptions = append(descriptions, description)
+		}
+	}
+
+	for _, other := range others {
+		if other.EndTime == nil {
+			return ErrTimeBlockRunning
+		}
+		if other.StartTime.Before(start) {
+			start = other.StartTime
+		}
+		if other.EndTime.After(end) {
+			end = *other.EndTime
+		}
+		worked = append(worked, workedIntervals(other)...)
+
+		if err := copyTimeBlockTags(q, other.ID, kept.ID); err != nil {
+			return err
+		}
+		if err := copyTimeBlockFields(q, other.ID, kept.ID); err != nil {
+			return err
+		}
+		if err := deleteTimeBlock(q, other.ID); err != nil {
+			return err
+		}
+	}
+
+	worked = unionIntervals(worked)
+
+	if _, err := q.Exec("DELETE FROM time_block_segments WHERE time_block_id = ?", kept.ID); err != nil {
+		return err
+	}
+	gapless := len(worked) == 1 && worked[0].start.Equal(start) && worked[0].end.Equal(end)
+	duration, err := insertSegments(q, kept.ID, worked, !gapless)
+	if err != nil {
+		return err
+	}
+
+	// An overridden duration is not derived from the interval, so the merged block keeps the sum of the parts
+	override, sum := false, 0
+	for _, timeBlock := range append([]models.TimeBlock{kept}, others...) {
+		override = override || timeBlock.DurationOverridden
+		sum += timeBlock.Duration
+	}
+	if override {
+		duration = sum
+	}
+
+	var description *string
+	if len(descriptions) > 0 {
+		joined := strings.Join(descriptions, "; ")
+		description = &joined
+	}
+
+	query := `
+		UPDATE time_blocks SET start_time = ?, end_time = ?, duration = ?, duration_override = ?, description = ?, updated_at = ?
+		WHERE id = ?
+	`
+	_, err = q.Exec(query, start, end, duration, override, description, time.Now().In(time.Local), kept.ID)
+	return err
+}
+
+// reshapeTimeBlock moves the bounds of a closed time block, clipping its segments and recomputing its duration
+func reshapeTimeBlock(q querier, timeBlock models.TimeBlock, start, end time.Time) error {
+	duration := int(end.Sub(start).Seconds())
+	if len(timeBlock.Segments) > 0 {
+		if _, err := q.Exec("DELETE FROM time_block_segments WHERE time_block_id = ?", timeBlock.ID); err != nil {
+			return err
+		}
+		var err error
+		duration, err = insertSegments(q, timeBlock.ID, clipIntervals(workedIntervals(timeBlock), start, end), true)
+		if err != nil {
+			return err
+		}
+	}
+
+	duration, override := partDuration(timeBlock, duration)
+
+	query := "UPDATE time_blocks SET start_time = ?, end_time = ?, duration = ?, duration_override = ?, updated_at = ? WHERE id = ?"
+	_, err := q.Exec(query, start, end, duration, override, time.Now().In(time.Local), timeBlock.ID)
+	return err
+}
+
+// insertTimeBlockPart creates a copy of a closed time block limited to the given bounds
+func insertTimeBlockPart(q querier, timeBlock models.TimeBlock, start, end time.Time) (int, error) {
+	id, err := insertTimeBlock(q, models.CreateTimeBlockRequest{
+		ProjectID:   timeBlock.ProjectID,
+		TaskID:      timeBlock.TaskID,
+		StartTime:   start,
+		EndTime:     &end,
+		IsManual:    timeBlock.IsManual,
+		Description: timeBlock.Description,
+		Billable:    timeBlock.Billable,
+		HourlyRate:  timeBlock.HourlyRate,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := copyTimeBlockTags(q, timeBlock.ID, id); err != nil {
+		return 0, err
+	}
+	if err := copyTimeBlockFields(q, timeBlock.ID, id); err != nil {
+		return 0, err
+	}
+
+	if len(timeBlock.Segments) == 0 && !timeBlock.DurationOverridden {
+		return id, nil
+	}
+
+	duration := int(end.Sub(start).Seconds())
+	if len(timeBlock.Segments) > 0 {
+		duration, err = insertSegments(q, id, clipIntervals(workedIntervals(timeBlock), start, end), true)
+		if err != nil {
+			return 0, err
+		}
+	}
+	duration, override := partDuration(timeBlock, duration)
+	if _, err := q.Exec("UPDATE time_blocks SET duration = ?, duration_override = ? WHERE id = ?", duration, override, id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// partDuration returns the duration of a part of a time block that worked the given seconds. An overridden
+// duration is shared out in proportion to the worked time, so the parts still add up to the override.
+func partDuration(timeBlock models.TimeBlock, worked int) (int, bool) {
+	if !timeBlock.DurationOverridden {
+		return worked, false
+	}
+
+	total := 0
+	for _, interval := range workedIntervals(timeBlock) {
+		total += int(interval.end.Sub(interval.start).Seconds())
+	}
+	if total <= 0 {
+		return timeBlock.Duration, true
+	}
+	return int(math.Round(float64(timeBlock.Duration) * float64(worked) / float64(total))), true
+}
+
+// deleteTimeBlock deletes a time block with its segments, Pomodoro cycles, tag links and custom field values.
+// Foreign keys are not enforced, so the schema's cascades never run and every child row is removed here.
+func deleteTimeBlock(q querier, id int) error {
+	for _, query := range []string{
+		"DELETE FROM time_block_segments WHERE time_block_id = ?",
+		"DELETE FROM pomodoro_cycles WHERE time_block_id = ?",
+		"DELETE FROM time_block_tags WHERE time_block_id = ?",
+		"DELETE FROM time_block_field_values WHERE time_block_id = ?",
+		"DELETE FROM time_blocks WHERE id = ?",
+	} {
+		if _, err := q.Exec(query, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyTimeBlockTags gives a time block every tag of another one
+func copyTimeBlockTags(q querier, fromID, toID int) error {
+	query := "INSERT OR IGNORE INTO time_block_tags (time_block_id, tag_id) SELECT ?, tag_id FROM time_block_tags WHERE time_block_id = ?"
+	_, err := q.Exec(query, toID, fromID)
+	return err
+}
+
+// insertSegments stores the intervals as segments of a time block when store is set and returns their total seconds
+func insertSegments(q querier, timeBlockID int, intervals []interval, store bool) (int, error) {
+	now := time.Now().In(time.Local)
+	total := 0
+	for _, worked := range intervals {
+		total += int(worked.end.Sub(worked.start).Seconds())
+		if !store {
+			continue
+		}
+		query := "INSERT INTO time_block_segments (time_block_id, start_time, end_time, created_at) VALUES (?, ?, ?, ?)"
+		if _, err := q.Exec(query, timeBlockID, worked.start, worked.end, now); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// workedIntervals returns the stretches a closed time block was worked: its segments, or its whole span
+func workedIntervals(timeBlock models.TimeBlock) []interval {
+	end := blockEnd(timeBlock, time.Now().In(time.Local))
+	if len(timeBlock.Segments) == 0 {
+		return []interval{{start: timeBlock.StartTime, end: end}}
+	}
+
+	intervals := make([]interval, 0, len(timeBlock.Segments))
+	for _, segment := range timeBlock.Segments {
+		segmentEnd := end
+		if segment.EndTime != nil {
+			segmentEnd = *segment.EndTime
+		}
+		intervals = append(intervals, interval{start: segment.StartTime, end: segmentEnd})
+	}
+	return intervals
+}
+
+// fitIntervals clips the intervals to the given bounds and stretches the first and last ones to reach them,
+// so moving a block's start or end moves its outer worked stretches along
+func fitIntervals(intervals []interval, start, end time.Time) []interval {
+	if !end.After(start) {
+		return []interval{}
+	}
+	fitted := clipIntervals(intervals, start, end)
+	if len(fitted) == 0 {
+		return []interval{{start: start, end: end}}
+	}
+	fitted[0].start = start
+	fitted[len(fitted)-1].end = end
+	return fitted
+}
+
+// intervalsDuration sums the length of the intervals in seconds
+func intervalsDuration(intervals []interval) int {
+	total := 0
+	for _, worked := range intervals {
+		total += int(worked.end.Sub(worked.start).Seconds())
+	}
+	return total
+}
+
+// clipIntervals limits the intervals to the given bounds, dropping those left empty
+func clipIntervals(intervals []interval, start, end time.Time) []interval {
+	clipped := []interval{}
+	for _, worked := range intervals {
+		if worked.start.Before(start) {
+			worked.start = start
+		}
+		if worked.end.After(end) {
+			worked.end = end
+		}
+		if worked.end.After(worked.start) {
+			clipped = append(clipped, worked)
+		}
+	}
+	return clipped
+}
+
+// unionIntervals merges overlapping or touching intervals into a sorted, disjoint list
+func unionIntervals(intervals []interval) []interval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+
+	union := []interval{}
+	for _, worked := range intervals {
+		if !worked.end.After(worked.start) {
+			continue
+		}
+		last := len(union) - 1
+		if last >= 0 && !worked.start.After(union[last].end) {
+			if worked.end.After(union[last].end) {
+				union[last].end = worked.end
+			}
+			continue
+		}
+		union = append(union, worked)
+	}
+	return union
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ThinkTimerV2/internal/database"
+	"ThinkTimerV2/internal/models"
+)
+
+// serviceFixture is an empty database with the services the tests drive and a single project
+type serviceFixture struct {
+	db        *sql.DB
+	timeBlock *TimeBlockService
+	timer     *TimerService
+	trash     *TrashService
+	projectID int
+	day       time.Time // Midnight of a past day the tests place their blocks on
+}
+
+func newServiceFixture(t *testing.T) *serviceFixture {
+	t.Helper()
+
+	db, err := database.Open(filepath.Join(t.TempDir(), "thinktimer.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	conn := db.GetConnection()
+	settingsService := NewSettingsService(conn)
+	f := &serviceFixture{
+		db:        conn,
+		timeBlock: NewTimeBlockService(conn, settingsService),
+	}
+	f.timer = NewTimerService(conn, f.timeBlock)
+	f.trash = NewTrashService(conn, settingsService, f.timeBlock)
+
+	project, err := NewProjectService(conn).CreateProject(models.CreateProjectRequest{Name: "Website"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.projectID = project.ID
+
+	yesterday := time.Now().In(time.Local).AddDate(0, 0, -1)
+	f.day = time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, time.Local)
+
+	return f
+}
+
+// at returns the fixture day at the given hour and minute
+func (f *serviceFixture) at(hour, minute int) time.Time {
+	return f.day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+// createBlock creates a stopped block on the fixture project; a zero duration is derived from the interval
+func (f *serviceFixture) createBlock(t *testing.T, start, end time.Time, duration int) *models.TimeBlock {
+	t.Helper()
+
+	timeBlock, err := f.timeBlock.CreateTimeBlock(models.CreateTimeBlockRequest{
+		ProjectID: f.projectID,
+		StartTime: start,
+		EndTime:   &end,
+		Duration:  duration,
+		IsManual:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return timeBlock
+}
+
+func TestSplitTimeBlockDuration(t *testing.T) {
+	tests := []struct {
+		name         string
+		duration     int // Overridden duration of the 3h block, zero when derived
+		splitMinutes int // Minutes after the start to split at
+		want         [2]int
+		wantOverride bool
+	}{
+		{name: "derived", splitMinutes: 90, want: [2]int{5400, 5400}},
+		{name: "overridden in the middle", duration: 7200, splitMinutes: 90, want: [2]int{3600, 3600}, wantOverride: true},
+		{name: "overridden after an hour", duration: 7200, splitMinutes: 60, want: [2]int{2400, 4800}, wantOverride: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t)
+			timeBlock := f.createBlock(t, f.at(9, 0), f.at(12, 0), tt.duration)
+
+			parts, err := f.timeBlock.SplitTimeBlock(timeBlock.ID, f.at(9, tt.splitMinutes), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(parts) != 2 {
+				t.Fatalf("split into %d parts, want 2", len(parts))
+			}
+			for i, part := range parts {
+				if part.Duration != tt.want[i] || part.DurationOverridden != tt.wantOverride {
+					t.Errorf("part %d duration = %d (overridden %t), want %d (overridden %t)",
+						i, part.Duration, part.DurationOverridden, tt.want[i], tt.wantOverride)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeTimeBlocksDuration(t *testing.T) {
+	tests := []struct {
+		name         string
+		durations    [2]int // Overridden durations of the two 1h blocks, zero when derived
+		want         int
+		wantOverride bool
+	}{
+		{name: "derived", want: 7200},
+		{name: "one overridden", durations: [2]int{1800, 0}, want: 5400, wantOverride: true},
+		{name: "both overridden", durations: [2]int{1800, 2700}, want: 4500, wantOverride: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t)
+			first := f.createBlock(t, f.at(9, 0), f.at(10, 0), tt.durations[0])
+			second := f.createBlock(t, f.at(11, 0), f.at(12, 0), tt.durations[1])
+
+			merged, err := f.timeBlock.MergeTimeBlocks([]int{first.ID, second.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if merged.Duration != tt.want || merged.DurationOverridden != tt.wantOverride {
+				t.Errorf("merged duration = %d (overridden %t), want %d (overridden %t)",
+					merged.Duration, merged.DurationOverridden, tt.want, tt.wantOverride)
+			}
+			if !merged.StartTime.Equal(f.at(9, 0)) || merged.EndTime == nil || !merged.EndTime.Equal(f.at(12, 0)) {
+				t.Errorf("merged span = %v to %v, want 09:00 to 12:00", merged.StartTime, merged.EndTime)
+			}
+		})
+	}
+}
+
+// span is the bounds and duration of a stopped block in hours and minutes of the fixture day
+type span struct {
+	start, end string
+	duration   int
+}
+
+// daySpans returns the blocks of the fixture day in start order
+func (f *serviceFixture) daySpans(t *testing.T) []span {
+	t.Helper()
+
+	timeBlocks, err := f.timeBlock.GetTimeBlocksByDate(f.day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans := make([]span, len(timeBlocks))
+	for i, timeBlock := range timeBlocks {
+		// The day's blocks come latest first
+		spans[len(timeBlocks)-1-i] = span{timeBlock.StartTime.Format("15:04"), timeBlock.EndTime.Format("15:04"), timeBlock.Duration}
+	}
+	return spans
+}
+
+func TestResolveOverlaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy models.OverlapStrategy
+		duration int // Overridden duration of the 09:00 to 12:00 block, zero when derived
+		want     []span
+	}{
+		{name: "trim", strategy: models.OverlapTrim, want: []span{{"09:00", "10:00", 3600}, {"10:00", "11:00", 3600}}},
+		{name: "split", strategy: models.OverlapSplit, want: []span{{"09:00", "10:00", 3600}, {"10:00", "11:00", 3600}, {"11:00", "12:00", 3600}}},
+		{name: "merge", strategy: models.OverlapMerge, want: []span{{"09:00", "12:00", 10800}}},
+		{
+			name: "split overridden", strategy: models.OverlapSplit, duration: 5400,
+			want: []span{{"09:00", "10:00", 1800}, {"10:00", "11:00", 3600}, {"11:00", "12:00", 1800}},
+		},
+		{name: "merge overridden", strategy: models.OverlapMerge, duration: 5400, want: []span{{"09:00", "12:00", 9000}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t)
+			f.createBlock(t, f.at(9, 0), f.at(12, 0), tt.duration)
+
+			end := f.at(11, 0)
+			_, err := f.timeBlock.ResolveOverlaps(models.ResolveOverlapRequest{
+				Create:   &models.CreateTimeBlockRequest{ProjectID: f.projectID, StartTime: f.at(10, 0), EndTime: &end, IsManual: true},
+				Strategy: tt.strategy,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := f.daySpans(t)
+			if len(got) != len(tt.want) {
+				t.Fatalf("blocks = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("block %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOverlappingWritesAreRefused(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(t *testing.T, f *serviceFixture, otherID int) error
+	}{
+		{
+			name: "create",
+			write: func(t *testing.T, f *serviceFixture, otherID int) error {
+				end := f.at(11, 0)
+				_, err := f.timeBlock.CreateTimeBlock(models.CreateTimeBlockRequest{ProjectID: f.projectID, StartTime: f.at(10, 0), EndTime: &end})
+				return err
+			},
+		},
+		{
+			name: "update",
+			write: func(t *testing.T, f *serviceFixture, otherID int) error {
+				start := f.at(11, 30)
+				_, err := f.timeBlock.UpdateTimeBlock(otherID, models.UpdateTimeBlockRequest{StartTime: &start})
+				return err
+			},
+		},
+		{
+			name: "merge",
+			write: func(t *testing.T, f *serviceFixture, otherID int) error {
+				first := f.createBlock(t, f.at(7, 0), f.at(8, 0), 0)
+				_, err := f.timeBlock.MergeTimeBlocks([]int{first.ID, otherID})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t)
+			kept := f.createBlock(t, f.at(9, 0), f.at(12, 0), 0)
+			other := f.createBlock(t, f.at(13, 0), f.at(14, 0), 0)
+
+			var overlapErr *OverlapError
+			if err := tt.write(t, f, other.ID); !errors.As(err, &overlapErr) {
+				t.Fatalf("write = %v, want an overlap error", err)
+			}
+			if len(overlapErr.TimeBlockIDs) != 1 || overlapErr.TimeBlockIDs[0] != kept.ID {
+				t.Errorf("overlapping blocks = %v, want [%d]", overlapErr.TimeBlockIDs, kept.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,684 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+var (
+	// ErrTimerAlreadyActive is returned when starting the timer or resuming an orphaned block while the timer
+	// is running or paused and cannot hand over
+	ErrTimerAlreadyActive = errors.New("timer is already active")
+	// ErrTimerNotRunning is returned when pausing a timer that is not running
+	ErrTimerNotRunning = errors.New("timer is not running")
+	// ErrTimerNotPaused is returned when resuming a timer that is not paused
+	ErrTimerNotPaused = errors.New("timer is not paused")
+	// ErrTimerIdle is returned when stopping or resetting a timer that was never started
+	ErrTimerIdle = errors.New("timer is not active")
+	// ErrNotOrphaned is returned when recovering a time block that is not awaiting recovery
+	ErrNotOrphaned = errors.New("time block is not awaiting recovery")
+	// ErrInvalidRecoveryAction is returned for an unknown recovery action
+	ErrInvalidRecoveryAction = errors.New("invalid recovery action")
+	// ErrNoIdlePeriod is returned when resolving idle time while none is pending
+	ErrNoIdlePeriod = errors.New("no idle period is pending")
+	// ErrInvalidIdleAction is returned for an unknown idle action
+	ErrInvalidIdleAction = errors.New("invalid idle action")
+)
+
+const (
+	// HeartbeatInterval is how often a running timer records that it is still alive
+	HeartbeatInterval = 30 * time.Second
+	// IdleCheckInterval is how often the idle source is polled while a timer runs
+	IdleCheckInterval = 15 * time.Second
+)
+
+// TimerService owns the running timer and persists its state
+type TimerService struct {
+	db               *sql.DB
+	timeBlockService *TimeBlockService
+	mu               sync.Mutex
+	orphaned         map[int]bool       // Time blocks found open at startup, awaiting a recovery decision
+	idlePeriod       *models.IdlePeriod // Idle stretch awaiting a keep, trim or split decision
+}
+
+// NewTimerService creates a new timer service
+func NewTimerService(db *sql.DB, timeBlockService *TimeBlockService) *TimerService {
+	return &TimerService{db: db, timeBlockService: timeBlockService, orphaned: map[int]bool{}}
+}
+
+// GetTimerState returns the current timer state
+func (s *TimerService) GetTimerState() (*models.TimerState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.getState()
+}
+
+// StartTimer starts the timer for a project by opening a new time block.
+// An active timer is stopped first. With parallel timers the timer keeps controlling a single block, so
+// starting it again is refused; other running blocks are stopped from the time block list.
+// The block, its first segment and the timer state are written in one transaction.
+func (s *TimerService) StartTimer(req models.StartTimerRequest) (*models.TimerState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	settings, err := s.timeBlockService.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.AllowParallelTimers {
+		state, err := s.getState()
+		if err != nil {
+			return nil, err
+		}
+		if state.Status != models.TimerIdle {
+			return nil, ErrTimerAlreadyActive
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var stopped []int
+	if !settings.AllowParallelTimers {
+		if stopped, err = s.stopOrphaned(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().In(time.Local)
+
+	id, err := s.timeBlockService.createTimeBlock(tx, models.CreateTimeBlockRequest{
+		ProjectID:   req.ProjectID,
+		TaskID:      req.TaskID,
+		StartTime:   now,
+		Duration:    0,
+		IsManual:    false,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := startSegment(tx, id, now); err != nil {
+		return nil, err
+	}
+
+	if err := setRunning(tx, id, req.ProjectID, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	for _, id := range stopped {
+		delete(s.orphaned, id)
+	}
+
+	return s.getState()
+}
+
+// PauseTimer pauses the running timer
+func (s *TimerService) PauseTimer() (*models.TimerState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.getState()
+	if err != nil {
+		return nil, err
+	}
+	if state.Status != models.TimerRunning {
+		return nil, ErrTimerNotRunning
+	}
+
+	now := time.Now().In(time.Local)
+
+	if err := s.timeBlockService.EndSegment(*state.TimeBlockID, now); err != nil {
+		return nil, err
+	}
+
+	query := "UPDATE timer_state SET status = ?, paused_at = ?, updated_at = ? WHERE id = 1"
+	_, err = s.db.Exec(query, models.TimerPaused, now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getState()
+}
+
+// ResumeTimer resumes a paused timer by opening a new segment
+func (s *TimerService) ResumeTimer() (*models.TimerState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.getState()
+	if err != nil {
+		return nil, err
+	}
+	if state.Status != models.TimerPaused || state.PausedAt == nil {
+		return nil, ErrTimerNotPaused
+	}
+
+	now := time.Now().In(time.Local)
+
+	if err := s.timeBlockService.StartSegment(*state.TimeBlockID, now); err != nil {
+		return nil, err
+	}
+
+	query := "UPDATE timer_state SET status = ?, paused_at = NULL, updated_at = ? WHERE id = 1"
+	_, err = s.db.Exec(query, models.TimerRunning, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getState()
+}
+
+// StopTimer stops the timer and closes its time block with the duration of its segments
+func (s *TimerService) StopTimer() (*models.TimeBlock, error) {
+	return s.StopTimerAt(time.Now())
+}
+
+// StopTimerAt stops the timer and closes its time block at the given end time
+func (s *TimerService) StopTimerAt(endTime time.Time) (*models.TimeBlock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.getState()
+	if err != nil {
+		return nil, err
+	}
+	if state.Status == models.TimerIdle || state.TimeBlockID == nil {
+		return nil, ErrTimerIdle
+	}
+
+	timeBlock, err := s.timeBlockService.StopTimeBlockAt(*state.TimeBlockID, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.clearState(); err != nil {
+		return nil, err
+	}
+	s.idlePeriod = nil
+
+	return timeBlock, nil
+}
+
+// ResetTimer discards the active timer and deletes its time block
+func (s *TimerService) ResetTimer() (*models.TimerState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.getState()
+	if err != nil {
+		return nil, err
+	}
+	if state.Status == models.TimerIdle {
+		return nil, ErrTimerIdle
+	}
+
+	if state.TimeBlockID != nil {
+		if err := s.timeBlockService.DeleteTimeBlock(*state.TimeBlockID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.clearState(); err != nil {
+		return nil, err
+	}
+	s.idlePeriod = nil
+
+	return s.getState()
+}
+
+// DetectOrphanedTimeBlocks marks every open time block as awaiting recovery; call it once on startup
+func (s *TimerService) DetectOrphanedTimeBlocks() ([]models.OrphanedTimeBlock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timeBlocks, err := s.timeBlockService.GetOpenTimeBlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	s.orphaned = map[int]bool{}
+	for _, timeBlock := range timeBlocks {
+		s.orphaned[timeBlock.ID] = true
+	}
+
+	return s.getOrphaned()
+}
+
+// GetOrphanedTimeBlocks returns the time blocks still awaiting a recovery decision
+func (s *TimerService) GetOrphanedTimeBlocks() ([]models.OrphanedTimeBlock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.getOrphaned()
+}
+
+// RecoverTimeBlock resumes, stops at the last heartbeat or discards an orphaned time block
+func (s *TimerService) RecoverTimeBlock(req models.RecoverTimeBlockRequest) (*models.TimerState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.orphaned[req.TimeBlockID] {
+		return nil, ErrNotOrphaned
+	}
+
+	timeBlock, err := s.timeBlockService.GetTimeBlockByID(req.TimeBlockID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.getState()
+	if err != nil {
+		return nil, err
+	}
+	isTimerBlock := state.TimeBlockID != nil && *state.TimeBlockID == timeBlock.ID
+	lastActive := lastActivity(timeBlock)
+
+	switch req.Action {
+	case models.RecoveryResume:
+		err = s.resumeOrphaned(timeBlock, state, isTimerBlock, lastActive)
+	case models.RecoveryStop:
+		_, err = s.timeBlockService.StopTimeBlockAt(timeBlock.ID, lastActive)
+		if err == nil && isTimerBlock {
+			err = s.clearState()
+		}
+	case models.RecoveryDiscard:
+		err = s.timeBlockService.DeleteTimeBlock(timeBlock.ID)
+		if err == nil && isTimerBlock {
+			err = s.clearState()
+		}
+	default:
+		return nil, ErrInvalidRecoveryAction
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	delete(s.orphaned, timeBlock.ID)
+
+	return s.getState()
+}
+
+// Heartbeat records that the running time blocks are still alive
+func (s *TimerService) Heartbeat() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.getState()
+	if err != nil {
+		return err
+	}
+
+	running, err := s.timeBlockService.GetOpenTimeBlocks()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, timeBlock := range running {
+		if s.orphaned[timeBlock.ID] {
+			continue
+		}
+		// A paused timer is not tracking anything
+		if state.Status == models.TimerPaused && state.TimeBlockID != nil && *state.TimeBlockID == timeBlock.ID {
+			continue
+		}
+		if err := s.timeBlockService.RecordHeartbeat(timeBlock.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckIdle records an idle period once the user has been idle for threshold while the timer runs.
+// It returns the period only when it is newly detected, so callers notify the user once.
+func (s *TimerService) CheckIdle(idle, threshold time.Duration) (*models.IdlePeriod, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if threshold <= 0 || idle < threshold {
+		return nil, nil
+	}
+
+	state, err := s.getState()
+	if err != nil {
+		return nil, err
+	}
+	if state.Status != models.TimerRunning || state.TimeBlockID == nil || s.orphaned[*state.TimeBlockID] {
+		return nil, nil
+	}
+	if s.idlePeriod != nil && s.idlePeriod.TimeBlockID == *state.TimeBlockID {
+		return nil, nil
+	}
+
+	now := time.Now().In(time.Local)
+	idleStart := now.Add(-idle)
+	if state.StartTime != nil && idleStart.Before(*state.StartTime) {
+		idleStart = *state.StartTime
+	}
+
+	s.idlePeriod = &models.IdlePeriod{
+		TimeBlockID: *state.TimeBlockID,
+		IdleStart:   idleStart,
+		DetectedAt:  now,
+	}
+
+	period := *s.idlePeriod
+	return &period, nil
+}
+
+// GetIdlePeriod returns the idle period awaiting a decision, if any
+func (s *TimerService) GetIdlePeriod() *models.IdlePeriod {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.idlePeriod == nil {
+		return nil
+	}
+	period := *s.idlePeriod
+	return &period
+}
+
+// ResolveIdle keeps the idle stretch, trims the block at the idle start,
+// or splits the idle stretch into its own block and keeps tracking in a new one
+func (s *TimerService) ResolveIdle(req models.ResolveIdleRequest) (*models.TimerState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.idlePeriod == nil {
+		return nil, ErrNoIdlePeriod
+	}
+	period := *s.idlePeriod
+
+	state, err := s.getState()
+	if err != nil {
+		return nil, err
+	}
+	isTimerBlock := state.TimeBlockID != nil && *state.TimeBlockID == period.TimeBlockID
+
+	switch req.Action {
+	case models.IdleKeep:
+	case models.IdleTrim:
+		if _, err := s.timeBlockService.StopTimeBlockAt(period.TimeBlockID, period.IdleStart); err != nil {
+			return nil, err
+		}
+		if isTimerBlock {
+			if err := s.clearState(); err != nil {
+				return nil, err
+			}
+		}
+	case models.IdleSplit:
+		if err := s.splitIdle(period, state, isTimerBlock); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrInvalidIdleAction
+	}
+
+	s.idlePeriod = nil
+
+	return s.getState()
+}
+
+// splitIdle ends the block at the idle start, logs the idle stretch as a separate block
+// and, if the timer was still running, continues tracking in a new block with the task, billing, tags
+// and custom field values of the old one
+func (s *TimerService) splitIdle(period models.IdlePeriod, state *models.TimerState, isTimerBlock bool) error {
+	now := time.Now().In(time.Local)
+
+	timeBlock, err := s.timeBlockService.StopTimeBlockAt(period.TimeBlockID, period.IdleStart)
+	if err != nil {
+		return err
+	}
+
+	description := "Idle time"
+	_, err = s.timeBlockService.CreateTimeBlock(models.CreateTimeBlockRequest{
+		ProjectID:   timeBlock.ProjectID,
+		StartTime:   period.IdleStart,
+		EndTime:     &now,
+		Duration:    int(now.Sub(period.IdleStart).Seconds()),
+		IsManual:    false,
+		Description: &description,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !isTimerBlock {
+		return nil
+	}
+	if state.Status != models.TimerRunning {
+		return s.clearState()
+	}
+
+	next, err := s.timeBlockService.CreateTimeBlock(models.CreateTimeBlockRequest{
+		ProjectID:   timeBlock.ProjectID,
+		TaskID:      timeBlock.TaskID,
+		StartTime:   now,
+		Duration:    0,
+		IsManual:    false,
+		Description: timeBlock.Description,
+		Billable:    timeBlock.Billable,
+		HourlyRate:  timeBlock.HourlyRate,
+	})
+	if err != nil {
+		return err
+	}
+	if err := copyTimeBlockTags(s.db, timeBlock.ID, next.ID); err != nil {
+		return err
+	}
+	if err := copyTimeBlockFields(s.db, timeBlock.ID, next.ID); err != nil {
+		return err
+	}
+
+	if err := s.timeBlockService.StartSegment(next.ID, now); err != nil {
+		return err
+	}
+
+	return setRunning(s.db, next.ID, next.ProjectID, now)
+}
+
+// stopOrphaned closes the blocks still awaiting recovery at their last activity, before starting a new block
+// would stop them at the current time, and returns their IDs
+func (s *TimerService) stopOrphaned(q querier) ([]int, error) {
+	if len(s.orphaned) == 0 {
+		return nil, nil
+	}
+
+	timeBlocks, err := queryTimeBlocks(q, openTimeBlocksQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var stopped []int
+	for _, timeBlock := range timeBlocks {
+		if !s.orphaned[timeBlock.ID] {
+			continue
+		}
+		if err := stopTimeBlockAt(q, timeBlock.ID, lastActivity(&timeBlock)); err != nil {
+			return nil, err
+		}
+		stopped = append(stopped, timeBlock.ID)
+	}
+
+	return stopped, nil
+}
+
+// resumeOrphaned keeps tracking an orphaned block, treating the time since its last activity as a pause
+func (s *TimerService) resumeOrphaned(timeBlock *models.TimeBlock, state *models.TimerState, isTimerBlock bool, lastActive time.Time) error {
+	if !isTimerBlock && state.Status != models.TimerIdle {
+		return ErrTimerAlreadyActive
+	}
+	if isTimerBlock && state.Status == models.TimerPaused {
+		// Nothing was lost while paused
+		return nil
+	}
+
+	now := time.Now().In(time.Local)
+
+	// Blocks started before segments existed get one covering the tracked time
+	if len(timeBlock.Segments) == 0 {
+		if err := s.timeBlockService.StartSegment(timeBlock.ID, timeBlock.StartTime); err != nil {
+			return err
+		}
+	}
+	if err := s.timeBlockService.EndSegment(timeBlock.ID, lastActive); err != nil {
+		return err
+	}
+	if err := s.timeBlockService.StartSegment(timeBlock.ID, now); err != nil {
+		return err
+	}
+	if err := s.timeBlockService.RecordHeartbeat(timeBlock.ID, now); err != nil {
+		return err
+	}
+
+	if isTimerBlock {
+		return nil
+	}
+
+	return setRunning(s.db, timeBlock.ID, timeBlock.ProjectID, timeBlock.StartTime)
+}
+
+// getOrphaned builds the recovery details of the blocks awaiting a decision
+func (s *TimerService) getOrphaned() ([]models.OrphanedTimeBlock, error) {
+	orphaned := []models.OrphanedTimeBlock{}
+	if len(s.orphaned) == 0 {
+		return orphaned, nil
+	}
+
+	timeBlocks, err := s.timeBlockService.GetOpenTimeBlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.getState()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, timeBlock := range timeBlocks {
+		if !s.orphaned[timeBlock.ID] {
+			continue
+		}
+
+		end := lastActivity(&timeBlock)
+		duration := int(end.Sub(timeBlock.StartTime).Seconds())
+		if len(timeBlock.Segments) > 0 {
+			duration = segmentsDuration(timeBlock.Segments, end)
+		}
+
+		orphaned = append(orphaned, models.OrphanedTimeBlock{
+			TimeBlock:         timeBlock,
+			LastHeartbeat:     timeBlock.HeartbeatAt,
+			RecoveredEndTime:  end,
+			RecoveredDuration: duration,
+			IsTimerBlock:      state.TimeBlockID != nil && *state.TimeBlockID == timeBlock.ID,
+		})
+	}
+
+	return orphaned, nil
+}
+
+// lastActivity returns the latest moment a time block is known to have been tracked
+func lastActivity(timeBlock *models.TimeBlock) time.Time {
+	last := timeBlock.StartTime
+	if timeBlock.HeartbeatAt != nil && timeBlock.HeartbeatAt.After(last) {
+		last = *timeBlock.HeartbeatAt
+	}
+	for _, segment := range timeBlock.Segments {
+		if segment.StartTime.After(last) {
+			last = segment.StartTime
+		}
+		if segment.EndTime != nil && segment.EndTime.After(last) {
+			last = *segment.EndTime
+		}
+	}
+	return last.In(time.Local)
+}
+
+// getState reads the timer state and computes the net elapsed seconds
+func (s *TimerService) getState() (*models.TimerState, error) {
+	query := `
+		SELECT COALESCE(ts.status, 'idle'), ts.time_block_id, ts.project_id, ts.start_time, ts.paused_at,
+		       COALESCE(ts.paused_seconds, 0), ts.updated_at,
+		       EXISTS(SELECT 1 FROM time_blocks tb WHERE tb.id = ts.time_block_id AND tb.end_time IS NULL AND tb.deleted_at IS NULL)
+		FROM timer_state ts
+		WHERE ts.id = 1
+	`
+
+	var state models.TimerState
+	var hasTimeBlock bool
+	err := s.db.QueryRow(query).Scan(
+		&state.Status, &state.TimeBlockID, &state.ProjectID, &state.StartTime,
+		&state.PausedAt, &state.PausedSeconds, &state.UpdatedAt, &hasTimeBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The time block may have been deleted, trashed or stopped elsewhere while the timer was active
+	if state.Status != models.TimerIdle && !hasTimeBlock {
+		if err := s.clearState(); err != nil {
+			return nil, err
+		}
+		return &models.TimerState{Status: models.TimerIdle, UpdatedAt: time.Now().In(time.Local)}, nil
+	}
+
+	if state.Status != models.TimerIdle && state.StartTime != nil && state.TimeBlockID != nil {
+		// While paused, every segment is closed so elapsed time is frozen
+		now := time.Now()
+		elapsed, err := s.timeBlockService.GetSegmentedDuration(*state.TimeBlockID, now)
+		if err != nil {
+			return nil, err
+		}
+
+		until := now
+		if state.Status == models.TimerPaused && state.PausedAt != nil {
+			until = *state.PausedAt
+		}
+
+		state.Elapsed = elapsed
+		state.PausedSeconds = int(until.Sub(*state.StartTime).Seconds()) - elapsed
+		if state.PausedSeconds < 0 {
+			state.PausedSeconds = 0
+		}
+	}
+
+	return &state, nil
+}
+
+// setRunning points the timer at a running time block
+func setRunning(q querier, timeBlockID, projectID int, startTime time.Time) error {
+	query := `
+		UPDATE timer_state
+		SET status = ?, time_block_id = ?, project_id = ?, start_time = ?, paused_at = NULL, paused_seconds = 0, updated_at = ?
+		WHERE id = 1
+	`
+	_, err := q.Exec(query, models.TimerRunning, timeBlockID, projectID, startTime, time.Now().In(time.Local))
+	return err
+}
+
+// clearState puts the timer back to idle
+func (s *TimerService) clearState() error {
+	query := `
+		UPDATE timer_state
+		SET status = ?, time_block_id = NULL, project_id = NULL, start_time = NULL, paused_at = NULL, paused_seconds = 0, updated_at = ?
+		WHERE id = 1
+	`
+	_, err := s.db.Exec(query, models.TimerIdle, time.Now().In(time.Local))
+	return err
+}
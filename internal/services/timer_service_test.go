@@ -0,0 +1,287 @@
+package services
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ThinkTimerV2/internal/database"
+	"ThinkTimerV2/internal/idle"
+	"ThinkTimerV2/internal/models"
+)
+
+// idleFixture is a timer running for an hour on a block with a task, billing override, tag and custom field
+type idleFixture struct {
+	db        *sql.DB
+	timer     *TimerService
+	timeBlock *TimeBlockService
+	source    *idle.Fake
+	blockID   int
+	taskID    int
+	tagID     int
+	fieldID   int
+}
+
+func newIdleFixture(t *testing.T) *idleFixture {
+	t.Helper()
+
+	db, err := database.Open(filepath.Join(t.TempDir(), "thinktimer.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	conn := db.GetConnection()
+	settingsService := NewSettingsService(conn)
+	f := &idleFixture{
+		db:        conn,
+		timeBlock: NewTimeBlockService(conn, settingsService),
+		source:    idle.NewFake(),
+	}
+	f.timer = NewTimerService(conn, f.timeBlock)
+
+	project, err := NewProjectService(conn).CreateProject(models.CreateProjectRequest{Name: "Website"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := NewTaskService(conn).CreateTask(models.CreateTaskRequest{ProjectID: project.ID, Name: "Checkout"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.taskID = task.ID
+
+	description := "Checkout flow"
+	state, err := f.timer.StartTimer(models.StartTimerRequest{ProjectID: project.ID, TaskID: &task.ID, Description: &description})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.blockID = *state.TimeBlockID
+
+	// Move the start back so there is tracked time before the idle stretch
+	start := time.Now().In(time.Local).Add(-time.Hour)
+	for _, query := range []string{
+		"UPDATE time_blocks SET start_time = ? WHERE id = ?",
+		"UPDATE time_block_segments SET start_time = ? WHERE time_block_id = ?",
+	} {
+		if _, err := conn.Exec(query, start, f.blockID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := conn.Exec("UPDATE timer_state SET start_time = ? WHERE id = 1", start); err != nil {
+		t.Fatal(err)
+	}
+
+	billable, rate := true, 95.0
+	if _, err := f.timeBlock.UpdateTimeBlock(f.blockID, models.UpdateTimeBlockRequest{Billable: &billable, HourlyRate: &rate}); err != nil {
+		t.Fatal(err)
+	}
+
+	tag, err := NewTagService(conn).CreateTag(models.CreateTagRequest{Name: "client", Color: "#ff0000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.tagID = tag.ID
+	if _, err := NewTagService(conn).SetTimeBlockTags(f.blockID, []int{tag.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	fieldService := NewCustomFieldService(conn)
+	field, err := fieldService.CreateCustomField(models.CreateCustomFieldRequest{Name: "Ticket", Type: models.FieldText})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.fieldID = field.ID
+	if _, err := fieldService.SetTimeBlockFieldValues(f.blockID, map[int]string{field.ID: "WEB-42"}); err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+// goIdle reports idle time through the fake source and returns the detected idle period
+func (f *idleFixture) goIdle(t *testing.T, d time.Duration) models.IdlePeriod {
+	t.Helper()
+
+	f.source.SetIdle(d)
+	idleTime, err := f.source.IdleTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	period, err := f.timer.CheckIdle(idleTime, 15*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if period == nil {
+		t.Fatal("idle period was not detected")
+	}
+
+	// A second check reports nothing new
+	if again, err := f.timer.CheckIdle(idleTime, 15*time.Minute); err != nil || again != nil {
+		t.Fatalf("repeated idle check = %v, %v; want nil, nil", again, err)
+	}
+
+	return *period
+}
+
+func assertNear(t *testing.T, name string, got, want time.Time) {
+	t.Helper()
+	if diff := got.Sub(want); diff < -time.Second || diff > time.Second {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestCheckIdleBelowThreshold(t *testing.T) {
+	f := newIdleFixture(t)
+
+	f.source.SetIdle(5 * time.Minute)
+	idleTime, _ := f.source.IdleTime()
+	period, err := f.timer.CheckIdle(idleTime, 15*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if period != nil || f.timer.GetIdlePeriod() != nil {
+		t.Fatalf("idle period = %v, want none below the threshold", period)
+	}
+}
+
+func TestResolveIdleKeep(t *testing.T) {
+	f := newIdleFixture(t)
+	f.goIdle(t, 20*time.Minute)
+
+	state, err := f.timer.ResolveIdle(models.ResolveIdleRequest{Action: models.IdleKeep})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Status != models.TimerRunning || *state.TimeBlockID != f.blockID {
+		t.Fatalf("state = %s on block %v, want running on block %d", state.Status, state.TimeBlockID, f.blockID)
+	}
+	if f.timer.GetIdlePeriod() != nil {
+		t.Error("idle period is still pending")
+	}
+
+	timeBlock, err := f.timeBlock.GetTimeBlockByID(f.blockID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeBlock.EndTime != nil {
+		t.Errorf("kept block was stopped at %v", *timeBlock.EndTime)
+	}
+}
+
+func TestResolveIdleTrim(t *testing.T) {
+	f := newIdleFixture(t)
+	period := f.goIdle(t, 20*time.Minute)
+
+	state, err := f.timer.ResolveIdle(models.ResolveIdleRequest{Action: models.IdleTrim})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Status != models.TimerIdle {
+		t.Fatalf("state = %s, want idle", state.Status)
+	}
+	if f.timer.GetIdlePeriod() != nil {
+		t.Error("idle period is still pending")
+	}
+
+	timeBlock, err := f.timeBlock.GetTimeBlockByID(f.blockID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeBlock.EndTime == nil {
+		t.Fatal("trimmed block is still running")
+	}
+	assertNear(t, "end time", *timeBlock.EndTime, period.IdleStart)
+	if want := 40 * 60; timeBlock.Duration < want-2 || timeBlock.Duration > want+2 {
+		t.Errorf("duration = %d, want about %d", timeBlock.Duration, want)
+	}
+}
+
+func TestResolveIdleSplit(t *testing.T) {
+	f := newIdleFixture(t)
+	period := f.goIdle(t, 20*time.Minute)
+
+	state, err := f.timer.ResolveIdle(models.ResolveIdleRequest{Action: models.IdleSplit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Status != models.TimerRunning || state.TimeBlockID == nil || *state.TimeBlockID == f.blockID {
+		t.Fatalf("state = %s on block %v, want running on a new block", state.Status, state.TimeBlockID)
+	}
+	if f.timer.GetIdlePeriod() != nil {
+		t.Error("idle period is still pending")
+	}
+
+	original, err := f.timeBlock.GetTimeBlockByID(f.blockID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if original.EndTime == nil {
+		t.Fatal("original block is still running")
+	}
+	assertNear(t, "original end time", *original.EndTime, period.IdleStart)
+
+	var idleID int
+	err = f.db.QueryRow("SELECT id FROM time_blocks WHERE description = 'Idle time'").Scan(&idleID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idleBlock, err := f.timeBlock.GetTimeBlockByID(idleID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idleBlock.EndTime == nil {
+		t.Fatal("idle block is still running")
+	}
+	assertNear(t, "idle start", idleBlock.StartTime, period.IdleStart)
+	assertNear(t, "idle end", *idleBlock.EndTime, *state.StartTime)
+
+	next, err := f.timeBlock.GetTimeBlockByID(*state.TimeBlockID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.EndTime != nil {
+		t.Fatal("continuation block is not running")
+	}
+	if next.ProjectID != original.ProjectID || next.TaskID == nil || *next.TaskID != f.taskID {
+		t.Errorf("continuation block is on project %d task %v, want project %d task %d", next.ProjectID, next.TaskID, original.ProjectID, f.taskID)
+	}
+	if next.Description == nil || *next.Description != "Checkout flow" {
+		t.Errorf("continuation description = %v, want Checkout flow", next.Description)
+	}
+	if next.Billable == nil || !*next.Billable || next.HourlyRate == nil || *next.HourlyRate != 95 {
+		t.Errorf("continuation billing = %v at %v, want billable at 95", next.Billable, next.HourlyRate)
+	}
+	if len(next.Tags) != 1 || next.Tags[0].ID != f.tagID {
+		t.Errorf("continuation tags = %v, want tag %d", next.Tags, f.tagID)
+	}
+	if next.CustomFields[f.fieldID] != "WEB-42" {
+		t.Errorf("continuation custom fields = %v, want WEB-42", next.CustomFields)
+	}
+}
+
+func TestStopAndResetClearIdlePeriod(t *testing.T) {
+	f := newIdleFixture(t)
+	f.goIdle(t, 20*time.Minute)
+
+	if _, err := f.timer.StopTimer(); err != nil {
+		t.Fatal(err)
+	}
+	if f.timer.GetIdlePeriod() != nil {
+		t.Error("idle period is still pending after stopping the timer")
+	}
+	if _, err := f.timer.ResolveIdle(models.ResolveIdleRequest{Action: models.IdleTrim}); err != ErrNoIdlePeriod {
+		t.Errorf("resolving after stop = %v, want %v", err, ErrNoIdlePeriod)
+	}
+
+	g := newIdleFixture(t)
+	g.goIdle(t, 20*time.Minute)
+
+	if _, err := g.timer.ResetTimer(); err != nil {
+		t.Fatal(err)
+	}
+	if g.timer.GetIdlePeriod() != nil {
+		t.Error("idle period is still pending after resetting the timer")
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"fmt"
+
+	"ThinkTimerV2/internal/models"
+	"ThinkTimerV2/internal/pdf"
+)
+
+// Layout of the PDF timesheet in points
+const (
+	pdfMargin       = 40.0
+	pdfBottom       = pdf.PageHeight - 50
+	pdfRight        = pdf.PageWidth - pdfMargin
+	pdfFontSize     = 9.0
+	pdfLineHeight   = 12.0
+	pdfRowPadding   = 4.0
+	pdfProjectX     = 140.0
+	pdfProjectWidth = 105.0
+	pdfTextX        = 255.0
+	pdfTextWidth    = 235.0
+)
+
+// timesheetPDF lays out a timesheet on A4 pages, moving down the page as rows are added
+type timesheetPDF struct {
+	doc   *pdf.Document
+	sheet *models.Timesheet
+	y     float64
+}
+
+// renderTimesheetPDF renders a timesheet as a PDF document
+func renderTimesheetPDF(sheet *models.Timesheet) ([]byte, error) {
+	w := &timesheetPDF{doc: pdf.New("Timesheet " + formatTimesheetPeriod(sheet)), sheet: sheet}
+	w.newPage()
+
+	w.doc.Text(pdfMargin, w.y+18, pdf.Bold, 20, "Timesheet")
+	subtitle := formatTimesheetPeriod(sheet)
+	if sheet.ClientName != "" {
+		subtitle = sheet.ClientName + " · " + subtitle
+	}
+	w.doc.Text(pdfMargin, w.y+38, pdf.Regular, 11, subtitle)
+	w.y += 60
+
+	if len(sheet.Days) == 0 {
+		w.doc.Text(pdfMargin, w.y+pdfLineHeight, pdf.Regular, 10, "No time was tracked in this period.")
+		return w.doc.Bytes()
+	}
+
+	w.entryHeader()
+	for _, day := range sheet.Days {
+		// A day heading stays on the page of its first entry
+		w.ensureSpace(pdfLineHeight+pdfRowPadding+w.entryHeight(day.Entries[0]), true)
+		w.band(formatTimesheetDate(day.Date), "", 0.95)
+		for _, entry := range day.Entries {
+			w.entry(entry)
+		}
+		w.ensureSpace(pdfLineHeight+pdfRowPadding, true)
+		w.doc.Line(pdfMargin, w.y, pdfRight, w.y, 0.4)
+		w.row(pdf.Bold, "Subtotal "+formatTimesheetDate(day.Date), formatTimesheetDuration(day.Duration))
+		w.y += 6
+	}
+
+	w.y += 12
+	w.ensureSpace(2*(pdfLineHeight+pdfRowPadding), false)
+	w.band("Project", "Duration", 0.9)
+	for _, project := range sheet.Projects {
+		w.ensureSpace(pdfLineHeight+pdfRowPadding, false)
+		w.row(pdf.Regular, project.ProjectName, formatTimesheetDuration(project.Duration))
+	}
+	w.ensureSpace(pdfLineHeight+2*pdfRowPadding, false)
+	w.doc.Line(pdfMargin, w.y, pdfRight, w.y, 1)
+	w.y += 2
+	w.row(pdf.Bold, "Total", formatTimesheetDuration(sheet.Duration))
+
+	return w.doc.Bytes()
+}
+
+// newPage starts a page with its footer
+func (w *timesheetPDF) newPage() {
+	w.doc.AddPage()
+	footer := fmt.Sprintf("Generated %s · Page %d", w.sheet.GeneratedAt.Format("2 Jan 2006 15:04"), w.doc.PageCount())
+	w.doc.TextRight(pdfRight, pdf.PageHeight-25, pdf.Regular, 8, footer)
+	w.y = pdfMargin
+}
+
+// ensureSpace moves to a new page when the next height does not fit, repeating the entry header if asked
+func (w *timesheetPDF) ensureSpace(height float64, entryHeader bool) {
+	if w.y+height <= pdfBottom {
+		return
+	}
+	w.newPage()
+	if entryHeader {
+		w.entryHeader()
+	}
+}
+
+func (w *timesheetPDF) entryHeader() {
+	top := w.y
+	w.doc.FillRect(pdfMargin, top, pdfRight-pdfMargin, pdfLineHeight+pdfRowPadding, 0.9)
+	baseline := top + pdfLineHeight - 1
+	w.doc.Text(pdfMargin+2, baseline, pdf.Bold, pdfFontSize, "Time")
+	w.doc.Text(pdfProjectX, baseline, pdf.Bold, pdfFontSize, "Project")
+	w.doc.Text(pdfTextX, baseline, pdf.Bold, pdfFontSize, "Description")
+	w.doc.TextRight(pdfRight-2, baseline, pdf.Bold, pdfFontSize, "Duration")
+	w.y += pdfLineHeight + pdfRowPadding
+}
+
+// band draws a shaded row with a bold label and an optional value on the right
+func (w *timesheetPDF) band(label, value string, gray float64) {
+	w.doc.FillRect(pdfMargin, w.y, pdfRight-pdfMargin, pdfLineHeight+pdfRowPadding, gray)
+	w.row(pdf.Bold, label, value)
+}
+
+// row draws a label on the left and a value on the right
+func (w *timesheetPDF) row(font pdf.Font, label, value string) {
+	baseline := w.y + pdfLineHeight - 1
+	w.doc.Text(pdfMargin+2, baseline, font, pdfFontSize, label)
+	if value != "" {
+		w.doc.TextRight(pdfRight-2, baseline, font, pdfFontSize, value)
+	}
+	w.y += pdfLineHeight + pdfRowPadding
+}
+
+// entryLines wraps the project and description of an entry to their columns
+func entryLines(entry models.TimesheetEntry) ([]string, []string) {
+	return pdf.WrapText(pdf.Regular, pdfFontSize, entry.ProjectName, pdfProjectWidth),
+		pdf.WrapText(pdf.Regular, pdfFontSize, timesheetEntryText(entry), pdfTextWidth)
+}
+
+func (w *timesheetPDF) entryHeight(entry models.TimesheetEntry) float64 {
+	project, text := entryLines(entry)
+	return float64(max(len(project), len(text)))*pdfLineHeight + pdfRowPadding
+}
+
+// entry draws one time block; long descriptions continue on the next page
+func (w *timesheetPDF) entry(entry models.TimesheetEntry) {
+	project, text := entryLines(entry)
+	lines := max(len(project), len(text))
+
+	for i := 0; i < lines; i++ {
+		w.ensureSpace(pdfLineHeight+pdfRowPadding, true)
+		baseline := w.y + pdfLineHeight - 1
+		if i == 0 {
+			w.doc.Text(pdfMargin+2, baseline, pdf.Regular, pdfFontSize, formatTimesheetClock(entry, w.sheet.TimeFormat))
+			w.doc.TextRight(pdfRight-2, baseline, pdf.Regular, pdfFontSize, formatTimesheetDuration(entry.Duration))
+		}
+		if i < len(project) {
+			w.doc.Text(pdfProjectX, baseline, pdf.Regular, pdfFontSize, project[i])
+		}
+		if i < len(text) {
+			w.doc.Text(pdfTextX, baseline, pdf.Regular, pdfFontSize, text[i])
+		}
+		w.y += pdfLineHeight
+	}
+	w.y += pdfRowPadding
+}
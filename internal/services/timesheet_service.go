@@ -0,0 +1,211 @@
+package services
+
+import (
+	"bytes"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+var (
+	// ErrInvalidTimesheetFormat is returned when a timesheet is requested in an unknown format
+	ErrInvalidTimesheetFormat = errors.New("timesheet format must be html or pdf")
+	// ErrInvalidTimesheetRange is returned when a timesheet ends before it starts
+	ErrInvalidTimesheetRange = errors.New("timesheet end date must not be before its start date")
+)
+
+//go:embed templates/timesheet.html
+var timesheetHTML string
+
+// timesheetTemplate renders a timesheet as a standalone HTML page
+var timesheetTemplate = template.Must(template.New("timesheet").Funcs(template.FuncMap{
+	"duration": formatTimesheetDuration,
+	"date":     formatTimesheetDate,
+	"period":   formatTimesheetPeriod,
+	"clock": func(sheet *models.Timesheet, entry models.TimesheetEntry) string {
+		return formatTimesheetClock(entry, sheet.TimeFormat)
+	},
+	"entryText": timesheetEntryText,
+}).Parse(timesheetHTML))
+
+// TimesheetService builds timesheets of tracked time and renders them for printing or sending
+type TimesheetService struct {
+	db              *sql.DB
+	settingsService *SettingsService
+}
+
+// NewTimesheetService creates a new timesheet service
+func NewTimesheetService(db *sql.DB, settingsService *SettingsService) *TimesheetService {
+	return &TimesheetService{db: db, settingsService: settingsService}
+}
+
+// GetTimesheet returns the stopped time blocks starting in the date range, day by day with subtotals.
+// Running blocks are left out until they are stopped.
+func (s *TimesheetService) GetTimesheet(startDate, endDate time.Time, projectIDs []int) (*models.Timesheet, error) {
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidTimesheetRange
+	}
+
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	projectSQL := ""
+	args := []interface{}{startDate.In(time.Local), endDate.In(time.Local)}
+	if len(projectIDs) > 0 {
+		placeholders := make([]string, len(projectIDs))
+		for i, projectID := range projectIDs {
+			placeholders[i] = "?"
+			args = append(args, projectID)
+		}
+		projectSQL = " AND tb.project_id IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	query := `
+		SELECT tb.start_time, tb.end_time, p.name, (SELECT name FROM tasks WHERE id = tb.task_id), tb.description,
+		       tb.duration, p.client_id, COALESCE(c.name, '')
+		FROM time_blocks tb
+		JOIN projects p ON tb.project_id = p.id
+		LEFT JOIN clients c ON p.client_id = c.id
+		WHERE tb.end_time IS NOT NULL AND tb.deleted_at IS NULL AND tb.start_time >= ? AND tb.start_time <= ?` + projectSQL + `
+		ORDER BY tb.start_time ASC, tb.id ASC
+	`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sheet := &models.Timesheet{
+		StartDate:   startDate.In(time.Local),
+		EndDate:     endDate.In(time.Local),
+		Days:        []models.TimesheetDay{},
+		Projects:    []models.TimesheetProjectTotal{},
+		GeneratedAt: time.Now(),
+		TimeFormat:  settings.TimeFormat,
+	}
+
+	projectTotals := map[string]int{}
+	var clientID *int
+	singleClient := true
+	for rows.Next() {
+		var entry models.TimesheetEntry
+		var entryClientID *int
+		var clientName string
+		err := rows.Scan(&entry.StartTime, &entry.EndTime, &entry.ProjectName, &entry.TaskName, &entry.Description,
+			&entry.Duration, &entryClientID, &clientName)
+		if err != nil {
+			return nil, err
+		}
+		entry.StartTime = entry.StartTime.In(time.Local)
+		entry.EndTime = entry.EndTime.In(time.Local)
+
+		// Only a timesheet whose entries all belong to one client is addressed to it
+		if len(sheet.Days) == 0 {
+			clientID, sheet.ClientName = entryClientID, clientName
+		} else if entryClientID == nil || clientID == nil || *entryClientID != *clientID {
+			singleClient = false
+		}
+
+		day := time.Date(entry.StartTime.Year(), entry.StartTime.Month(), entry.StartTime.Day(), 0, 0, 0, 0, time.Local)
+		if len(sheet.Days) == 0 || !sheet.Days[len(sheet.Days)-1].Date.Equal(day) {
+			sheet.Days = append(sheet.Days, models.TimesheetDay{Date: day})
+		}
+		current := &sheet.Days[len(sheet.Days)-1]
+		current.Entries = append(current.Entries, entry)
+		current.Duration += entry.Duration
+
+		projectTotals[entry.ProjectName] += entry.Duration
+		sheet.Duration += entry.Duration
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if !singleClient {
+		sheet.ClientName = ""
+	}
+
+	for name, duration := range projectTotals {
+		sheet.Projects = append(sheet.Projects, models.TimesheetProjectTotal{ProjectName: name, Duration: duration})
+	}
+	sort.Slice(sheet.Projects, func(i, j int) bool {
+		if sheet.Projects[i].Duration != sheet.Projects[j].Duration {
+			return sheet.Projects[i].Duration > sheet.Projects[j].Duration
+		}
+		return strings.ToLower(sheet.Projects[i].ProjectName) < strings.ToLower(sheet.Projects[j].ProjectName)
+	})
+
+	return sheet, nil
+}
+
+// RenderTimesheet builds the timesheet of a request and renders it in the requested format
+func (s *TimesheetService) RenderTimesheet(req models.TimesheetRequest) ([]byte, error) {
+	if req.Format != models.TimesheetHTML && req.Format != models.TimesheetPDF {
+		return nil, ErrInvalidTimesheetFormat
+	}
+
+	sheet, err := s.GetTimesheet(req.StartDate, req.EndDate, req.ProjectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Format == models.TimesheetPDF {
+		return renderTimesheetPDF(sheet)
+	}
+
+	var buf bytes.Buffer
+	if err := timesheetTemplate.Execute(&buf, sheet); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatTimesheetDuration formats seconds as hours and minutes, such as 7:05
+func formatTimesheetDuration(seconds int) string {
+	minutes := (seconds + 30) / 60
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// formatTimesheetDate formats a day, such as Mon 12 Oct 2026
+func formatTimesheetDate(date time.Time) string {
+	return date.Format("Mon 2 Jan 2006")
+}
+
+// formatTimesheetPeriod formats the date range of a timesheet
+func formatTimesheetPeriod(sheet *models.Timesheet) string {
+	start, end := sheet.StartDate.Format("2 Jan 2006"), sheet.EndDate.Format("2 Jan 2006")
+	if start == end {
+		return start
+	}
+	return start + " – " + end
+}
+
+// formatTimesheetClock formats the start and end of an entry in the 12 or 24 hour format of the settings
+func formatTimesheetClock(entry models.TimesheetEntry, timeFormat string) string {
+	layout := "15:04"
+	if timeFormat == "12" {
+		layout = "3:04 PM"
+	}
+	return entry.StartTime.Format(layout) + " – " + entry.EndTime.Format(layout)
+}
+
+// timesheetEntryText joins the task and description of an entry
+func timesheetEntryText(entry models.TimesheetEntry) string {
+	parts := []string{}
+	if entry.TaskName != nil && *entry.TaskName != "" {
+		parts = append(parts, *entry.TaskName)
+	}
+	if entry.Description != nil && strings.TrimSpace(*entry.Description) != "" {
+		parts = append(parts, strings.TrimSpace(*entry.Description))
+	}
+	return strings.Join(parts, " – ")
+}
@@ -0,0 +1,332 @@
+package services
+
+import (
+	"database/sql"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+// TrashService lists, restores and purges soft-deleted projects and time blocks
+type TrashService struct {
+	db               *sql.DB
+	settingsService  *SettingsService
+	timeBlockService *TimeBlockService
+}
+
+// NewTrashService creates a new trash service
+func NewTrashService(db *sql.DB, settingsService *SettingsService, timeBlockService *TimeBlockService) *TrashService {
+	return &TrashService{db: db, settingsService: settingsService, timeBlockService: timeBlockService}
+}
+
+// GetTrash returns the deleted projects and the time blocks that were deleted on their own, most recent first
+func (s *TrashService) GetTrash() (*models.Trash, error) {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	trash := &models.Trash{
+		Projects:      []models.TrashedProject{},
+		TimeBlocks:    []models.TimeBlock{},
+		RetentionDays: settings.TrashRetentionDays,
+	}
+
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, name ASC
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []models.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := attachProjectTags(s.db, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectLinks(s.db, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectFields(s.db, projects); err != nil {
+		return nil, err
+	}
+	if err := attachProjectMilestones(s.db, projects); err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		trashed := models.TrashedProject{Project: project}
+		query := "SELECT COUNT(*), COALESCE(SUM(duration), 0) FROM time_blocks WHERE project_id = ? AND deleted_at = ?"
+		if err := s.db.QueryRow(query, project.ID, project.DeletedAt).Scan(&trashed.TimeBlockCount, &trashed.Duration); err != nil {
+			return nil, err
+		}
+		trash.Projects = append(trash.Projects, trashed)
+	}
+
+	query = `
+		SELECT ` + timeBlockColumns + `
+		FROM time_blocks tb
+		JOIN projects p ON tb.project_id = p.id
+		WHERE tb.deleted_at IS NOT NULL AND (p.deleted_at IS NULL OR p.deleted_at != tb.deleted_at)
+		ORDER BY tb.deleted_at DESC, tb.start_time DESC
+	`
+
+	timeBlocks, err := queryTimeBlocks(s.db, query)
+	if err != nil {
+		return nil, err
+	}
+	trash.TimeBlocks = append(trash.TimeBlocks, timeBlocks...)
+
+	return trash, nil
+}
+
+// RestoreProjectFromTrash restores a deleted project together with the time blocks that were deleted with it.
+// Blocks deleted while running are stopped at their last recorded activity rather than resumed.
+func (s *TrashService) RestoreProjectFromTrash(id int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var deletedAt time.Time
+	if err := tx.QueryRow("SELECT deleted_at FROM projects WHERE id = ? AND deleted_at IS NOT NULL", id).Scan(&deletedAt); err != nil {
+		return err
+	}
+
+	now := time.Now().In(time.Local)
+	if _, err := tx.Exec("UPDATE time_blocks SET deleted_at = NULL, updated_at = ? WHERE project_id = ? AND deleted_at = ?", now, id, deletedAt); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE projects SET deleted_at = NULL, updated_at = ? WHERE id = ?", now, id); err != nil {
+		return err
+	}
+
+	query := `
+		SELECT ` + timeBlockColumns + `
+		FROM time_blocks tb
+		JOIN projects p ON tb.project_id = p.id
+		WHERE tb.project_id = ? AND tb.end_time IS NULL AND tb.deleted_at IS NULL
+	`
+	running, err := queryTimeBlocks(tx, query, id)
+	if err != nil {
+		return err
+	}
+	for i := range running {
+		if err := stopTimeBlockAt(tx, running[i].ID, lastActivity(&running[i])); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// RestoreTimeBlockFromTrash restores a deleted time block, restoring its project too when that is in the trash.
+// A block deleted while running is stopped at its last recorded activity rather than resumed.
+func (s *TrashService) RestoreTimeBlockFromTrash(id int) (*models.TimeBlock, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var projectID int
+	if err := tx.QueryRow("SELECT project_id FROM time_blocks WHERE id = ? AND deleted_at IS NOT NULL", id).Scan(&projectID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(time.Local)
+	if _, err := tx.Exec("UPDATE time_blocks SET deleted_at = NULL, updated_at = ? WHERE id = ?", now, id); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("UPDATE projects SET deleted_at = NULL, updated_at = ? WHERE id = ? AND deleted_at IS NOT NULL", now, projectID); err != nil {
+		return nil, err
+	}
+
+	timeBlock, err := getTimeBlock(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if timeBlock.EndTime == nil {
+		if err := stopTimeBlockAt(tx, id, lastActivity(timeBlock)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.timeBlockService.GetTimeBlockByID(id)
+}
+
+// PurgeProject permanently deletes a trashed project with its tag links, tasks and all of its time blocks
+func (s *TrashService) PurgeProject(id int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := purgeProject(tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// PurgeTimeBlock permanently deletes a trashed time block
+func (s *TrashService) PurgeTimeBlock(id int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var existing int
+	if err := tx.QueryRow("SELECT id FROM time_blocks WHERE id = ? AND deleted_at IS NOT NULL", id).Scan(&existing); err != nil {
+		return err
+	}
+	if err := deleteTimeBlock(tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// EmptyTrash permanently deletes everything in the trash
+func (s *TrashService) EmptyTrash() error {
+	return s.purge(nil)
+}
+
+// PurgeExpired permanently deletes the items that have been in the trash longer than the retention setting
+// and returns how many projects and time blocks were removed
+func (s *TrashService) PurgeExpired() (int, error) {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return 0, err
+	}
+	if settings.TrashRetentionDays <= 0 {
+		return 0, nil
+	}
+
+	cutoff := time.Now().In(time.Local).AddDate(0, 0, -settings.TrashRetentionDays)
+
+	var count int
+	query := `
+		SELECT (SELECT COUNT(*) FROM projects WHERE deleted_at < ?) +
+		       (SELECT COUNT(*) FROM time_blocks WHERE deleted_at < ?)
+	`
+	if err := s.db.QueryRow(query, cutoff, cutoff).Scan(&count); err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, nil
+	}
+
+	return count, s.purge(&cutoff)
+}
+
+// purge permanently deletes the projects and time blocks trashed before the cutoff, or all of them without one
+func (s *TrashService) purge(cutoff *time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	where, args := "deleted_at IS NOT NULL", []interface{}{}
+	if cutoff != nil {
+		where, args = "deleted_at < ?", []interface{}{*cutoff}
+	}
+
+	projectIDs, err := queryIDs(tx, "SELECT id FROM projects WHERE "+where, args...)
+	if err != nil {
+		return err
+	}
+	for _, id := range projectIDs {
+		if err := purgeProject(tx, id); err != nil {
+			return err
+		}
+	}
+
+	timeBlockIDs, err := queryIDs(tx, "SELECT id FROM time_blocks WHERE "+where, args...)
+	if err != nil {
+		return err
+	}
+	for _, id := range timeBlockIDs {
+		if err := deleteTimeBlock(tx, id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// purgeProject hard-deletes a trashed project and everything that belongs to it
+func purgeProject(q querier, id int) error {
+	var existing int
+	if err := q.QueryRow("SELECT id FROM projects WHERE id = ? AND deleted_at IS NOT NULL", id).Scan(&existing); err != nil {
+		return err
+	}
+
+	timeBlockIDs, err := queryIDs(q, "SELECT id FROM time_blocks WHERE project_id = ?", id)
+	if err != nil {
+		return err
+	}
+	for _, timeBlockID := range timeBlockIDs {
+		if err := deleteTimeBlock(q, timeBlockID); err != nil {
+			return err
+		}
+	}
+
+	for _, query := range []string{
+		"DELETE FROM project_tags WHERE project_id = ?",
+		"DELETE FROM project_links WHERE project_id = ?",
+		"DELETE FROM project_field_values WHERE project_id = ?",
+		"DELETE FROM milestones WHERE project_id = ?",
+		"DELETE FROM tasks WHERE project_id = ?",
+		"DELETE FROM projects WHERE id = ?",
+	} {
+		if _, err := q.Exec(query, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queryIDs collects the integer IDs selected by a query
+func queryIDs(q querier, query string, args ...interface{}) ([]int, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
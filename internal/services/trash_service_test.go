@@ -0,0 +1,146 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"ThinkTimerV2/internal/models"
+)
+
+// createRunningBlock starts a block an hour ago on the fixture project that last reported activity half an hour ago
+func (f *serviceFixture) createRunningBlock(t *testing.T) (*models.TimeBlock, time.Time) {
+	t.Helper()
+
+	now := time.Now().In(time.Local)
+	timeBlock, err := f.timeBlock.CreateTimeBlock(models.CreateTimeBlockRequest{ProjectID: f.projectID, StartTime: now.Add(-time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	heartbeat := now.Add(-30 * time.Minute)
+	if _, err := f.db.Exec("UPDATE time_blocks SET heartbeat_at = ? WHERE id = ?", heartbeat, timeBlock.ID); err != nil {
+		t.Fatal(err)
+	}
+	return timeBlock, heartbeat
+}
+
+func TestRestoreFromTrashStopsRunningBlocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		trash   func(f *serviceFixture, id int) error
+		restore func(f *serviceFixture, id int) error
+	}{
+		{
+			name:  "project",
+			trash: func(f *serviceFixture, id int) error { return NewProjectService(f.db).DeleteProject(f.projectID) },
+			restore: func(f *serviceFixture, id int) error {
+				return f.trash.RestoreProjectFromTrash(f.projectID)
+			},
+		},
+		{
+			name:  "time block",
+			trash: func(f *serviceFixture, id int) error { return f.timeBlock.DeleteTimeBlock(id) },
+			restore: func(f *serviceFixture, id int) error {
+				_, err := f.trash.RestoreTimeBlockFromTrash(id)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t)
+			timeBlock, heartbeat := f.createRunningBlock(t)
+
+			if err := tt.trash(f, timeBlock.ID); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.restore(f, timeBlock.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			restored, err := f.timeBlock.GetTimeBlockByID(timeBlock.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if restored.EndTime == nil {
+				t.Fatal("restored block is still running")
+			}
+			assertNear(t, "end time", *restored.EndTime, heartbeat)
+			if want := 30 * 60; restored.Duration < want-2 || restored.Duration > want+2 {
+				t.Errorf("duration = %d, want about %d", restored.Duration, want)
+			}
+		})
+	}
+}
+
+func TestPurgeRemovesChildRows(t *testing.T) {
+	tests := []struct {
+		name  string
+		trash func(f *serviceFixture, id int) error
+		purge func(f *serviceFixture, id int) error
+	}{
+		{
+			name:  "project",
+			trash: func(f *serviceFixture, id int) error { return NewProjectService(f.db).DeleteProject(f.projectID) },
+			purge: func(f *serviceFixture, id int) error { return f.trash.PurgeProject(f.projectID) },
+		},
+		{
+			name:  "time block",
+			trash: func(f *serviceFixture, id int) error { return f.timeBlock.DeleteTimeBlock(id) },
+			purge: func(f *serviceFixture, id int) error { return f.trash.PurgeTimeBlock(id) },
+		},
+		{
+			name:  "empty trash",
+			trash: func(f *serviceFixture, id int) error { return NewProjectService(f.db).DeleteProject(f.projectID) },
+			purge: func(f *serviceFixture, id int) error { return f.trash.EmptyTrash() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t)
+			timeBlock := f.createBlock(t, f.at(9, 0), f.at(10, 0), 0)
+			query := "INSERT INTO time_block_segments (time_block_id, start_time, end_time) VALUES (?, ?, ?)"
+			if _, err := f.db.Exec(query, timeBlock.ID, f.at(9, 0), f.at(10, 0)); err != nil {
+				t.Fatal(err)
+			}
+
+			tag, err := NewTagService(f.db).CreateTag(models.CreateTagRequest{Name: "client", Color: "#ff0000"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewTagService(f.db).SetTimeBlockTags(timeBlock.ID, []int{tag.ID}); err != nil {
+				t.Fatal(err)
+			}
+			fieldService := NewCustomFieldService(f.db)
+			field, err := fieldService.CreateCustomField(models.CreateCustomFieldRequest{Name: "Ticket", Type: models.FieldText})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := fieldService.SetTimeBlockFieldValues(timeBlock.ID, map[int]string{field.ID: "WEB-42"}); err != nil {
+				t.Fatal(err)
+			}
+			query = "INSERT INTO pomodoro_cycles (time_block_id, project_id, started_at, completed_at) VALUES (?, ?, ?, ?)"
+			if _, err := f.db.Exec(query, timeBlock.ID, f.projectID, f.at(9, 0), f.at(9, 25)); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tt.trash(f, timeBlock.ID); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.purge(f, timeBlock.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, table := range []string{"time_blocks", "time_block_segments", "time_block_tags", "time_block_field_values", "pomodoro_cycles"} {
+				var count int
+				if err := f.db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+					t.Fatal(err)
+				}
+				if count != 0 {
+					t.Errorf("%s keeps %d rows after the purge", table, count)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+
+	"ThinkTimerV2/internal/models"
+)
+
+// CommandLogLimit is how many commands the undo history keeps; older ones can no longer be undone
+const CommandLogLimit = 100
+
+var (
+	// ErrNothingToUndo is returned when the command log has no command left to undo
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrNothingToRedo is returned when no undone command is left to redo
+	ErrNothingToRedo = errors.New("nothing to redo")
+	// ErrCommandConflict is returned when a command no longer applies to the data; it is dropped from the log
+	ErrCommandConflict = errors.New("the change no longer matches the data and was removed from the undo history")
+)
+
+// UndoService records data changes as commands and undoes or redoes them.
+// Database triggers store the SQL reverting each change made while a command is recording; undoing a command
+// runs those statements, which records the statements that redo it in their place.
+type UndoService struct {
+	db *sql.DB
+}
+
+// NewUndoService creates a new undo service
+func NewUndoService(db *sql.DB) *UndoService {
+	return &UndoService{db: db}
+}
+
+// Begin starts recording the changes of a command. Callers serialize commands and must call End.
+func (s *UndoService) Begin(label string) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	if err := tx.QueryRow("INSERT INTO command_log (label, undone, created_at) VALUES (?, FALSE, CURRENT_TIMESTAMP) RETURNING id", label).Scan(&id); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec("UPDATE command_log_state SET recording_id = ? WHERE id = 1", id); err != nil {
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// End stops recording. A command that changed nothing is dropped; otherwise it replaces the redo history,
+// and the log is trimmed to CommandLogLimit commands.
+func (s *UndoService) End(id int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE command_log_state SET recording_id = NULL WHERE id = 1"); err != nil {
+		return err
+	}
+
+	var entries int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM command_log_entries WHERE command_id = ?", id).Scan(&entries); err != nil {
+		return err
+	}
+
+	if entries == 0 {
+		if _, err := tx.Exec("DELETE FROM command_log WHERE id = ?", id); err != nil {
+			return err
+		}
+	} else {
+		if _, err := tx.Exec("DELETE FROM command_log WHERE undone = TRUE"); err != nil {
+			return err
+		}
+		query := "DELETE FROM command_log WHERE id NOT IN (SELECT id FROM command_log ORDER BY id DESC LIMIT ?)"
+		if _, err := tx.Exec(query, CommandLogLimit); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM command_log_entries WHERE command_id NOT IN (SELECT id FROM command_log)"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetUndoState returns the commands that undo and redo would apply next
+func (s *UndoService) GetUndoState() (*models.UndoState, error) {
+	state := &models.UndoState{}
+
+	var err error
+	if state.Undo, err = s.nextCommand(false); err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if state.Redo, err = s.nextCommand(true); err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// Undo reverts the most recent command that is not undone yet
+func (s *UndoService) Undo() (*models.Command, error) {
+	command, err := s.nextCommand(false)
+	if err == sql.ErrNoRows {
+		return nil, ErrNothingToUndo
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.apply(command)
+}
+
+// Redo applies again the earliest command among those undone
+func (s *UndoService) Redo() (*models.Command, error) {
+	command, err := s.nextCommand(true)
+	if err == sql.ErrNoRows {
+		return nil, ErrNothingToRedo
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.apply(command)
+}
+
+// nextCommand returns the command undo would revert, or the one redo would apply when undone is set.
+// Undone commands always follow the others, so both sit at the boundary between the two.
+func (s *UndoService) nextCommand(undone bool) (*models.Command, error) {
+	query := "SELECT id, label, undone, created_at FROM command_log WHERE undone = FALSE ORDER BY id DESC LIMIT 1"
+	if undone {
+		query = "SELECT id, label, undone, created_at FROM command_log WHERE undone = TRUE ORDER BY id ASC LIMIT 1"
+	}
+
+	var command models.Command
+	if err := s.db.QueryRow(query).Scan(&command.ID, &command.Label, &command.Undone, &command.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &command, nil
+}
+
+// apply runs the stored statements of a command in reverse order and keeps the statements this records,
+// which revert it again, so the same command flips between done and undone. Every statement must change
+// exactly one row; one that finds its row edited or gone since the command ran is a conflict.
+func (s *UndoService) apply(command *models.Command) (*models.Command, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var lastEntry int
+	if err := tx.QueryRow("SELECT COALESCE(MAX(id), 0) FROM command_log_entries").Scan(&lastEntry); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query("SELECT statement FROM command_log_entries WHERE command_id = ? ORDER BY id DESC", command.ID)
+	if err != nil {
+		return nil, err
+	}
+	var statements []string
+	for rows.Next() {
+		var statement string
+		if err := rows.Scan(&statement); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec("UPDATE command_log_state SET recording_id = ? WHERE id = 1", command.ID); err != nil {
+		return nil, err
+	}
+	for _, statement := range statements {
+		result, err := tx.Exec(statement)
+		if err == nil {
+			var affected int64
+			if affected, err = result.RowsAffected(); err == nil && affected != 1 {
+				err = ErrCommandConflict
+			}
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, s.discard(command.ID)
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE command_log_state SET recording_id = NULL WHERE id = 1"); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("DELETE FROM command_log_entries WHERE command_id = ? AND id <= ?", command.ID, lastEntry); err != nil {
+		return nil, err
+	}
+
+	command.Undone = !command.Undone
+	if _, err := tx.Exec("UPDATE command_log SET undone = ? WHERE id = ?", command.Undone, command.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+// discard drops a command that can no longer be applied and reports the conflict
+func (s *UndoService) discard(id int) error {
+	for _, query := range []string{
+		"DELETE FROM command_log_entries WHERE command_id = ?",
+		"DELETE FROM command_log WHERE id = ?",
+	} {
+		if _, err := s.db.Exec(query, id); err != nil {
+			return err
+		}
+	}
+	return ErrCommandConflict
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+
+	"ThinkTimerV2/internal/models"
+)
+
+// record runs change while the command log records it, as the app does for its edits
+func record(t *testing.T, undo *UndoService, label string, change func() error) {
+	t.Helper()
+
+	id, err := undo.Begin(label)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = change()
+	if endErr := undo.End(id); err == nil {
+		err = endErr
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// recordCreateProject creates a project as a recorded command
+func (f *serviceFixture) recordCreateProject(t *testing.T, undo *UndoService) *models.Project {
+	t.Helper()
+
+	var project *models.Project
+	record(t, undo, "Create project", func() (err error) {
+		project, err = NewProjectService(f.db).CreateProject(models.CreateProjectRequest{Name: "Mobile app"})
+		return err
+	})
+	return project
+}
+
+func TestUndoCreateProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		since   func(t *testing.T, f *serviceFixture, projectID int) // Unrecorded changes made after the command
+		wantErr error
+	}{
+		{
+			name:  "untouched",
+			since: func(t *testing.T, f *serviceFixture, projectID int) {},
+		},
+		{
+			name: "timer started and stopped on it",
+			since: func(t *testing.T, f *serviceFixture, projectID int) {
+				if _, err := f.timer.StartTimer(models.StartTimerRequest{ProjectID: projectID}); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := f.timer.StopTimer(); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: ErrCommandConflict,
+		},
+		{
+			name: "timer still running on it",
+			since: func(t *testing.T, f *serviceFixture, projectID int) {
+				if _, err := f.timer.StartTimer(models.StartTimerRequest{ProjectID: projectID}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: ErrCommandConflict,
+		},
+		{
+			name: "task added to it",
+			since: func(t *testing.T, f *serviceFixture, projectID int) {
+				if _, err := NewTaskService(f.db).CreateTask(models.CreateTaskRequest{ProjectID: projectID, Name: "Login"}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: ErrCommandConflict,
+		},
+		{
+			name: "renamed",
+			since: func(t *testing.T, f *serviceFixture, projectID int) {
+				name := "Mobile"
+				if _, err := NewProjectService(f.db).UpdateProject(projectID, models.UpdateProjectRequest{Name: &name}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: ErrCommandConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t)
+			undo := NewUndoService(f.db)
+			project := f.recordCreateProject(t, undo)
+			tt.since(t, f, project.ID)
+
+			var blocksBefore int
+			if err := f.db.QueryRow("SELECT COUNT(*) FROM time_blocks").Scan(&blocksBefore); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := undo.Undo(); err != tt.wantErr {
+				t.Fatalf("undo = %v, want %v", err, tt.wantErr)
+			}
+
+			var existing int
+			err := f.db.QueryRow("SELECT id FROM projects WHERE id = ?", project.ID).Scan(&existing)
+			switch {
+			case tt.wantErr == nil && err != sql.ErrNoRows:
+				t.Errorf("project after undo: %v, want it removed", err)
+			case tt.wantErr != nil && err != nil:
+				t.Errorf("project after a conflicting undo: %v, want it kept", err)
+			}
+
+			var blocksAfter int
+			if err := f.db.QueryRow("SELECT COUNT(*) FROM time_blocks").Scan(&blocksAfter); err != nil {
+				t.Fatal(err)
+			}
+			if blocksAfter != blocksBefore {
+				t.Errorf("undo changed the time blocks from %d to %d", blocksBefore, blocksAfter)
+			}
+
+			// A conflict drops the command, and a clean undo leaves it to redo
+			state, err := undo.GetUndoState()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state.Undo != nil {
+				t.Errorf("undo is still offered for %q", state.Undo.Label)
+			}
+			if (state.Redo != nil) != (tt.wantErr == nil) {
+				t.Errorf("redo offered = %t, want %t", state.Redo != nil, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestRedoRestoresUndoneProject(t *testing.T) {
+	f := newServiceFixture(t)
+	undo := NewUndoService(f.db)
+	project := f.recordCreateProject(t, undo)
+
+	if _, err := undo.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := undo.Redo(); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := NewProjectService(f.db).GetProjectByID(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Name != project.Name {
+		t.Errorf("redone project name = %q, want %q", restored.Name, project.Name)
+	}
+}
+
+func TestUndoUpdateSettings(t *testing.T) {
+	f := newServiceFixture(t)
+	undo := NewUndoService(f.db)
+	settingsService := NewSettingsService(f.db)
+
+	theme := "dark"
+	record(t, undo, "Update settings", func() error {
+		_, err := settingsService.UpdateSettings(models.UpdateSettingsRequest{Theme: &theme})
+		return err
+	})
+
+	if _, err := undo.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := settingsService.GetSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Theme != "light" {
+		t.Errorf("theme after undo = %q, want light", settings.Theme)
+	}
+}
+
+func TestUndoMergeRestoresBlocks(t *testing.T) {
+	f := newServiceFixture(t)
+	undo := NewUndoService(f.db)
+	first := f.createBlock(t, f.at(9, 0), f.at(10, 0), 0)
+	second := f.createBlock(t, f.at(11, 0), f.at(12, 0), 1800)
+	query := "INSERT INTO pomodoro_cycles (time_block_id, project_id, started_at, completed_at) VALUES (?, ?, ?, ?)"
+	if _, err := f.db.Exec(query, second.ID, f.projectID, f.at(11, 0), f.at(11, 25)); err != nil {
+		t.Fatal(err)
+	}
+
+	record(t, undo, "Merge time blocks", func() error {
+		_, err := f.timeBlock.MergeTimeBlocks([]int{first.ID, second.ID})
+		return err
+	})
+	if _, err := undo.Undo(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []span{{"09:00", "10:00", 3600}, {"11:00", "12:00", 1800}}
+	got := f.daySpans(t)
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("blocks after undo = %v, want %v", got, want)
+	}
+	var cycles int
+	if err := f.db.QueryRow("SELECT COUNT(*) FROM pomodoro_cycles WHERE time_block_id = ?", second.ID).Scan(&cycles); err != nil {
+		t.Fatal(err)
+	}
+	if cycles != 1 {
+		t.Errorf("Pomodoro cycles of the merged block after undo = %d, want 1", cycles)
+	}
+}